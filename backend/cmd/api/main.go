@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
 	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/handlers"
+	"github.com/baramulti/ticketing-system/backend/internal/jobs"
+	"github.com/baramulti/ticketing-system/backend/internal/payment"
 	"github.com/baramulti/ticketing-system/backend/internal/repositories"
 	"github.com/baramulti/ticketing-system/backend/internal/router"
 	"github.com/baramulti/ticketing-system/backend/internal/services"
+	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
+	"github.com/baramulti/ticketing-system/backend/pkg/ticketpass"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -34,21 +43,60 @@ func main() {
 
 	logger.Info().Msg("database connected")
 
+	// Connect to Redis
+	redisClient, err := cache.NewRedisClient(context.Background(), cfg.Redis)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to connect to redis")
+	}
+	defer redisClient.Close()
+
+	logger.Info().Msg("redis connected")
+
+	// Load token signing keys
+	keys, err := loadSigningKeys(cfg.JWT, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to load jwt signing keys")
+	}
+
+	passSigner, err := initTicketPassSigner(cfg.TicketPass, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to load ticket pass signing keys")
+	}
+
+	gateway, err := initPaymentGateway(cfg.Payment, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to set up payment gateway")
+	}
+
 	// Initialize dependencies
 	repos := initRepositories(db)
-	services := initServices(repos, cfg, logger)
+	stores := initStores(redisClient)
+	services := initServices(repos, stores, keys, passSigner, gateway, cfg, logger)
 	handlers := initHandlers(services)
 
 	// Setup router
 	r := router.Setup(&router.RouterConfig{
-		Config:        cfg,
-		Logger:        logger,
-		AuthHandler:   handlers.auth,
-		EventHandler:  handlers.event,
-		TicketHandler: handlers.ticket,
-		UserHandler:   handlers.user,
+		Config:            cfg,
+		Logger:            logger,
+		AuthService:       services.auth,
+		PermissionService: services.permission,
+		IdempotencyStore:  stores.idempotency,
+		AuthHandler:       handlers.auth,
+		EventHandler:      handlers.event,
+		TicketHandler:     handlers.ticket,
+		UserHandler:       handlers.user,
+		RoleHandler:       handlers.role,
+		PaymentHandler:    handlers.payment,
+		CheckinHandler:    handlers.checkin,
+		SeatHandler:       handlers.seat,
+		VenueHandler:      handlers.venue,
 	})
 
+	// Background jobs stop when the process is asked to exit
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go jobs.NewHoldSweeper(services.ticket, cfg.Checkout.SweepInterval, logger).Run(ctx)
+
 	addr := fmt.Sprintf(":%s", cfg.Server.Port)
 	logger.Info().Str("addr", addr).Str("env", cfg.Server.Env).Msg("server starting")
 
@@ -76,48 +124,149 @@ func connectDB(url string) (*sqlx.DB, error) {
 	//return nil, fmt.Errorf("database connection not implemented")
 }
 
+func loadSigningKeys(cfg config.JWTConfig, logger zerolog.Logger) (*jwtutil.KeySet, error) {
+	if cfg.PrivateKeyFile == "" {
+		logger.Warn().Msg("JWT_PRIVATE_KEY_FILE not set, signing tokens with HS256 shared secret")
+		return jwtutil.NewHMACKeySet(cfg.Secret), nil
+	}
+
+	keys, err := jwtutil.LoadKeySet(cfg.PrivateKeyFile, cfg.PreviousKeyFiles)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info().Str("kid", keys.ActiveKeyID()).Int("previous_keys", len(cfg.PreviousKeyFiles)).Msg("jwt signing keys loaded")
+	return keys, nil
+}
+
+// initTicketPassSigner loads the QR pass signing keys. Outside production a
+// throwaway key is generated when none is configured, which invalidates
+// every pass on restart.
+func initTicketPassSigner(cfg config.TicketPassConfig, logger zerolog.Logger) (*ticketpass.Signer, error) {
+	if cfg.KeyFile == "" {
+		logger.Warn().Msg("TICKET_PASS_KEY_FILE not set, signing ticket passes with an ephemeral key")
+		key, err := jwtutil.GenerateEd25519Key()
+		if err != nil {
+			return nil, err
+		}
+		return ticketpass.NewSigner(key)
+	}
+
+	signer, err := ticketpass.LoadSigner(cfg.KeyFile, cfg.PreviousKeyFiles)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info().Int("previous_keys", len(cfg.PreviousKeyFiles)).Msg("ticket pass signing keys loaded")
+	return signer, nil
+}
+
+// initPaymentGateway builds the configured provider. Only the in-process
+// simulator exists so far.
+func initPaymentGateway(cfg config.PaymentConfig, logger zerolog.Logger) (payment.Gateway, error) {
+	if !payment.ValidSimulatorMode(cfg.SimulatorMode) {
+		return nil, fmt.Errorf("invalid PAYMENT_SIMULATOR_MODE %q", cfg.SimulatorMode)
+	}
+	logger.Warn().Str("mode", cfg.SimulatorMode).Msg("using simulated payment gateway, no real payments are taken")
+	return payment.NewSimulator(payment.SimulatorMode(cfg.SimulatorMode), cfg.SimulatorDelay), nil
+}
+
 type repositoryDeps struct {
-	user   repositories.UserRepository
-	event  repositories.EventRepository
-	ticket repositories.TicketRepository
+	user         repositories.UserRepository
+	event        repositories.EventRepository
+	ticket       repositories.TicketRepository
+	tier         repositories.TicketTierRepository
+	refreshToken repositories.RefreshTokenRepository
+	role         repositories.RoleRepository
+	checkinScan  repositories.CheckinScanRepository
+	seat         repositories.SeatRepository
+	venue        repositories.VenueRepository
+	uow          repositories.UnitOfWork
 }
 
 func initRepositories(db *sqlx.DB) *repositoryDeps {
 	return &repositoryDeps{
-		user:   repositories.NewUserRepository(db),
-		event:  repositories.NewEventRepository(db),
-		ticket: repositories.NewTicketRepository(db),
+		user:         repositories.NewUserRepository(db),
+		event:        repositories.NewEventRepository(db),
+		ticket:       repositories.NewTicketRepository(db),
+		tier:         repositories.NewTicketTierRepository(db),
+		refreshToken: repositories.NewRefreshTokenRepository(db),
+		role:         repositories.NewRoleRepository(db),
+		checkinScan:  repositories.NewCheckinScanRepository(db),
+		seat:         repositories.NewSeatRepository(db),
+		venue:        repositories.NewVenueRepository(db),
+		uow:          repositories.NewUnitOfWork(db),
+	}
+}
+
+type storeDeps struct {
+	revocation  cache.RevocationStore
+	permissions cache.PermissionCache
+	idempotency cache.IdempotencyStore
+}
+
+func initStores(client *redis.Client) *storeDeps {
+	return &storeDeps{
+		revocation:  cache.NewRedisRevocationStore(client),
+		permissions: cache.NewRedisPermissionCache(client),
+		idempotency: cache.NewRedisIdempotencyStore(client),
 	}
 }
 
 type serviceDeps struct {
-	auth   services.AuthService
-	event  services.EventService
-	ticket services.TicketService
-	user   services.UserService
+	auth       services.AuthService
+	permission services.PermissionService
+	event      services.EventService
+	ticket     services.TicketService
+	user       services.UserService
+	role       services.RoleService
+	payment    services.PaymentService
+	ticketPass services.TicketPassService
+	transfer   services.TicketTransferService
+	checkin    services.CheckinService
+	seat       services.SeatService
+	venue      services.VenueService
 }
 
-func initServices(repos *repositoryDeps, cfg *config.Config, logger zerolog.Logger) *serviceDeps {
+func initServices(repos *repositoryDeps, stores *storeDeps, keys *jwtutil.KeySet, passSigner *ticketpass.Signer, gateway payment.Gateway, cfg *config.Config, logger zerolog.Logger) *serviceDeps {
+	permission := services.NewPermissionService(repos.user, stores.permissions, logger)
+
 	return &serviceDeps{
-		auth:   services.NewAuthService(repos.user, cfg.JWT, logger),
-		event:  services.NewEventService(repos.event, logger),
-		ticket: services.NewTicketService(repos.ticket, repos.event, logger),
-		user:   services.NewUserService(repos.user, logger),
+		auth:       services.NewAuthService(repos.user, repos.refreshToken, repos.uow, stores.revocation, keys, cfg.JWT, logger),
+		permission: permission,
+		event:      services.NewEventService(repos.event, repos.ticket, repos.tier, repos.venue, repos.uow, logger),
+		ticket:     services.NewTicketService(repos.ticket, repos.event, repos.uow, gateway, cfg.Payment, cfg.Checkout, logger),
+		user:       services.NewUserService(repos.user, permission, logger),
+		role:       services.NewRoleService(repos.role, permission, logger),
+		payment:    services.NewPaymentService(repos.uow, cfg.Payment.WebhookSecret, logger),
+		ticketPass: services.NewTicketPassService(repos.ticket, repos.event, passSigner, cfg.TicketPass, logger),
+		transfer:   services.NewTicketTransferService(repos.uow, repos.ticket, repos.event, repos.user, cfg.Transfer, logger),
+		checkin:    services.NewCheckinService(repos.uow, repos.ticket, repos.event, repos.user, repos.checkinScan, passSigner, cfg.TicketPass, logger),
+		seat:       services.NewSeatService(repos.uow, repos.seat, repos.event, logger),
+		venue:      services.NewVenueService(repos.venue, repos.uow, logger),
 	}
 }
 
 type handlerDeps struct {
-	auth   *handlers.AuthHandler
-	event  *handlers.EventHandler
-	ticket *handlers.TicketHandler
-	user   *handlers.UserHandler
+	auth    *handlers.AuthHandler
+	event   *handlers.EventHandler
+	ticket  *handlers.TicketHandler
+	user    *handlers.UserHandler
+	role    *handlers.RoleHandler
+	payment *handlers.PaymentHandler
+	checkin *handlers.CheckinHandler
+	seat    *handlers.SeatHandler
+	venue   *handlers.VenueHandler
 }
 
 func initHandlers(services *serviceDeps) *handlerDeps {
 	return &handlerDeps{
-		auth:   handlers.NewAuthHandler(services.auth),
-		event:  handlers.NewEventHandler(services.event),
-		ticket: handlers.NewTicketHandler(services.ticket),
-		user:   handlers.NewUserHandler(services.user),
+		auth:    handlers.NewAuthHandler(services.auth),
+		event:   handlers.NewEventHandler(services.event),
+		ticket:  handlers.NewTicketHandler(services.ticket, services.ticketPass, services.transfer),
+		user:    handlers.NewUserHandler(services.user),
+		role:    handlers.NewRoleHandler(services.role),
+		payment: handlers.NewPaymentHandler(services.payment),
+		checkin: handlers.NewCheckinHandler(services.checkin),
+		seat:    handlers.NewSeatHandler(services.seat),
+		venue:   handlers.NewVenueHandler(services.venue),
 	}
 }
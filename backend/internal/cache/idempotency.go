@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// IdempotentResponse is what a request with an Idempotency-Key produced.
+// Status is zero while the first request is still being handled.
+type IdempotentResponse struct {
+	Fingerprint string `json:"fingerprint"` // hash of the method, path and body that claimed the key
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// InProgress reports whether the first request has not finished yet
+func (r *IdempotentResponse) InProgress() bool {
+	return r.Status == 0
+}
+
+// IdempotencyStore remembers responses by idempotency key so retries are
+// answered without running the request again.
+type IdempotencyStore interface {
+	// Begin claims key for a new request. It returns nil if the key was free,
+	// otherwise the entry already stored for it. An unfinished claim expires
+	// after lockTTL so a crashed request does not block the key forever.
+	Begin(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*IdempotentResponse, error)
+	// Complete stores the final response for a claimed key
+	Complete(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error
+	// Release drops a claim so the request can be retried, e.g. after a server error
+	Release(ctx context.Context, key string) error
+}
+
+const idempotencyKeyPrefix = "idempotency:"
+
+type redisIdempotencyStore struct {
+	client *redis.Client
+}
+
+// NewRedisIdempotencyStore creates an idempotency store shared by all API instances
+func NewRedisIdempotencyStore(client *redis.Client) IdempotencyStore {
+	return &redisIdempotencyStore{client: client}
+}
+
+func (s *redisIdempotencyStore) Begin(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*IdempotentResponse, error) {
+	data, err := json.Marshal(&IdempotentResponse{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.client.SetNX(ctx, idempotencyKeyPrefix+key, data, lockTTL).Result()
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	existing, err := s.client.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// The claim expired between SETNX and GET; try once more
+		return s.Begin(ctx, key, fingerprint, lockTTL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resp IdempotentResponse
+	if err := json.Unmarshal(existing, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (s *redisIdempotencyStore) Complete(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, idempotencyKeyPrefix+key, data, ttl).Err()
+}
+
+func (s *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, idempotencyKeyPrefix+key).Err()
+}
+
+type memoryIdempotencyEntry struct {
+	resp      IdempotentResponse
+	expiresAt time.Time
+}
+
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryIdempotencyEntry
+}
+
+// NewMemoryIdempotencyStore creates a process-local idempotency store for tests
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{entries: make(map[string]memoryIdempotencyEntry)}
+}
+
+func (s *memoryIdempotencyStore) Begin(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		resp := entry.resp
+		return &resp, nil
+	}
+	s.entries[key] = memoryIdempotencyEntry{
+		resp:      IdempotentResponse{Fingerprint: fingerprint},
+		expiresAt: time.Now().Add(lockTTL),
+	}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = memoryIdempotencyEntry{resp: *resp, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// PermissionCache holds each user's resolved permission names so authorization
+// checks do not hit user_roles -> role_permissions -> permissions on every request.
+type PermissionCache interface {
+	// Get returns the cached permissions and whether there was an entry
+	Get(ctx context.Context, userID string) ([]string, bool, error)
+	Set(ctx context.Context, userID string, permissions []string, ttl time.Duration) error
+	// Delete drops one user's entry, e.g. after their roles change
+	Delete(ctx context.Context, userID string) error
+	// Clear drops every entry, e.g. after a role's permissions change
+	Clear(ctx context.Context) error
+}
+
+func permissionKey(userID string) string {
+	return fmt.Sprintf("user:%s:permissions", userID)
+}
+
+type redisPermissionCache struct {
+	client *redis.Client
+}
+
+// NewRedisPermissionCache creates a permission cache shared by all API instances
+func NewRedisPermissionCache(client *redis.Client) PermissionCache {
+	return &redisPermissionCache{client: client}
+}
+
+func (c *redisPermissionCache) Get(ctx context.Context, userID string) ([]string, bool, error) {
+	data, err := c.client.Get(ctx, permissionKey(userID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var permissions []string
+	if err := json.Unmarshal(data, &permissions); err != nil {
+		return nil, false, err
+	}
+	return permissions, true, nil
+}
+
+func (c *redisPermissionCache) Set(ctx context.Context, userID string, permissions []string, ttl time.Duration) error {
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, permissionKey(userID), data, ttl).Err()
+}
+
+func (c *redisPermissionCache) Delete(ctx context.Context, userID string) error {
+	return c.client.Del(ctx, permissionKey(userID)).Err()
+}
+
+func (c *redisPermissionCache) Clear(ctx context.Context) error {
+	// SCAN rather than KEYS so a large keyspace does not block Redis
+	iter := c.client.Scan(ctx, 0, permissionKey("*"), 100).Iterator()
+	for iter.Next(ctx) {
+		if err := c.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+type memoryPermissionEntry struct {
+	permissions []string
+	expiresAt   time.Time
+}
+
+type memoryPermissionCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryPermissionEntry
+}
+
+// NewMemoryPermissionCache creates a process-local permission cache for tests
+func NewMemoryPermissionCache() PermissionCache {
+	return &memoryPermissionCache{entries: make(map[string]memoryPermissionEntry)}
+}
+
+func (c *memoryPermissionCache) Get(ctx context.Context, userID string) ([]string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[permissionKey(userID)]
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, permissionKey(userID))
+		return nil, false, nil
+	}
+	return entry.permissions, true, nil
+}
+
+func (c *memoryPermissionCache) Set(ctx context.Context, userID string, permissions []string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[permissionKey(userID)] = memoryPermissionEntry{
+		permissions: permissions,
+		expiresAt:   time.Now().Add(ttl),
+	}
+	return nil
+}
+
+func (c *memoryPermissionCache) Delete(ctx context.Context, userID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, permissionKey(userID))
+	return nil
+}
+
+func (c *memoryPermissionCache) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]memoryPermissionEntry)
+	return nil
+}
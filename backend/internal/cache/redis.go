@@ -0,0 +1,36 @@
+// Package cache holds short-lived, shared state kept outside Postgres:
+// token revocations and other data that expires on its own. Each store has a
+// Redis implementation for production and an in-memory one for tests and
+// single-instance development.
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisClient connects to Redis using REDIS_URL when set, otherwise REDIS_ADDR
+func NewRedisClient(ctx context.Context, cfg config.RedisConfig) (*redis.Client, error) {
+	opts := &redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	}
+	if cfg.URL != "" {
+		parsed, err := redis.ParseURL(cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+		}
+		opts = parsed
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RevocationStore tracks access tokens (by jti) that were revoked before expiry.
+// Entries only need to live until the token would have expired anyway.
+type RevocationStore interface {
+	Revoke(ctx context.Context, jti string, ttl time.Duration) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+const revokedTokenKeyPrefix = "auth:revoked:"
+
+type redisRevocationStore struct {
+	client *redis.Client
+}
+
+// NewRedisRevocationStore creates a revocation store shared by all API instances
+func NewRedisRevocationStore(client *redis.Client) RevocationStore {
+	return &redisRevocationStore{client: client}
+}
+
+func (s *redisRevocationStore) Revoke(ctx context.Context, jti string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil // already expired, nothing to revoke
+	}
+	return s.client.Set(ctx, revokedTokenKeyPrefix+jti, 1, ttl).Err()
+}
+
+func (s *redisRevocationStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	err := s.client.Get(ctx, revokedTokenKeyPrefix+jti).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+type memoryRevocationStore struct {
+	mu      sync.Mutex
+	revoked map[string]time.Time // jti -> expiry
+}
+
+// NewMemoryRevocationStore creates a process-local revocation store for tests
+func NewMemoryRevocationStore() RevocationStore {
+	return &memoryRevocationStore{revoked: make(map[string]time.Time)}
+}
+
+func (s *memoryRevocationStore) Revoke(ctx context.Context, jti string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revoked[jti] = time.Now().Add(ttl)
+	return nil
+}
+
+func (s *memoryRevocationStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiry, ok := s.revoked[jti]
+	if !ok {
+		return false, nil
+	}
+	if time.Now().After(expiry) {
+		delete(s.revoked, jti)
+		return false, nil
+	}
+	return true, nil
+}
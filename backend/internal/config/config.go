@@ -3,16 +3,24 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Redis    RedisConfig
-	Storage  StorageConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	JWT         JWTConfig
+	Redis       RedisConfig
+	Storage     StorageConfig
+	Payment     PaymentConfig
+	Checkout    CheckoutConfig
+	Idempotency IdempotencyConfig
+	TicketPass  TicketPassConfig
+	Transfer    TransferConfig
 }
 
 type ServerConfig struct {
@@ -27,11 +35,18 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	Secret string
-	Expiry string
+	Secret        string // HS256 fallback when no private key is configured
+	Expiry        string // access token lifetime
+	RefreshExpiry string // refresh token lifetime
+
+	// Asymmetric signing (RS256/EdDSA). Previous keys stay valid for
+	// verification until every token they signed has expired.
+	PrivateKeyFile   string
+	PreviousKeyFiles []string
 }
 
 type RedisConfig struct {
+	URL      string // takes precedence over Addr/Password/DB when set
 	Addr     string
 	Password string
 	DB       int
@@ -43,6 +58,36 @@ type StorageConfig struct {
 	Region string
 }
 
+type PaymentConfig struct {
+	Provider       string        // only "simulator" for now
+	Timeout        time.Duration // per gateway call
+	SimulatorMode  string        // succeed, decline, timeout or delay
+	SimulatorDelay time.Duration // response delay in delay mode
+	WebhookSecret  string        // HMAC key for POST /payments/webhook; webhooks are refused when empty
+}
+
+type CheckoutConfig struct {
+	HoldWindow    time.Duration // how long a pending order holds its tickets
+	SweepInterval time.Duration // how often expired holds are released
+}
+
+type IdempotencyConfig struct {
+	TTL         time.Duration // how long a stored response is replayed
+	LockTimeout time.Duration // how long an unfinished request keeps its key claimed
+}
+
+type TicketPassConfig struct {
+	// Ed25519 key signing the QR payloads; previous keys still verify passes
+	// issued before a rotation
+	KeyFile          string
+	PreviousKeyFiles []string
+	Validity         time.Duration // how long after the event starts a pass stays valid
+}
+
+type TransferConfig struct {
+	Cutoff time.Duration // transfers close this long before the event starts
+}
+
 func Load() (*Config, error) {
 	// Load .env file in development
 	if os.Getenv("ENV") != "production" {
@@ -60,19 +105,47 @@ func Load() (*Config, error) {
 			MaxIdleConns: 5,
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", ""),
-			Expiry: getEnv("JWT_EXPIRY", "24h"),
+			Secret:        getEnv("JWT_SECRET", ""),
+			Expiry:        getEnv("JWT_EXPIRY", "15m"),
+			RefreshExpiry: getEnv("REFRESH_TOKEN_EXPIRY", "720h"),
+
+			PrivateKeyFile:   getEnv("JWT_PRIVATE_KEY_FILE", ""),
+			PreviousKeyFiles: getEnvList("JWT_PREVIOUS_KEY_FILES"),
 		},
 		Redis: RedisConfig{
+			URL:      getEnv("REDIS_URL", ""),
 			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
 			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       0,
+			DB:       getEnvInt("REDIS_DB", 0),
 		},
 		Storage: StorageConfig{
 			Type:   getEnv("STORAGE_TYPE", "local"),
 			Bucket: getEnv("S3_BUCKET", ""),
 			Region: getEnv("S3_REGION", "us-east-1"),
 		},
+		Payment: PaymentConfig{
+			Provider:       getEnv("PAYMENT_PROVIDER", "simulator"),
+			Timeout:        getEnvDuration("PAYMENT_TIMEOUT", 10*time.Second),
+			SimulatorMode:  getEnv("PAYMENT_SIMULATOR_MODE", "succeed"),
+			SimulatorDelay: getEnvDuration("PAYMENT_SIMULATOR_DELAY", 2*time.Second),
+			WebhookSecret:  getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+		},
+		Checkout: CheckoutConfig{
+			HoldWindow:    getEnvDuration("HOLD_WINDOW", 10*time.Minute),
+			SweepInterval: getEnvDuration("HOLD_SWEEP_INTERVAL", 30*time.Second),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:         getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			LockTimeout: getEnvDuration("IDEMPOTENCY_LOCK_TIMEOUT", time.Minute),
+		},
+		TicketPass: TicketPassConfig{
+			KeyFile:          getEnv("TICKET_PASS_KEY_FILE", ""),
+			PreviousKeyFiles: getEnvList("TICKET_PASS_PREVIOUS_KEY_FILES"),
+			Validity:         getEnvDuration("TICKET_PASS_VALIDITY", 24*time.Hour),
+		},
+		Transfer: TransferConfig{
+			Cutoff: getEnvDuration("TRANSFER_CUTOFF", 24*time.Hour),
+		},
 	}
 
 	if err := cfg.validate(); err != nil {
@@ -86,8 +159,44 @@ func (c *Config) validate() error {
 	if c.Database.URL == "" {
 		return fmt.Errorf("DATABASE_URL is required")
 	}
-	if c.JWT.Secret == "" {
-		return fmt.Errorf("JWT_SECRET is required")
+	if c.JWT.Secret == "" && c.JWT.PrivateKeyFile == "" {
+		return fmt.Errorf("JWT_PRIVATE_KEY_FILE or JWT_SECRET is required")
+	}
+	if _, err := time.ParseDuration(c.JWT.Expiry); err != nil {
+		return fmt.Errorf("invalid JWT_EXPIRY: %w", err)
+	}
+	if _, err := time.ParseDuration(c.JWT.RefreshExpiry); err != nil {
+		return fmt.Errorf("invalid REFRESH_TOKEN_EXPIRY: %w", err)
+	}
+	if c.Payment.Provider != "simulator" {
+		return fmt.Errorf("unsupported PAYMENT_PROVIDER %q", c.Payment.Provider)
+	}
+	if c.Payment.Timeout <= 0 {
+		return fmt.Errorf("PAYMENT_TIMEOUT must be positive")
+	}
+	// Charge and capture both have to fit in the hold
+	if c.Checkout.HoldWindow <= 2*c.Payment.Timeout {
+		return fmt.Errorf("HOLD_WINDOW must be longer than twice PAYMENT_TIMEOUT")
+	}
+	if c.Checkout.SweepInterval <= 0 {
+		return fmt.Errorf("HOLD_SWEEP_INTERVAL must be positive")
+	}
+	// A purchase must finish before its key can be claimed again
+	if c.Idempotency.LockTimeout <= 2*c.Payment.Timeout {
+		return fmt.Errorf("IDEMPOTENCY_LOCK_TIMEOUT must be longer than twice PAYMENT_TIMEOUT")
+	}
+	if c.Idempotency.TTL < c.Idempotency.LockTimeout {
+		return fmt.Errorf("IDEMPOTENCY_TTL must not be shorter than IDEMPOTENCY_LOCK_TIMEOUT")
+	}
+	// A generated key would invalidate every issued pass on restart
+	if c.TicketPass.KeyFile == "" && c.Server.Env == "production" {
+		return fmt.Errorf("TICKET_PASS_KEY_FILE is required in production")
+	}
+	if c.TicketPass.Validity <= 0 {
+		return fmt.Errorf("TICKET_PASS_VALIDITY must be positive")
+	}
+	if c.Transfer.Cutoff < 0 {
+		return fmt.Errorf("TRANSFER_CUTOFF must not be negative")
 	}
 	return nil
 }
@@ -97,4 +206,33 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList reads a comma-separated list, skipping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -10,10 +10,23 @@ type LoginRequest struct {
 type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
-	Role     string `json:"role,omitempty"` // defaults to "user" if empty
+	// Role is accepted only so self-assignment attempts can be rejected explicitly.
+	// Registration always grants the default "user" role; elevated roles are
+	// granted by an admin via POST /users/:id/roles.
+	Role string `json:"role,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"` // optional, revokes the refresh token family too
 }
 
 type AuthResponse struct {
-	Token string       `json:"token"`
-	User  *models.User `json:"user"`
-}
\ No newline at end of file
+	Token        string       `json:"token"`
+	RefreshToken string       `json:"refresh_token"`
+	ExpiresIn    int64        `json:"expires_in"` // access token lifetime in seconds
+	User         *models.User `json:"user"`
+}
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+)
+
+// CheckinRequest is what a gate scanner sends: the event it is admitting to
+// and either a typed ticket code or the signed pass read from a QR code
+type CheckinRequest struct {
+	EventID string `json:"event_id" binding:"required,uuid"`
+	Code    string `json:"code" binding:"required,max=512"`
+}
+
+// Check-in rejection reasons, stable for scanner apps to switch on
+const (
+	CheckinAlreadyUsed       = "already_used"
+	CheckinTicketCancelled   = "ticket_cancelled"
+	CheckinTicketTransferred = "ticket_transferred"
+	CheckinWrongEvent        = "wrong_event"
+	CheckinOrderNotConfirmed = "order_not_confirmed"
+
+	// Only for scans uploaded by offline scanners
+	CheckinUnknownTicket     = "unknown_ticket"
+	CheckinInvalidPass       = "invalid_pass"
+	CheckinInvalidScanTime   = "invalid_scan_time"
+	CheckinReplacedLaterScan = "replaced_later_scan" // admitted; the later admission became a duplicate
+)
+
+// CheckinResponse tells the gate whether to admit the holder. The holder and
+// order are included either way so staff can sort out disputes at the gate.
+type CheckinResponse struct {
+	Admitted bool                `json:"admitted"`
+	Reason   string              `json:"reason,omitempty"`
+	Message  string              `json:"message"`
+	Ticket   *models.Ticket      `json:"ticket"`
+	Order    *models.TicketOrder `json:"order"`
+	Holder   *CheckinHolder      `json:"holder"`
+}
+
+// CheckinHolder identifies the ticket's buyer. Accounts only have an email,
+// so that is what gate staff check against.
+type CheckinHolder struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// CheckinSnapshot lists the tickets a gate may see for an event, for
+// scanners to check against while offline. It is served signed with the
+// ticket pass keys.
+type CheckinSnapshot struct {
+	EventID     string           `json:"event_id"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	Tickets     []SnapshotTicket `json:"tickets"`
+}
+
+type SnapshotTicket struct {
+	TicketID string     `json:"ticket_id"`
+	CodeHash string     `json:"code_hash"` // hex SHA-256 of the ticket code
+	Status   string     `json:"status"`    // valid or used
+	UsedAt   *time.Time `json:"used_at,omitempty"`
+}
+
+// CheckinSyncRequest uploads scans a device recorded while offline. ScanID
+// is generated by the device, so uploading a batch again is harmless.
+type CheckinSyncRequest struct {
+	EventID  string        `json:"event_id" binding:"required,uuid"`
+	DeviceID string        `json:"device_id" binding:"required,max=100"`
+	Scans    []OfflineScan `json:"scans" binding:"required,min=1,max=500,dive"`
+}
+
+type OfflineScan struct {
+	ScanID    string    `json:"scan_id" binding:"required,uuid"`
+	Code      string    `json:"code" binding:"required,max=512"`
+	Gate      string    `json:"gate" binding:"max=100"`
+	ScannedAt time.Time `json:"scanned_at" binding:"required"`
+}
+
+// CheckinSyncResponse has a result per uploaded scan, and lists separately
+// every scan the server did not simply admit: the device let someone in
+// that staff should look into.
+type CheckinSyncResponse struct {
+	Results   []ScanOutcome  `json:"results"`
+	Conflicts []ScanConflict `json:"conflicts"`
+}
+
+type ScanOutcome struct {
+	ScanID   string `json:"scan_id"`
+	TicketID string `json:"ticket_id,omitempty"`
+	Result   string `json:"result"` // admitted, duplicate or rejected
+	Reason   string `json:"reason,omitempty"`
+	Replayed bool   `json:"replayed,omitempty"` // uploaded before; the stored result is returned
+}
+
+type ScanConflict struct {
+	ScanID   string `json:"scan_id"`
+	TicketID string `json:"ticket_id,omitempty"`
+	Reason   string `json:"reason"`
+	Message  string `json:"message"`
+	// OtherScan is the competing scan of the same ticket, if any
+	OtherScan *models.CheckinScan `json:"other_scan,omitempty"`
+}
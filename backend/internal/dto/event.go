@@ -1,31 +1,100 @@
 package dto
 
-import "github.com/baramulti/ticketing-system/backend/internal/models"
+import (
+	"time"
 
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+)
+
+// CreateEventRequest describes a new event. event_date is an RFC 3339
+// timestamp with a UTC offset and must lie in the future; total_tickets
+// cannot exceed the venue's capacity.
 type CreateEventRequest struct {
-	Title            string  `json:"title" binding:"required"`
-	Description      string  `json:"description"`
-	EventDate        string  `json:"event_date" binding:"required"`
-	Venue            string  `json:"venue" binding:"required"`
-	TicketPrice      float64 `json:"ticket_price" binding:"required,min=0"`
-	TotalTickets     int     `json:"total_tickets" binding:"required,min=1"`
-	AvailableTickets int     `json:"available_tickets" binding:"required,min=0"`
+	Title            string   `json:"title" binding:"required,max=255"`
+	Description      string   `json:"description"`
+	EventDate        string   `json:"event_date" binding:"required"`
+	VenueID          string   `json:"venue_id" binding:"required,uuid"`
+	TicketPrice      *float64 `json:"ticket_price" binding:"required,min=0"` // 0 for free events
+	TotalTickets     int      `json:"total_tickets" binding:"required,min=1"`
+	AvailableTickets *int     `json:"available_tickets" binding:"required,min=0"`
+	TransfersEnabled *bool    `json:"transfers_enabled"` // defaults to true
 }
 
+// UpdateEventRequest changes only the fields that are present. available_tickets
+// cannot be set directly; changing total_tickets moves it by the same amount.
 type UpdateEventRequest struct {
-	Title       string  `json:"title,omitempty"`
-	Description string  `json:"description,omitempty"`
-	EventDate   string  `json:"event_date,omitempty"`
-	Venue       string  `json:"venue,omitempty"`
-	TicketPrice float64 `json:"ticket_price,omitempty"`
+	Title            *string  `json:"title" binding:"omitempty,min=1,max=255"`
+	Description      *string  `json:"description"`
+	EventDate        *string  `json:"event_date"`
+	VenueID          *string  `json:"venue_id" binding:"omitempty,uuid"`
+	TicketPrice      *float64 `json:"ticket_price" binding:"omitempty,min=0"`
+	TotalTickets     *int     `json:"total_tickets" binding:"omitempty,min=1"`
+	TransfersEnabled *bool    `json:"transfers_enabled"`
+}
+
+// CreateTierRequest adds a ticket tier to an event. The sale window is open
+// on either side when a bound is left out.
+type CreateTierRequest struct {
+	Name         string     `json:"name" binding:"required,max=100"`
+	Price        *float64   `json:"price" binding:"required,min=0"`
+	TotalTickets int        `json:"total_tickets" binding:"required,min=1"`
+	SalesStart   *time.Time `json:"sales_start"`
+	SalesEnd     *time.Time `json:"sales_end"`
+	MaxPerOrder  *int       `json:"max_per_order" binding:"omitempty,min=1"`
+}
+
+// UpdateTierRequest changes only the fields that are present. Changing
+// total_tickets moves the tier's available tickets by the same amount.
+type UpdateTierRequest struct {
+	Name         *string    `json:"name" binding:"omitempty,min=1,max=100"`
+	Price        *float64   `json:"price" binding:"omitempty,min=0"`
+	TotalTickets *int       `json:"total_tickets" binding:"omitempty,min=1"`
+	SalesStart   *time.Time `json:"sales_start"`
+	SalesEnd     *time.Time `json:"sales_end"`
+	MaxPerOrder  *int       `json:"max_per_order" binding:"omitempty,min=1"`
+}
+
+// PageQuery pages a listing. page_size is capped by the service.
+type PageQuery struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// EventListQuery filters, sorts and pages GET /events. from and to are RFC
+// 3339 timestamps and both bounds are inclusive; min_price and max_price
+// apply to the event's ticket_price. sort is date, price, -date or -price
+// (descending). A cursor from a previous response continues after its last
+// event instead of using page.
+type EventListQuery struct {
+	PageQuery
+	Cursor    string     `form:"cursor"`
+	From      *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	VenueID   string     `form:"venue_id" binding:"omitempty,uuid"`
+	MinPrice  *float64   `form:"min_price" binding:"omitnil,min=0"`
+	MaxPrice  *float64   `form:"max_price" binding:"omitnil,min=0"`
+	Available *bool      `form:"available"`
+	Sort      string     `form:"sort" binding:"omitempty,oneof=date -date price -price"`
 }
 
 type EventResponse struct {
 	Event *models.Event `json:"event"`
 }
 
+// EventListResponse is one page of events. Total counts every event that
+// matches the filters; next_cursor is set while more events follow.
 type EventListResponse struct {
-	Events []*models.Event `json:"events"`
-	Total  int             `json:"total"`
-	Page   int             `json:"page"`
+	Events     []*models.Event `json:"events"`
+	Total      int             `json:"total"`
+	Page       int             `json:"page,omitempty"` // not set for cursor pages
+	PageSize   int             `json:"page_size"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// EventSalesResponse summarizes an event's orders for its organizer
+type EventSalesResponse struct {
+	EventID     string                       `json:"event_id"`
+	TicketsSold int                          `json:"tickets_sold"` // paid and confirmed orders
+	Revenue     float64                      `json:"revenue"`      // paid and confirmed orders
+	ByStatus    []*models.OrderStatusSummary `json:"by_status"`
 }
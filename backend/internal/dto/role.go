@@ -0,0 +1,17 @@
+package dto
+
+type CreateRoleRequest struct {
+	Name        string `json:"name" binding:"required,max=100,role_name"`
+	Description string `json:"description"`
+}
+
+// CreatePermissionRequest takes a resource.action name (e.g. "venues.create");
+// resource and action are derived from it
+type CreatePermissionRequest struct {
+	Name        string `json:"name" binding:"required,max=100,permission_name"`
+	Description string `json:"description"`
+}
+
+type RolePermissionRequest struct {
+	Permission string `json:"permission" binding:"required,permission_name"`
+}
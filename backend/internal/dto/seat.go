@@ -0,0 +1,34 @@
+package dto
+
+import "github.com/baramulti/ticketing-system/backend/internal/models"
+
+// CreateSeatMapRequest describes a venue's layout section by section. The
+// seats of a row are numbered from 1, and the map can only be assigned to
+// events at its venue.
+type CreateSeatMapRequest struct {
+	Name     string               `json:"name" binding:"required,max=255"`
+	VenueID  string               `json:"venue_id" binding:"required,uuid"`
+	Sections []SeatSectionRequest `json:"sections" binding:"required,min=1,max=50,dive"`
+}
+
+type SeatSectionRequest struct {
+	Name string           `json:"name" binding:"required,max=50"`
+	Rows []SeatRowRequest `json:"rows" binding:"required,min=1,max=200,dive"`
+}
+
+type SeatRowRequest struct {
+	Label string `json:"label" binding:"required,max=10"`
+	Seats int    `json:"seats" binding:"required,min=1,max=500"`
+}
+
+// AssignSeatMapRequest switches an event to reserved seating
+type AssignSeatMapRequest struct {
+	SeatMapID string `json:"seat_map_id" binding:"required,uuid"`
+}
+
+// EventSeatsResponse lists an event's seats and whether each can still be bought
+type EventSeatsResponse struct {
+	EventID   string              `json:"event_id"`
+	Available int                 `json:"available"`
+	Seats     []*models.EventSeat `json:"seats"`
+}
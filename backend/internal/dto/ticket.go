@@ -1,17 +1,31 @@
 package dto
 
-import "github.com/baramulti/ticketing-system/backend/internal/models"
+import (
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+)
 
 type PurchaseRequest struct {
-	EventID  string `json:"event_id" binding:"required"`
-	Quantity int    `json:"quantity" binding:"required,min=1,max=10"`
+	EventID  string   `json:"event_id" binding:"required,uuid"`
+	TierID   string   `json:"tier_id" binding:"omitempty,uuid"`                     // required for events sold by tier
+	SeatIDs  []string `json:"seat_ids" binding:"omitempty,max=10,unique,dive,uuid"` // required for reserved seating, one per ticket
+	Quantity int      `json:"quantity" binding:"required,min=1,max=10"`
 }
 
 type PurchaseResponse struct {
-	OrderID       string `json:"order_id"`
-	TransactionID string `json:"transaction_id"` // from payment gateway
-	Status        string `json:"status"`
-	Message       string `json:"message,omitempty"`
+	OrderID       string     `json:"order_id"`
+	TransactionID string     `json:"transaction_id,omitempty"` // from payment gateway
+	Status        string     `json:"status"`
+	TotalPrice    float64    `json:"total_price"`
+	HeldUntil     *time.Time `json:"held_until,omitempty"` // pending orders release their tickets after this
+	Message       string     `json:"message,omitempty"`
+}
+
+// CloseOrderRequest is the optional body of the cancel and refund endpoints.
+// The reason is kept in the order's status history.
+type CloseOrderRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
 }
 
 type OrderResponse struct {
@@ -22,4 +36,22 @@ type OrderResponse struct {
 type OrderListResponse struct {
 	Orders []*models.TicketOrder `json:"orders"`
 	Total  int                   `json:"total"`
-}
\ No newline at end of file
+}
+
+// TransferTicketRequest names the registered user a ticket is given to
+type TransferTicketRequest struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+}
+
+// TransferTicketResponse returns the recorded transfer and the sender's ticket,
+// now transferred. The recipient's new ticket code is only shown to them.
+type TransferTicketResponse struct {
+	Transfer *models.TicketTransfer `json:"transfer"`
+	Ticket   *models.Ticket         `json:"ticket"`
+}
+
+// TransferChainResponse lists a ticket's ownership chain, oldest first
+type TransferChainResponse struct {
+	TicketID  string                   `json:"ticket_id"`
+	Transfers []*models.TicketTransfer `json:"transfers"`
+}
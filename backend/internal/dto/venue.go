@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/baramulti/ticketing-system/backend/internal/models"
+
+// CreateVenueRequest describes a new venue. time_zone is an IANA name such
+// as Asia/Jakarta; coordinates are optional but come as a pair.
+type CreateVenueRequest struct {
+	Name      string   `json:"name" binding:"required,max=255"`
+	Address   string   `json:"address" binding:"max=1000"`
+	TimeZone  string   `json:"time_zone" binding:"required,max=64"`
+	Capacity  int      `json:"capacity" binding:"required,min=1"`
+	Latitude  *float64 `json:"latitude" binding:"required_with=Longitude,omitnil,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required_with=Latitude,omitnil,min=-180,max=180"`
+}
+
+// UpdateVenueRequest changes only the fields that are present. capacity
+// cannot go below the total tickets of an event held at the venue.
+type UpdateVenueRequest struct {
+	Name      *string  `json:"name" binding:"omitempty,min=1,max=255"`
+	Address   *string  `json:"address" binding:"omitempty,max=1000"`
+	TimeZone  *string  `json:"time_zone" binding:"omitempty,min=1,max=64"`
+	Capacity  *int     `json:"capacity" binding:"omitempty,min=1"`
+	Latitude  *float64 `json:"latitude" binding:"required_with=Longitude,omitnil,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required_with=Latitude,omitnil,min=-180,max=180"`
+}
+
+// VenueListResponse is one page of venues. Total counts every venue.
+type VenueListResponse struct {
+	Venues   []*models.Venue `json:"venues"`
+	Total    int             `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
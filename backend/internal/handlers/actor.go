@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// actorFromContext builds the service-level actor from the values set by the
+// auth middleware, whose roles are read from the database
+func actorFromContext(c *gin.Context) (services.Actor, bool) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		return services.Actor{}, false
+	}
+	return services.Actor{UserID: userID, Roles: c.GetStringSlice("user_roles")}, true
+}
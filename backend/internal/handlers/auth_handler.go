@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
 	"github.com/baramulti/ticketing-system/backend/internal/services"
 	"github.com/baramulti/ticketing-system/backend/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -26,7 +28,14 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	result, err := h.authSvc.Login(c.Request.Context(), &req)
 	if err != nil {
-		response.Error(c, http.StatusUnauthorized, err.Error())
+		switch {
+		case errors.Is(err, services.ErrInvalidCredentials):
+			response.Error(c, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, services.ErrAccountDisabled):
+			response.Error(c, http.StatusForbidden, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
@@ -40,11 +49,82 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	// Reject self-assigned roles before touching the service
+	if req.Role != "" && req.Role != models.RoleUser {
+		response.Error(c, http.StatusForbidden, services.ErrRoleNotSelfAssignable.Error())
+		return
+	}
+
 	result, err := h.authSvc.Register(c.Request.Context(), &req)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error())
+		switch {
+		case errors.Is(err, services.ErrRoleNotSelfAssignable):
+			response.Error(c, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrEmailAlreadyRegistered):
+			response.Error(c, http.StatusConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
 	response.Success(c, http.StatusCreated, result)
-}
\ No newline at end of file
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.authSvc.Refresh(c.Request.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidRefreshToken), errors.Is(err, services.ErrRefreshTokenReused):
+			response.Error(c, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, services.ErrAccountDisabled):
+			response.Error(c, http.StatusForbidden, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, result)
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	token, exists := c.Get("access_token")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	// Body is optional; it only carries the refresh token to revoke
+	var req dto.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	if err := h.authSvc.Logout(c.Request.Context(), token.(string), &req); err != nil {
+		if errors.Is(err, services.ErrInvalidToken) {
+			response.Error(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"message": "logged out"})
+}
+
+// JWKS serves the public verification keys as a bare JWK Set (RFC 7517),
+// not wrapped in the usual response envelope, so standard clients can read it
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authSvc.JWKS())
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// doJSON performs a JSON request against the given engine and returns the recorder
+func doJSON(r http.Handler, method, path string, body interface{}) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestAuthHandler_Register_RoleEscalation
+// Summary: Register requests that try to pick their own role
+// Purpose: Ensure elevated roles are refused with 403 and only "user" is ever granted
+func TestAuthHandler_Register_RoleEscalation(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		wantStatus int
+	}{
+		{name: "admin refused", role: models.RoleAdmin, wantStatus: http.StatusForbidden},
+		{name: "organizer refused", role: models.RoleOrganizer, wantStatus: http.StatusForbidden},
+		{name: "validator refused", role: models.RoleValidator, wantStatus: http.StatusForbidden},
+		{name: "default role allowed", role: "", wantStatus: http.StatusCreated},
+		{name: "explicit user role allowed", role: models.RoleUser, wantStatus: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewUserRepository(t)
+			mockRefreshRepo := mocks.NewRefreshTokenRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			if tt.wantStatus == http.StatusCreated {
+				mockUserRepo.On("FindByEmail", mock.Anything, "new@example.com").Return(nil, repositories.ErrNotFound).Once()
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Users: mockUserRepo})
+					},
+				).Once()
+				mockUserRepo.On("Create", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						args.Get(1).(*models.User).ID = "user-new"
+					}).
+					Return(nil).
+					Once()
+				// Only the default role may ever be assigned here
+				mockUserRepo.On("AssignRole", mock.Anything, "user-new", models.RoleUser, (*string)(nil)).Return(nil).Once()
+				mockRefreshRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.On("FindRolesByUserID", mock.Anything, "user-new").
+					Return([]*models.Role{{Name: models.RoleUser}}, nil).
+					Once()
+			}
+
+			authSvc := services.NewAuthService(mockUserRepo, mockRefreshRepo, uow, cache.NewMemoryRevocationStore(), jwtutil.NewHMACKeySet("test-secret"), config.JWTConfig{Secret: "test-secret", Expiry: "1h"}, zerolog.Nop())
+			h := NewAuthHandler(authSvc)
+
+			r := gin.New()
+			r.POST("/auth/register", h.Register)
+
+			w := doJSON(r, http.MethodPost, "/auth/register", gin.H{
+				"email":    "new@example.com",
+				"password": "password123",
+				"role":     tt.role,
+			})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/baramulti/ticketing-system/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type CheckinHandler struct {
+	checkinSvc services.CheckinService
+}
+
+func NewCheckinHandler(checkinSvc services.CheckinService) *CheckinHandler {
+	return &CheckinHandler{checkinSvc: checkinSvc}
+}
+
+// CheckIn admits a ticket at the gate. Refusals answer 409 with the reason,
+// ticket, order and holder in data.
+func (h *CheckinHandler) CheckIn(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.CheckinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	result, err := h.checkinSvc.CheckIn(c.Request.Context(), actor, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrTicketNotFound):
+			response.Error(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrInvalidTicketPass):
+			response.Error(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to check in ticket")
+		}
+		return
+	}
+
+	if !result.Admitted {
+		response.ErrorWithData(c, http.StatusConflict, result.Message, result)
+		return
+	}
+	response.Success(c, http.StatusOK, result)
+}
+
+// Snapshot serves the signed list of an event's tickets for offline scanning
+func (h *CheckinHandler) Snapshot(c *gin.Context) {
+	doc, err := h.checkinSvc.Snapshot(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to build check-in snapshot")
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	response.Success(c, http.StatusOK, doc)
+}
+
+// Sync takes the scans a device recorded while offline
+func (h *CheckinHandler) Sync(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.CheckinSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	result, err := h.checkinSvc.Sync(c.Request.Context(), actor, &req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to sync scans")
+		return
+	}
+	response.Success(c, http.StatusOK, result)
+}
@@ -1,9 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
 	"github.com/baramulti/ticketing-system/backend/internal/services"
 	"github.com/baramulti/ticketing-system/backend/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -22,20 +23,42 @@ func (h *EventHandler) GetByID(c *gin.Context) {
 
 	event, err := h.eventSvc.GetByID(c.Request.Context(), id)
 	if err != nil {
-		response.Error(c, http.StatusNotFound, "event not found")
+		h.handleError(c, err, "failed to get event")
 		return
 	}
 
 	response.Success(c, http.StatusOK, event)
 }
 
+// List filters, sorts and pages events; see dto.EventListQuery for the
+// query parameters
 func (h *EventHandler) List(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	var q dto.EventListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	events, err := h.eventSvc.List(c.Request.Context(), &q)
+	if err != nil {
+		h.handleError(c, err, "failed to list events")
+		return
+	}
+
+	response.Success(c, http.StatusOK, events)
+}
+
+// ListByVenue lists the events held at a venue, with the same parameters as List
+func (h *EventHandler) ListByVenue(c *gin.Context) {
+	var q dto.EventListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
 
-	events, err := h.eventSvc.List(c.Request.Context(), page, pageSize)
+	events, err := h.eventSvc.ListByVenue(c.Request.Context(), c.Param("id"), &q)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		h.handleError(c, err, "failed to list venue events")
 		return
 	}
 
@@ -43,18 +66,186 @@ func (h *EventHandler) List(c *gin.Context) {
 }
 
 func (h *EventHandler) Create(c *gin.Context) {
-	// TODO: parse CreateEventRequest DTO
-	// TODO: validate admin role
-	response.Error(c, http.StatusNotImplemented, "not implemented")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.CreateEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	event, err := h.eventSvc.Create(c.Request.Context(), actor, &req)
+	if err != nil {
+		h.handleError(c, err, "failed to create event")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, event)
 }
 
 func (h *EventHandler) Update(c *gin.Context) {
-	// TODO: parse UpdateEventRequest DTO
-	// TODO: validate admin role
-	response.Error(c, http.StatusNotImplemented, "not implemented")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.UpdateEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	event, err := h.eventSvc.Update(c.Request.Context(), actor, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err, "failed to update event")
+		return
+	}
+
+	response.Success(c, http.StatusOK, event)
 }
 
 func (h *EventHandler) Delete(c *gin.Context) {
-	// TODO: validate admin role
-	response.Error(c, http.StatusNotImplemented, "not implemented")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	if err := h.eventSvc.Delete(c.Request.Context(), actor, c.Param("id")); err != nil {
+		h.handleError(c, err, "failed to delete event")
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"message": "event deleted"})
+}
+
+// GetSales returns order totals for the event. Organizers only see their own events.
+func (h *EventHandler) GetSales(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	sales, err := h.eventSvc.GetSales(c.Request.Context(), actor, c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed to fetch event sales")
+		return
+	}
+
+	response.Success(c, http.StatusOK, sales)
+}
+
+// CreateTier adds a ticket tier to an event the actor manages
+func (h *EventHandler) CreateTier(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.CreateTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tier, err := h.eventSvc.CreateTier(c.Request.Context(), actor, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err, "failed to create ticket tier")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, tier)
+}
+
+// UpdateTier changes the fields present in the body on one of the event's tiers
+func (h *EventHandler) UpdateTier(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.UpdateTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tier, err := h.eventSvc.UpdateTier(c.Request.Context(), actor, c.Param("id"), c.Param("tierId"), &req)
+	if err != nil {
+		h.handleError(c, err, "failed to update ticket tier")
+		return
+	}
+
+	response.Success(c, http.StatusOK, tier)
+}
+
+// DeleteTier removes a tier nobody has ordered from
+func (h *EventHandler) DeleteTier(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	if err := h.eventSvc.DeleteTier(c.Request.Context(), actor, c.Param("id"), c.Param("tierId")); err != nil {
+		h.handleError(c, err, "failed to delete ticket tier")
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"message": "ticket tier deleted"})
+}
+
+// AssignSeatMap switches the event to reserved seating
+func (h *EventHandler) AssignSeatMap(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.AssignSeatMapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	event, err := h.eventSvc.AssignSeatMap(c.Request.Context(), actor, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err, "failed to assign seat map")
+		return
+	}
+
+	response.Success(c, http.StatusOK, event)
+}
+
+// handleError maps event service errors to HTTP responses
+func (h *EventHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrEventNotFound), errors.Is(err, services.ErrTierNotFound),
+		errors.Is(err, services.ErrSeatMapNotFound), errors.Is(err, services.ErrVenueNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrNotEventOrganizer):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidEventDate), errors.Is(err, services.ErrEventDateInPast),
+		errors.Is(err, services.ErrAvailableAboveTotal), errors.Is(err, services.ErrInvalidSalesWindow),
+		errors.Is(err, services.ErrInvalidDateRange), errors.Is(err, services.ErrInvalidPriceRange),
+		errors.Is(err, services.ErrInvalidCursor):
+		response.Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrTotalBelowSold), errors.Is(err, services.ErrEventHasOrders),
+		errors.Is(err, services.ErrTierCapacityExceeded), errors.Is(err, services.ErrTierExists),
+		errors.Is(err, services.ErrTierHasOrders), errors.Is(err, services.ErrSeatMapLocked),
+		errors.Is(err, services.ErrSeatedCapacity), errors.Is(err, services.ErrVenueCapacityExceeded),
+		errors.Is(err, services.ErrSeatMapVenueMismatch):
+		response.Error(c, http.StatusConflict, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, fallback)
+	}
 }
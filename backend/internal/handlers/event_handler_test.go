@@ -0,0 +1,504 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// asUser stands in for the auth middleware and sets the authenticated user
+func asUser(userID string, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Set("user_roles", roles)
+		c.Next()
+	}
+}
+
+func newEventRouter(h *EventHandler, userID string, roles ...string) *gin.Engine {
+	r := gin.New()
+	r.Use(asUser(userID, roles...))
+	r.PATCH("/events/:id", h.Update)
+	r.PUT("/events/:id", h.Update)
+	r.DELETE("/events/:id", h.Delete)
+	r.GET("/events/:id/sales", h.GetSales)
+	r.POST("/events/:id/tiers", h.CreateTier)
+	r.PATCH("/events/:id/tiers/:tierId", h.UpdateTier)
+	r.DELETE("/events/:id/tiers/:tierId", h.DeleteTier)
+	return r
+}
+
+// TestEventHandler_Ownership
+// Summary: Event update, delete, sales and ticket tier requests from organizers and admins
+// Purpose: Ensure organizers only manage their own events, admins can override,
+// admin-managed events (no organizer) stay off-limits to organizers, partial updates
+// are validated, events with paid, confirmed or refunded orders cannot be deleted, tiers
+// stay within the event's capacity and events within their venue's, and malformed event ids are not found
+func TestEventHandler_Ownership(t *testing.T) {
+	ownerID := "organizer-001"
+	otherID := "organizer-002"
+
+	ownedEvent := func() *models.Event {
+		return &models.Event{
+			ID:               "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			Title:            "Jakarta Tech Conference",
+			EventDate:        time.Now().Add(30 * 24 * time.Hour),
+			VenueID:          "venue-001",
+			TicketPrice:      250000,
+			TotalTickets:     500,
+			AvailableTickets: 500,
+			OrganizerID:      &ownerID,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       interface{}
+		userID     string
+		roles      []string
+		event      *models.Event // nil: not found
+		sold       bool          // the event has paid, confirmed or refunded orders
+		tiers      []*models.TicketTier
+		wantStatus int
+	}{
+		{
+			name:       "owner updates own event",
+			method:     http.MethodPut,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			body:       map[string]any{"title": "Renamed"},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "other organizer cannot update",
+			method:     http.MethodPut,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			body:       map[string]any{"title": "Hijacked"},
+			userID:     otherID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin overrides ownership on update",
+			method:     http.MethodPut,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			body:       map[string]any{"venue_id": "6f1c2d3e-4a5b-4c6d-8e7f-9a0b1c2d3e4f"},
+			userID:     "admin-001",
+			roles:      []string{models.RoleAdmin},
+			event:      ownedEvent(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "owner deletes own event",
+			method:     http.MethodDelete,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "other organizer cannot delete",
+			method:     http.MethodDelete,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			userID:     otherID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "organizer cannot delete admin-managed event",
+			method:     http.MethodDelete,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      &models.Event{ID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "owner sees sales",
+			method:     http.MethodGet,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/sales",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "other organizer cannot see sales",
+			method:     http.MethodGet,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/sales",
+			userID:     otherID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "owner patches own event",
+			method:     http.MethodPatch,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			body:       map[string]any{"total_tickets": 400, "transfers_enabled": false},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "available tickets are not writable",
+			method:     http.MethodPut,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			body:       map[string]any{"available_tickets": 600},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "event date in the past",
+			method:     http.MethodPatch,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			body:       map[string]any{"event_date": "2020-01-01T19:00:00+07:00"},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "total above the venue's capacity",
+			method:     http.MethodPatch,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			body:       map[string]any{"total_tickets": 1200},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "event with paid orders cannot be deleted",
+			method:     http.MethodDelete,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			sold:       true,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "owner adds a tier",
+			method:     http.MethodPost,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/tiers",
+			body:       map[string]any{"name": "VIP", "price": 750000, "total_tickets": 100, "max_per_order": 2},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			tiers:      []*models.TicketTier{{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", Name: "Regular", TotalTickets: 400}},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "other organizer cannot add a tier",
+			method:     http.MethodPost,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/tiers",
+			body:       map[string]any{"name": "VIP", "price": 750000, "total_tickets": 100},
+			userID:     otherID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "tiers above the event's capacity",
+			method:     http.MethodPost,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/tiers",
+			body:       map[string]any{"name": "VIP", "price": 750000, "total_tickets": 101},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			tiers:      []*models.TicketTier{{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", Name: "Regular", TotalTickets: 400}},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "sale window ending before it starts",
+			method:     http.MethodPost,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/tiers",
+			body:       map[string]any{"name": "Early bird", "price": 150000, "total_tickets": 50, "sales_start": "2030-02-01T00:00:00Z", "sales_end": "2030-01-01T00:00:00Z"},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "owner updates a tier",
+			method:     http.MethodPatch,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/tiers/tier-001",
+			body:       map[string]any{"price": 200000},
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			tiers:      []*models.TicketTier{{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", Name: "Regular", TotalTickets: 400, AvailableTickets: 400}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "owner deletes a tier",
+			method:     http.MethodDelete,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/tiers/tier-001",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			tiers:      []*models.TicketTier{{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", Name: "Regular", TotalTickets: 400}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "tier of another event",
+			method:     http.MethodDelete,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e/tiers/tier-002",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			event:      ownedEvent(),
+			tiers:      []*models.TicketTier{{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", Name: "Regular", TotalTickets: 400}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "missing event",
+			method:     http.MethodDelete,
+			path:       "/events/9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "malformed event id",
+			method:     http.MethodDelete,
+			path:       "/events/not-a-uuid",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "malformed event id for sales",
+			method:     http.MethodGet,
+			path:       "/events/not-a-uuid/sales",
+			userID:     ownerID,
+			roles:      []string{models.RoleOrganizer},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventRepo := mocks.NewEventRepository(t)
+			mockTicketRepo := mocks.NewTicketRepository(t)
+			mockTierRepo := mocks.NewTicketTierRepository(t)
+			mockVenueRepo := mocks.NewVenueRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			tierPath := strings.Contains(tt.path, "/tiers")
+			badWindow := tierPath && tt.wantStatus == http.StatusBadRequest
+
+			// Writes load the event locked, inside a transaction
+			find := "FindByID"
+			if tt.method != http.MethodGet && !badWindow {
+				find = "FindByIDForUpdate"
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Events: mockEventRepo, Tickets: mockTicketRepo, Tiers: mockTierRepo, Venues: mockVenueRepo})
+					},
+				).Once()
+			}
+			switch {
+			case badWindow:
+				// The sale window is checked before the event is loaded
+			case strings.HasPrefix(tt.path, "/events/not-a-uuid"):
+				// Malformed ids are answered without a query
+			case tt.event == nil:
+				mockEventRepo.On(find, mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(nil, repositories.ErrNotFound).Once()
+			default:
+				mockEventRepo.On(find, mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(tt.event, nil).Once()
+			}
+			if tt.method == http.MethodDelete && !tierPath && tt.event != nil && tt.wantStatus != http.StatusForbidden {
+				mockTicketRepo.On("HasSoldOrders", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(tt.sold, nil).Once()
+				if !tt.sold {
+					mockTicketRepo.On("HasActiveHolds", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(false, nil).Once()
+				}
+			}
+			// Changing the total or the venue checks the venue's capacity
+			eventWrite := (tt.method == http.MethodPatch || tt.method == http.MethodPut) && !tierPath &&
+				(tt.wantStatus == http.StatusOK || tt.wantStatus == http.StatusConflict)
+			body, _ := tt.body.(map[string]any)
+			if eventWrite && (body["total_tickets"] != nil || body["venue_id"] != nil) {
+				mockVenueRepo.On("FindByIDForUpdate", mock.Anything, mock.Anything).
+					Return(&models.Venue{ID: "venue-001", Capacity: 1000}, nil).Once()
+			}
+			changesTotal := eventWrite && tt.wantStatus == http.StatusOK && body["total_tickets"] != nil
+			if changesTotal || tierPath && tt.event != nil && tt.wantStatus != http.StatusForbidden && !badWindow {
+				mockTierRepo.On("ListByEventID", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(tt.tiers, nil).Once()
+			}
+
+			// Writes must only happen once ownership is confirmed
+			switch {
+			case tierPath && tt.wantStatus == http.StatusCreated:
+				mockTierRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.TicketTier")).Return(nil).Once()
+			case tierPath && tt.wantStatus == http.StatusOK && tt.method == http.MethodPatch:
+				mockTierRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.TicketTier")).Return(nil).Once()
+			case tierPath && tt.wantStatus == http.StatusOK && tt.method == http.MethodDelete:
+				mockTierRepo.On("Delete", mock.Anything, "tier-001").Return(nil).Once()
+			case tt.wantStatus == http.StatusOK:
+				switch tt.method {
+				case http.MethodPut, http.MethodPatch:
+					mockEventRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Event")).Return(nil).Once()
+				case http.MethodDelete:
+					mockTicketRepo.On("DeleteUnsoldOrdersByEvent", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(nil).Once()
+					mockEventRepo.On("Delete", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(nil).Once()
+				case http.MethodGet:
+					mockTicketRepo.On("SummarizeOrdersByEvent", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return([]*models.OrderStatusSummary{
+						{Status: string(models.OrderStatusConfirmed), Orders: 2, Tickets: 3, Revenue: 750000},
+						{Status: string(models.OrderStatusPending), Orders: 1, Tickets: 1, Revenue: 250000},
+					}, nil).Once()
+				}
+			}
+
+			eventSvc := services.NewEventService(mockEventRepo, mockTicketRepo, mockTierRepo, mockVenueRepo, uow, zerolog.Nop())
+			r := newEventRouter(NewEventHandler(eventSvc), tt.userID, tt.roles...)
+
+			w := doJSON(r, tt.method, tt.path, tt.body)
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+
+			if tt.method == http.MethodGet && tt.wantStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"tickets_sold":3`)
+			}
+			if tt.method == http.MethodPatch && !tierPath && tt.wantStatus == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"available_tickets":400`)
+				assert.Contains(t, w.Body.String(), `"transfers_enabled":false`)
+			}
+			if body["available_tickets"] != nil {
+				assert.Contains(t, w.Body.String(), `"available_tickets":500`)
+			}
+		})
+	}
+}
+
+// TestEventHandler_Create
+// Summary: Event create requests with zero and missing prices or ticket counts
+// Purpose: Ensure free events and events opening with no tickets on sale are accepted,
+// while leaving out ticket_price or available_tickets is still a bad request
+func TestEventHandler_Create(t *testing.T) {
+	eventDate := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	newBody := func(overrides map[string]any) map[string]any {
+		body := map[string]any{
+			"title":             "Community Meetup",
+			"event_date":        eventDate,
+			"venue_id":          "6f1c2d3e-4a5b-4c6d-8e7f-9a0b1c2d3e4f",
+			"ticket_price":      0,
+			"total_tickets":     100,
+			"available_tickets": 0,
+		}
+		for key, value := range overrides {
+			if value == nil {
+				delete(body, key)
+				continue
+			}
+			body[key] = value
+		}
+		return body
+	}
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		wantStatus int
+	}{
+		{name: "free event with no tickets on sale yet", body: newBody(nil), wantStatus: http.StatusCreated},
+		{name: "missing ticket price", body: newBody(map[string]any{"ticket_price": nil}), wantStatus: http.StatusBadRequest},
+		{name: "missing available tickets", body: newBody(map[string]any{"available_tickets": nil}), wantStatus: http.StatusBadRequest},
+		{name: "negative ticket price", body: newBody(map[string]any{"ticket_price": -1}), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventRepo := mocks.NewEventRepository(t)
+			mockVenueRepo := mocks.NewVenueRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			if tt.wantStatus == http.StatusCreated {
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Events: mockEventRepo, Venues: mockVenueRepo})
+					},
+				).Once()
+				mockVenueRepo.On("FindByIDForUpdate", mock.Anything, mock.Anything).
+					Return(&models.Venue{ID: "venue-001", Capacity: 1000}, nil).Once()
+				mockEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *models.Event) bool {
+					return e.TicketPrice == 0 && e.AvailableTickets == 0 && e.TotalTickets == 100
+				})).Return(nil).Once()
+			}
+
+			eventSvc := services.NewEventService(mockEventRepo, mocks.NewTicketRepository(t), mocks.NewTicketTierRepository(t), mockVenueRepo, uow, zerolog.Nop())
+			r := gin.New()
+			r.Use(asUser("organizer-001", models.RoleOrganizer))
+			r.POST("/events", NewEventHandler(eventSvc).Create)
+
+			w := doJSON(r, http.MethodPost, "/events", tt.body)
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+// TestEventHandler_GetByID
+// Summary: Reading one event
+// Purpose: Ensure missing and malformed event ids are not found, while a failing lookup is a server error
+// rather than a missing event
+func TestEventHandler_GetByID(t *testing.T) {
+	const eventID = "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e"
+
+	tests := []struct {
+		name       string
+		id         string
+		findErr    error
+		wantStatus int
+	}{
+		{name: "event found", id: eventID, wantStatus: http.StatusOK},
+		{name: "missing event", id: eventID, findErr: repositories.ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "malformed id", id: "not-a-uuid", wantStatus: http.StatusNotFound},
+		{name: "database failure", id: eventID, findErr: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventRepo := mocks.NewEventRepository(t)
+			mockTierRepo := mocks.NewTicketTierRepository(t)
+			mockVenueRepo := mocks.NewVenueRepository(t)
+			switch {
+			case tt.id != eventID:
+				// Malformed ids are answered without a query
+			case tt.findErr != nil:
+				mockEventRepo.On("FindByID", mock.Anything, eventID).Return(nil, tt.findErr).Once()
+			default:
+				mockEventRepo.On("FindByID", mock.Anything, eventID).
+					Return(&models.Event{ID: eventID, VenueID: "venue-001"}, nil).Once()
+				mockVenueRepo.On("ListByIDs", mock.Anything, []string{"venue-001"}).
+					Return([]*models.Venue{{ID: "venue-001", Name: "Jakarta Convention Center"}}, nil).Once()
+				mockTierRepo.On("ListByEventIDs", mock.Anything, []string{eventID}).Return([]*models.TicketTier{}, nil).Once()
+			}
+
+			eventSvc := services.NewEventService(mockEventRepo, mocks.NewTicketRepository(t), mockTierRepo, mockVenueRepo, mocks.NewUnitOfWork(t), zerolog.Nop())
+			r := gin.New()
+			r.GET("/events/:id", NewEventHandler(eventSvc).GetByID)
+
+			w := doJSON(r, http.MethodGet, "/events/"+tt.id, nil)
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
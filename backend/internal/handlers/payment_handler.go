@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/baramulti/ticketing-system/backend/internal/payment"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/baramulti/ticketing-system/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBody caps webhook payloads; gateway events are a few hundred bytes
+const maxWebhookBody = 64 << 10
+
+type PaymentHandler struct {
+	paymentSvc services.PaymentService
+}
+
+func NewPaymentHandler(paymentSvc services.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentSvc: paymentSvc}
+}
+
+// Webhook receives asynchronous payment updates from the gateway. It is
+// authenticated by the HMAC signature, not a user token.
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBody)
+	payload, err := c.GetRawData()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	outcome, err := h.paymentSvc.HandleWebhook(c.Request.Context(), payload, c.GetHeader(payment.WebhookSignatureHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrBadWebhookSignature):
+			response.Error(c, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, services.ErrInvalidWebhook):
+			response.Error(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrWebhookNotConfigured):
+			response.Error(c, http.StatusServiceUnavailable, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to process webhook")
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"status": outcome})
+}
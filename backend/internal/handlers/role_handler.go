@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/baramulti/ticketing-system/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	roleSvc services.RoleService
+}
+
+func NewRoleHandler(roleSvc services.RoleService) *RoleHandler {
+	return &RoleHandler{roleSvc: roleSvc}
+}
+
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleSvc.ListRoles(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to list roles")
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"roles": roles})
+}
+
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body: name must be lowercase letters, digits, '_' or '-'")
+		return
+	}
+
+	role, err := h.roleSvc.CreateRole(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err, "failed to create role")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, role)
+}
+
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	permissions, err := h.roleSvc.ListPermissions(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to list permissions")
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"permissions": permissions})
+}
+
+func (h *RoleHandler) CreatePermission(c *gin.Context) {
+	var req dto.CreatePermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body: name must be in resource.action format")
+		return
+	}
+
+	permission, err := h.roleSvc.CreatePermission(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err, "failed to create permission")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, permission)
+}
+
+func (h *RoleHandler) AttachPermission(c *gin.Context) {
+	var req dto.RolePermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body: permission must be in resource.action format")
+		return
+	}
+
+	if err := h.roleSvc.AttachPermission(c.Request.Context(), c.Param("id"), req.Permission); err != nil {
+		h.handleError(c, err, "failed to attach permission")
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"role_id": c.Param("id"), "permission": req.Permission})
+}
+
+func (h *RoleHandler) DetachPermission(c *gin.Context) {
+	permission := c.Param("permission")
+	if err := h.roleSvc.DetachPermission(c.Request.Context(), c.Param("id"), permission); err != nil {
+		h.handleError(c, err, "failed to detach permission")
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"role_id": c.Param("id"), "permission": permission})
+}
+
+// handleError maps role service errors to HTTP responses
+func (h *RoleHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrRoleNotFound),
+		errors.Is(err, services.ErrPermissionNotFound),
+		errors.Is(err, services.ErrPermissionNotAttached):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrRoleAlreadyExists), errors.Is(err, services.ErrPermissionExists):
+		response.Error(c, http.StatusConflict, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, fallback)
+	}
+}
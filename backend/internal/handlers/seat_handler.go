@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/baramulti/ticketing-system/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type SeatHandler struct {
+	seatSvc services.SeatService
+}
+
+func NewSeatHandler(seatSvc services.SeatService) *SeatHandler {
+	return &SeatHandler{seatSvc: seatSvc}
+}
+
+// CreateSeatMap stores a venue layout with its sections, rows and seats
+func (h *SeatHandler) CreateSeatMap(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.CreateSeatMapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	seatMap, err := h.seatSvc.CreateSeatMap(c.Request.Context(), actor, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSeatMapTooLarge), errors.Is(err, services.ErrDuplicateSeat):
+			response.Error(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrVenueNotFound):
+			response.Error(c, http.StatusNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to create seat map")
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, seatMap)
+}
+
+func (h *SeatHandler) GetSeatMap(c *gin.Context) {
+	seatMap, err := h.seatSvc.GetSeatMap(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrSeatMapNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to fetch seat map")
+		return
+	}
+
+	response.Success(c, http.StatusOK, seatMap)
+}
+
+// EventSeats reports which seats of an event are still free. Front ends poll
+// it, so it answers 304 when the seats have not changed since the ETag the
+// client sends back in If-None-Match.
+func (h *SeatHandler) EventSeats(c *gin.Context) {
+	seats, err := h.seatSvc.GetEventSeats(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEventNotFound), errors.Is(err, services.ErrNotSeated):
+			response.Error(c, http.StatusNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to fetch seats")
+		}
+		return
+	}
+
+	etag := seatsETag(seats)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=2")
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	response.Success(c, http.StatusOK, seats)
+}
+
+// seatsETag fingerprints the availability of every seat
+func seatsETag(seats *dto.EventSeatsResponse) string {
+	hash := sha256.New()
+	for _, seat := range seats.Seats {
+		hash.Write([]byte(seat.SeatID))
+		if seat.Available {
+			hash.Write([]byte{1})
+		} else {
+			hash.Write([]byte{0})
+		}
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
@@ -1,20 +1,29 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
 	"github.com/baramulti/ticketing-system/backend/internal/services"
 	"github.com/baramulti/ticketing-system/backend/pkg/response"
 	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
 )
 
+// qrCodeSize is the width and height of QR code images in pixels
+const qrCodeSize = 512
+
 type TicketHandler struct {
-	ticketSvc services.TicketService
+	ticketSvc   services.TicketService
+	passSvc     services.TicketPassService
+	transferSvc services.TicketTransferService
 }
 
-func NewTicketHandler(ticketSvc services.TicketService) *TicketHandler {
-	return &TicketHandler{ticketSvc: ticketSvc}
+func NewTicketHandler(ticketSvc services.TicketService, passSvc services.TicketPassService, transferSvc services.TicketTransferService) *TicketHandler {
+	return &TicketHandler{ticketSvc: ticketSvc, passSvc: passSvc, transferSvc: transferSvc}
 }
 
 func (h *TicketHandler) Purchase(c *gin.Context) {
@@ -33,11 +42,32 @@ func (h *TicketHandler) Purchase(c *gin.Context) {
 
 	result, err := h.ticketSvc.PurchaseTicket(c.Request.Context(), userID.(string), &req)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, err.Error())
+		switch {
+		case errors.Is(err, services.ErrEventNotFound), errors.Is(err, services.ErrTierNotFound):
+			response.Error(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrEventEnded), errors.Is(err, services.ErrInsufficientTickets),
+			errors.Is(err, services.ErrTierNotOnSale), errors.Is(err, services.ErrSeatsUnavailable):
+			response.Error(c, http.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrTierRequired), errors.Is(err, services.ErrTierOrderLimit),
+			errors.Is(err, services.ErrNotSeated), errors.Is(err, services.ErrSeatsRequired),
+			errors.Is(err, services.ErrSeatCountMismatch):
+			response.Error(c, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, services.ErrPaymentDeclined):
+			response.Error(c, http.StatusPaymentRequired, err.Error())
+		case errors.Is(err, services.ErrPaymentFailed):
+			response.Error(c, http.StatusBadGateway, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to purchase tickets")
+		}
 		return
 	}
 
-	response.Success(c, http.StatusOK, result)
+	// Payment still settling: the order exists but is not confirmed yet
+	if result.Status != string(models.OrderStatusConfirmed) {
+		response.Success(c, http.StatusAccepted, result)
+		return
+	}
+	response.Success(c, http.StatusCreated, result)
 }
 
 func (h *TicketHandler) GetUserOrders(c *gin.Context) {
@@ -55,4 +85,175 @@ func (h *TicketHandler) GetUserOrders(c *gin.Context) {
 	}
 
 	response.Success(c, http.StatusOK, gin.H{"orders": orders})
-}
\ No newline at end of file
+}
+
+func (h *TicketHandler) GetOrder(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	order, err := h.ticketSvc.GetOrderByID(c.Request.Context(), actor, c.Param("id"))
+	if err != nil {
+		writeOrderError(c, err, "failed to get order")
+		return
+	}
+
+	response.Success(c, http.StatusOK, order)
+}
+
+func (h *TicketHandler) CancelOrder(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	// The body is optional
+	var req dto.CloseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	order, err := h.ticketSvc.CancelOrder(c.Request.Context(), actor, c.Param("id"), req.Reason)
+	if err != nil {
+		writeOrderError(c, err, "failed to cancel order")
+		return
+	}
+
+	response.Success(c, http.StatusOK, order)
+}
+
+func (h *TicketHandler) RefundOrder(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	// The body is optional
+	var req dto.CloseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	order, err := h.ticketSvc.RefundOrder(c.Request.Context(), actor, c.Param("id"), req.Reason)
+	if err != nil {
+		writeOrderError(c, err, "failed to refund order")
+		return
+	}
+
+	response.Success(c, http.StatusOK, order)
+}
+
+// QR renders the ticket's signed pass as a PNG QR code
+func (h *TicketHandler) QR(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	pass, err := h.passSvc.IssuePass(c.Request.Context(), actor, c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrTicketNotFound):
+			response.Error(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrTicketNotValid):
+			response.Error(c, http.StatusConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to issue ticket pass")
+		}
+		return
+	}
+
+	png, err := qrcode.Encode(pass, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to render QR code")
+		return
+	}
+
+	// The pass is a bearer credential for entry, so keep it out of shared caches
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// PassKeys serves the public keys for ticket passes as a bare JWK Set, so
+// gate scanners can verify QR codes offline
+func (h *TicketHandler) PassKeys(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.passSvc.PublicKeys())
+}
+
+// Transfer gives one of the actor's tickets to another registered user
+func (h *TicketHandler) Transfer(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.TransferTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	result, err := h.transferSvc.TransferTicket(c.Request.Context(), actor, c.Param("id"), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrTicketNotFound):
+			response.Error(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrTicketNotTransferable),
+			errors.Is(err, services.ErrTransfersDisabled),
+			errors.Is(err, services.ErrTransferCutoffPassed):
+			response.Error(c, http.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrRecipientNotFound), errors.Is(err, services.ErrTransferToSelf):
+			response.Error(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to transfer ticket")
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, result)
+}
+
+// TransferChain lists the transfers a ticket went through
+func (h *TicketHandler) TransferChain(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	chain, err := h.transferSvc.GetTransferChain(c.Request.Context(), actor, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrTicketNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to load transfers")
+		return
+	}
+
+	response.Success(c, http.StatusOK, chain)
+}
+
+// writeOrderError maps order service errors to HTTP responses
+func writeOrderError(c *gin.Context, err error, fallback string) {
+	var transitionErr *models.TransitionError
+	switch {
+	case errors.Is(err, services.ErrOrderNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.As(err, &transitionErr), errors.Is(err, services.ErrOrderHasTransfers):
+		response.Error(c, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrRefundFailed):
+		response.Error(c, http.StatusBadGateway, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, fallback)
+	}
+}
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
 	"github.com/baramulti/ticketing-system/backend/internal/services"
 	"github.com/baramulti/ticketing-system/backend/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -21,10 +24,12 @@ func NewUserHandler(userSvc services.UserService) *UserHandler {
 func (h *UserHandler) GetMe(c *gin.Context) {
 	// Extract user ID from context (set by auth middleware)
 	userID, _ := c.Get("user_id")
-	// TODO: Check user is Exist?
-
 
 	user, err := h.userSvc.GetByID(c.Request.Context(), userID.(string))
+	if errors.Is(err, repositories.ErrNotFound) {
+		response.Error(c, http.StatusNotFound, "user not found")
+		return
+	}
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "failed to fetch user")
 		return
@@ -46,4 +51,56 @@ func (h *UserHandler) Update(c *gin.Context) {
 func (h *UserHandler) Delete(c *gin.Context) {
 	// TODO: admin only - delete user
 	response.Error(c, http.StatusNotImplemented, "not implemented")
-}
\ No newline at end of file
+}
+
+// AssignRole grants a role to the user in the path. Admin only.
+func (h *UserHandler) AssignRole(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err := h.userSvc.AssignRole(c.Request.Context(), c.Param("id"), req.Role, adminID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrRoleNotFound):
+			response.Error(c, http.StatusNotFound, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to assign role")
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"user_id": c.Param("id"), "role": req.Role})
+}
+
+// RevokeRole removes the role in the path from the user in the path. Admin only.
+func (h *UserHandler) RevokeRole(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	err := h.userSvc.RevokeRole(c.Request.Context(), c.Param("id"), c.Param("role"), adminID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserNotFound), errors.Is(err, services.ErrRoleNotAssigned):
+			response.Error(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, services.ErrCannotRevokeOwnAdmin):
+			response.Error(c, http.StatusConflict, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to revoke role")
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"user_id": c.Param("id"), "role": c.Param("role")})
+}
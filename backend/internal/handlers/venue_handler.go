@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/baramulti/ticketing-system/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+type VenueHandler struct {
+	venueSvc services.VenueService
+}
+
+func NewVenueHandler(venueSvc services.VenueService) *VenueHandler {
+	return &VenueHandler{venueSvc: venueSvc}
+}
+
+func (h *VenueHandler) GetByID(c *gin.Context) {
+	venue, err := h.venueSvc.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed to fetch venue")
+		return
+	}
+
+	response.Success(c, http.StatusOK, venue)
+}
+
+func (h *VenueHandler) List(c *gin.Context) {
+	var q dto.PageQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	venues, err := h.venueSvc.List(c.Request.Context(), q.Page, q.PageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to list venues")
+		return
+	}
+
+	response.Success(c, http.StatusOK, venues)
+}
+
+func (h *VenueHandler) Create(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.CreateVenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	venue, err := h.venueSvc.Create(c.Request.Context(), actor, &req)
+	if err != nil {
+		h.handleError(c, err, "failed to create venue")
+		return
+	}
+
+	response.Success(c, http.StatusCreated, venue)
+}
+
+func (h *VenueHandler) Update(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	var req dto.UpdateVenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	venue, err := h.venueSvc.Update(c.Request.Context(), actor, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err, "failed to update venue")
+		return
+	}
+
+	response.Success(c, http.StatusOK, venue)
+}
+
+func (h *VenueHandler) Delete(c *gin.Context) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "user not authenticated")
+		return
+	}
+
+	if err := h.venueSvc.Delete(c.Request.Context(), actor, c.Param("id")); err != nil {
+		h.handleError(c, err, "failed to delete venue")
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"message": "venue deleted"})
+}
+
+// handleError maps venue service errors to HTTP responses
+func (h *VenueHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrVenueNotFound):
+		response.Error(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrNotVenueOwner):
+		response.Error(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrInvalidTimeZone):
+		response.Error(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrCapacityBelowEvents), errors.Is(err, services.ErrVenueHasEvents):
+		response.Error(c, http.StatusConflict, err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, fallback)
+	}
+}
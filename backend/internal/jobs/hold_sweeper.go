@@ -0,0 +1,50 @@
+// Package jobs holds background work that runs inside the API process.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// HoldReleaser releases pending orders whose ticket hold has expired
+type HoldReleaser interface {
+	ReleaseExpiredHolds(ctx context.Context) (int, error)
+}
+
+// HoldSweeper periodically returns expired holds to the inventory. Releasing
+// is safe to run from several API instances at once.
+type HoldSweeper struct {
+	releaser HoldReleaser
+	interval time.Duration
+	log      zerolog.Logger
+}
+
+// NewHoldSweeper creates a sweeper that runs every interval
+func NewHoldSweeper(releaser HoldReleaser, interval time.Duration, log zerolog.Logger) *HoldSweeper {
+	return &HoldSweeper{
+		releaser: releaser,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Run sweeps immediately and then every interval until ctx is cancelled.
+// Errors are logged by the releaser and retried on the next tick.
+func (s *HoldSweeper) Run(ctx context.Context) {
+	s.log.Info().Dur("interval", s.interval).Msg("hold sweeper started")
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = s.releaser.ReleaseExpiredHolds(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.log.Info().Msg("hold sweeper stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
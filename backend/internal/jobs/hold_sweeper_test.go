@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingReleaser struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (r *countingReleaser) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	r.calls.Add(1)
+	return 0, r.err
+}
+
+// TestHoldSweeper_Run
+// Summary: Background release of expired holds
+// Purpose: Verify the sweeper runs on start and on every tick, keeps going after errors, and stops with its context
+func TestHoldSweeper_Run(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "releases on every tick"},
+		{name: "keeps running after errors", err: errors.New("database unavailable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			releaser := &countingReleaser{err: tt.err}
+			sweeper := NewHoldSweeper(releaser, 5*time.Millisecond, zerolog.Nop())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				sweeper.Run(ctx)
+				close(done)
+			}()
+
+			assert.Eventually(t, func() bool { return releaser.calls.Load() >= 3 }, time.Second, time.Millisecond)
+			cancel()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("sweeper did not stop after cancel")
+			}
+		})
+	}
+}
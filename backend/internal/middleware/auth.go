@@ -1,11 +1,11 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
-	"github.com/baramulti/ticketing-system/backend/internal/config"
-	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
 	"github.com/baramulti/ticketing-system/backend/pkg/response"
 	"github.com/gin-gonic/gin"
 )
@@ -16,9 +16,12 @@ const (
 	UserIDKey      = "user_id"
 	UserEmailKey   = "user_email"
 	UserRolesKey   = "user_roles"
+	AccessTokenKey = "access_token"
 )
 
-func AuthMiddleware(jwtCfg config.JWTConfig) gin.HandlerFunc {
+// AuthMiddleware validates the bearer token through the auth service, which
+// also rejects revoked tokens and deactivated users.
+func AuthMiddleware(authSvc services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader(AuthHeaderKey)
 		if authHeader == "" {
@@ -36,18 +39,32 @@ func AuthMiddleware(jwtCfg config.JWTConfig) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		// Validate JWT token
-		claims, err := jwtutil.ValidateToken(tokenString, jwtCfg.Secret)
+		// Validate JWT token. Only a bad token or account is the client's
+		// fault; an unreachable revocation store or database is not, and
+		// must not make clients drop valid sessions.
+		user, err := authSvc.ValidateToken(c.Request.Context(), tokenString)
 		if err != nil {
-			response.Error(c, http.StatusUnauthorized, "invalid or expired token")
+			if errors.Is(err, services.ErrInvalidToken) || errors.Is(err, services.ErrTokenRevoked) ||
+				errors.Is(err, services.ErrAccountDisabled) {
+				response.Error(c, http.StatusUnauthorized, "invalid or expired token")
+			} else {
+				response.Error(c, http.StatusServiceUnavailable, "failed to validate token")
+			}
 			c.Abort()
 			return
 		}
 
+		roles := make([]string, 0, len(user.Roles))
+		for _, role := range user.Roles {
+			roles = append(roles, role.Name)
+		}
+
 		// Set user info in context
-		c.Set(UserIDKey, claims.UserID)
-		c.Set(UserEmailKey, claims.Email)
-		c.Set(UserRolesKey, claims.Roles)
+		c.Set(UserContextKey, user)
+		c.Set(UserIDKey, user.ID)
+		c.Set(UserEmailKey, user.Email)
+		c.Set(UserRolesKey, roles)
+		c.Set(AccessTokenKey, tokenString)
 
 		c.Next()
 	}
@@ -68,7 +85,7 @@ func RequireRole(allowedRoles ...string) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		
+
 		for _, userRole := range userRoles {
 			for _, allowed := range allowedRoles {
 				if userRole == allowed {
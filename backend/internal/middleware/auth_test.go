@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubAuthService answers ValidateToken with a fixed result; the other
+// methods are never called
+type stubAuthService struct {
+	services.AuthService
+	user *models.User
+	err  error
+}
+
+func (s *stubAuthService) ValidateToken(ctx context.Context, token string) (*models.User, error) {
+	return s.user, s.err
+}
+
+// TestAuthMiddleware
+// Summary: Bearer tokens the auth service accepts, rejects or cannot check
+// Purpose: Verify bad, revoked and deactivated tokens get 401, while an unreachable revocation store
+// or database gets 503 so clients keep their session and retry
+func TestAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "valid token", wantStatus: http.StatusOK},
+		{name: "invalid token", err: fmt.Errorf("%w: signature is invalid", services.ErrInvalidToken), wantStatus: http.StatusUnauthorized},
+		{name: "revoked token", err: services.ErrTokenRevoked, wantStatus: http.StatusUnauthorized},
+		{name: "deactivated user", err: services.ErrAccountDisabled, wantStatus: http.StatusUnauthorized},
+		{name: "backend outage", err: errors.New("failed to validate token"), wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authSvc := &stubAuthService{err: tt.err}
+			if tt.err == nil {
+				authSvc.user = &models.User{ID: "user-001", Roles: []*models.Role{{Name: models.RoleUser}}}
+			}
+			r := gin.New()
+			r.GET("/me", AuthMiddleware(authSvc), func(c *gin.Context) {
+				c.String(http.StatusOK, c.GetString(UserIDKey))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			req.Header.Set(AuthHeaderKey, "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
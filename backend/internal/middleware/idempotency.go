@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/baramulti/ticketing-system/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// Idempotency makes retries of a request that carries an Idempotency-Key
+// safe. The first response for a (user, key) pair is stored and replayed for
+// later requests with the same method, path and body; reusing the key for a
+// different request is rejected with 422. Requests without the header are
+// passed through. Server errors are not stored, so the client can retry them.
+// It must run after AuthMiddleware.
+func Idempotency(store cache.IdempotencyStore, cfg config.IdempotencyConfig, log zerolog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.Error(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+
+		userID := c.GetString(UserIDKey)
+		if userID == "" {
+			response.Error(c, http.StatusUnauthorized, "user not authenticated")
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "failed to read request body")
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		storeKey := userID + ":" + key
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, body)
+
+		existing, err := store.Begin(ctx, storeKey, fingerprint, cfg.LockTimeout)
+		if err != nil {
+			// Without the store a retry could run twice, so refuse instead
+			response.Error(c, http.StatusServiceUnavailable, "failed to check idempotency key")
+			c.Abort()
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.Fingerprint != fingerprint:
+				response.Error(c, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			case existing.InProgress():
+				response.Error(c, http.StatusConflict, "a request with this Idempotency-Key is still being processed")
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(existing.Status, existing.ContentType, existing.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// The request may have been cancelled by now; the bookkeeping must still happen
+		ctx = context.WithoutCancel(ctx)
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			if err := store.Release(ctx, storeKey); err != nil {
+				log.Error().Err(err).Str("user_id", userID).Msg("failed to release idempotency key")
+			}
+			return
+		}
+
+		err = store.Complete(ctx, storeKey, &cache.IdempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}, cfg.TTL)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", userID).Msg("failed to store idempotent response")
+		}
+	}
+}
+
+// requestFingerprint identifies what a key was first used for
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies the response body while it is written to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIdempotency
+// Summary: Retried requests with an Idempotency-Key
+// Purpose: Verify the first response is replayed for identical retries, reused keys with another body get 422,
+// keys are scoped per user, and server errors are not stored
+func TestIdempotency(t *testing.T) {
+	type call struct {
+		userID     string
+		key        string
+		body       string
+		wantStatus int
+		wantReplay bool
+	}
+
+	tests := []struct {
+		name          string
+		handlerStatus int
+		calls         []call
+		wantRuns      int
+	}{
+		{
+			name:          "retry is replayed",
+			handlerStatus: http.StatusCreated,
+			calls: []call{
+				{userID: "user-001", key: "key-1", body: `{"quantity":2}`, wantStatus: http.StatusCreated},
+				{userID: "user-001", key: "key-1", body: `{"quantity":2}`, wantStatus: http.StatusCreated, wantReplay: true},
+			},
+			wantRuns: 1,
+		},
+		{
+			name:          "key reused with a different body",
+			handlerStatus: http.StatusCreated,
+			calls: []call{
+				{userID: "user-001", key: "key-1", body: `{"quantity":2}`, wantStatus: http.StatusCreated},
+				{userID: "user-001", key: "key-1", body: `{"quantity":3}`, wantStatus: http.StatusUnprocessableEntity},
+			},
+			wantRuns: 1,
+		},
+		{
+			name:          "keys are per user",
+			handlerStatus: http.StatusCreated,
+			calls: []call{
+				{userID: "user-001", key: "key-1", body: `{"quantity":2}`, wantStatus: http.StatusCreated},
+				{userID: "user-002", key: "key-1", body: `{"quantity":2}`, wantStatus: http.StatusCreated},
+			},
+			wantRuns: 2,
+		},
+		{
+			name:          "client errors are replayed",
+			handlerStatus: http.StatusPaymentRequired,
+			calls: []call{
+				{userID: "user-001", key: "key-1", body: `{}`, wantStatus: http.StatusPaymentRequired},
+				{userID: "user-001", key: "key-1", body: `{}`, wantStatus: http.StatusPaymentRequired, wantReplay: true},
+			},
+			wantRuns: 1,
+		},
+		{
+			name:          "server errors can be retried",
+			handlerStatus: http.StatusBadGateway,
+			calls: []call{
+				{userID: "user-001", key: "key-1", body: `{}`, wantStatus: http.StatusBadGateway},
+				{userID: "user-001", key: "key-1", body: `{}`, wantStatus: http.StatusBadGateway},
+			},
+			wantRuns: 2,
+		},
+		{
+			name:          "no key",
+			handlerStatus: http.StatusCreated,
+			calls: []call{
+				{userID: "user-001", body: `{}`, wantStatus: http.StatusCreated},
+				{userID: "user-001", body: `{}`, wantStatus: http.StatusCreated},
+			},
+			wantRuns: 2,
+		},
+		{
+			name:          "key too long",
+			handlerStatus: http.StatusCreated,
+			calls: []call{
+				{userID: "user-001", key: strings.Repeat("k", 256), body: `{}`, wantStatus: http.StatusBadRequest},
+			},
+			wantRuns: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := 0
+			mw := Idempotency(cache.NewMemoryIdempotencyStore(), config.IdempotencyConfig{TTL: time.Hour, LockTimeout: time.Minute}, zerolog.Nop())
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set(UserIDKey, c.GetHeader("X-Test-User"))
+				c.Next()
+			})
+			r.POST("/tickets/purchase", mw, func(c *gin.Context) {
+				runs++
+				c.JSON(tt.handlerStatus, gin.H{"run": runs})
+			})
+
+			var first string
+			for i, call := range tt.calls {
+				req := httptest.NewRequest(http.MethodPost, "/tickets/purchase", strings.NewReader(call.body))
+				req.Header.Set("X-Test-User", call.userID)
+				if call.key != "" {
+					req.Header.Set(IdempotencyKeyHeader, call.key)
+				}
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+
+				assert.Equal(t, call.wantStatus, w.Code, "call %d", i)
+				if call.wantReplay {
+					assert.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+					assert.Equal(t, first, w.Body.String(), "replay should return the original body")
+				} else {
+					assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+				}
+				if i == 0 {
+					first = w.Body.String()
+				}
+			}
+			assert.Equal(t, tt.wantRuns, runs)
+		})
+	}
+
+	t.Run("request still in progress", func(t *testing.T) {
+		store := cache.NewMemoryIdempotencyStore()
+		mw := Idempotency(store, config.IdempotencyConfig{TTL: time.Hour, LockTimeout: time.Minute}, zerolog.Nop())
+		body := `{"quantity":2}`
+		_, _ = store.Begin(context.Background(), "user-001:key-1", requestFingerprint(http.MethodPost, "/tickets/purchase", []byte(body)), time.Minute)
+
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set(UserIDKey, "user-001")
+			c.Next()
+		})
+		r.POST("/tickets/purchase", mw, func(c *gin.Context) {
+			t.Error("handler should not run while the first request is in progress")
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/tickets/purchase", strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/baramulti/ticketing-system/backend/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission allows the request only if one of the user's roles grants
+// the permission (user_roles -> role_permissions -> permissions). It must run
+// after AuthMiddleware. Lookup failures deny access rather than fail open.
+func RequirePermission(permSvc services.PermissionService, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString(UserIDKey)
+		if userID == "" {
+			response.Error(c, http.StatusUnauthorized, "user not authenticated")
+			c.Abort()
+			return
+		}
+
+		allowed, err := permSvc.HasPermission(c.Request.Context(), userID, permission)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "failed to check permissions")
+			c.Abort()
+			return
+		}
+
+		if !allowed {
+			response.Error(c, http.StatusForbidden, "insufficient permissions")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
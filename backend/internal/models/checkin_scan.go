@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// CheckinScan is one scan of a ticket at a gate
+type CheckinScan struct {
+	ID          string    `db:"id" json:"id"`
+	TicketID    *string   `db:"ticket_id" json:"ticket_id,omitempty"` // nil when the code matched no ticket
+	EventID     string    `db:"event_id" json:"event_id"`
+	ValidatorID *string   `db:"validator_id" json:"validator_id,omitempty"`
+	DeviceID    *string   `db:"device_id" json:"device_id,omitempty"`
+	Gate        *string   `db:"gate" json:"gate,omitempty"`
+	Source      string    `db:"source" json:"source"`
+	Result      string    `db:"result" json:"result"`
+	Reason      *string   `db:"reason" json:"reason,omitempty"`
+	ScannedAt   time.Time `db:"scanned_at" json:"scanned_at"`
+	ReceivedAt  time.Time `db:"received_at" json:"received_at"`
+}
+
+// ScanSource tells live scans apart from ones uploaded after the fact
+type ScanSource string
+
+const (
+	ScanSourceOnline  ScanSource = "online"
+	ScanSourceOffline ScanSource = "offline"
+)
+
+// ScanResult is what the server decided for a scan
+type ScanResult string
+
+const (
+	ScanResultAdmitted  ScanResult = "admitted"
+	ScanResultDuplicate ScanResult = "duplicate" // the ticket was admitted by an earlier scan
+	ScanResultRejected  ScanResult = "rejected"
+)
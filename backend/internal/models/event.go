@@ -8,10 +8,51 @@ type Event struct {
 	Title            string    `db:"title" json:"title"`
 	Description      string    `db:"description" json:"description"`
 	EventDate        time.Time `db:"event_date" json:"event_date"`
-	Venue            string    `db:"venue" json:"venue"`
+	VenueID          string    `db:"venue_id" json:"venue_id"`
 	TicketPrice      float64   `db:"ticket_price" json:"ticket_price"`
 	TotalTickets     int       `db:"total_tickets" json:"total_tickets"`
 	AvailableTickets int       `db:"available_tickets" json:"available_tickets"`
+	OrganizerID      *string   `db:"organizer_id" json:"organizer_id,omitempty"` // nil for admin-managed events
+	TransfersEnabled bool      `db:"transfers_enabled" json:"transfers_enabled"`
+	SeatMapID        *string   `db:"seat_map_id" json:"seat_map_id,omitempty"` // set for reserved seating
 	CreatedAt        time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+
+	// Relationships (loaded separately)
+	Venue *Venue        `db:"-" json:"venue,omitempty"`
+	Tiers []*TicketTier `db:"-" json:"tiers,omitempty"`
+}
+
+// EventFilter narrows an event listing. Fields left nil or empty do not filter.
+type EventFilter struct {
+	From      *time.Time // event_date on or after, UTC
+	To        *time.Time // event_date on or before, UTC
+	VenueID   string
+	MinPrice  *float64
+	MaxPrice  *float64
+	Available *bool // true: tickets left, false: sold out
+}
+
+// Sort keys of an event listing. Ties are broken by id.
+const (
+	EventSortDate  = "date"
+	EventSortPrice = "price"
+)
+
+// EventCursor is the last event of a page; a keyset listing continues after it
+type EventCursor struct {
+	EventDate   time.Time
+	TicketPrice float64
+	ID          string
+}
+
+// EventQuery selects one page of events. With After set, Offset is ignored
+// and the page starts right after that event in the sort order.
+type EventQuery struct {
+	Filter   EventFilter
+	SortBy   string // EventSortDate or EventSortPrice
+	SortDesc bool
+	After    *EventCursor
+	Limit    int
+	Offset   int
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PaymentWebhookEvent is a webhook delivery accepted from the payment gateway.
+// ID is the gateway's event ID.
+type PaymentWebhookEvent struct {
+	ID            string    `db:"id" json:"id"`
+	EventType     string    `db:"event_type" json:"event_type"`
+	TransactionID string    `db:"transaction_id" json:"transaction_id"`
+	Reference     string    `db:"reference" json:"reference"`
+	Payload       []byte    `db:"payload" json:"-"`
+	ReceivedAt    time.Time `db:"received_at" json:"received_at"`
+}
@@ -19,6 +19,11 @@ const (
 	PermEventUpdate = "events.update"
 	PermEventDelete = "events.delete"
 
+	// Venue permissions
+	PermVenueCreate = "venues.create"
+	PermVenueUpdate = "venues.update"
+	PermVenueDelete = "venues.delete"
+
 	// User permissions
 	PermUserCreate = "users.create"
 	PermUserRead   = "users.read"
@@ -30,4 +35,7 @@ const (
 	PermTicketRead     = "tickets.read"
 	PermTicketValidate = "tickets.validate"
 	PermTicketRefund   = "tickets.refund"
+
+	// Role management permissions
+	PermRoleManage = "roles.manage"
 )
\ No newline at end of file
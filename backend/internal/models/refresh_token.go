@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// RefreshToken represents a server-side record of an opaque refresh token.
+// The raw token is never stored, only its SHA-256 hash.
+type RefreshToken struct {
+	ID         string     `db:"id" json:"id"`
+	UserID     string     `db:"user_id" json:"user_id"`
+	FamilyID   string     `db:"family_id" json:"family_id"`
+	TokenHash  string     `db:"token_hash" json:"-"`
+	ExpiresAt  time.Time  `db:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revoked_at,omitempty"`
+	ReplacedBy *string    `db:"replaced_by" json:"replaced_by,omitempty"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// SeatMap is a venue's seating layout
+type SeatMap struct {
+	ID        string    `db:"id" json:"id"`
+	Name      string    `db:"name" json:"name"`
+	VenueID   string    `db:"venue_id" json:"venue_id"`
+	CreatedBy *string   `db:"created_by" json:"created_by,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+
+	// Relationships (loaded separately)
+	Seats []*Seat `db:"-" json:"seats,omitempty"`
+}
+
+// Seat is one numbered seat of a seat map
+type Seat struct {
+	ID        string `db:"id" json:"id"`
+	SeatMapID string `db:"seat_map_id" json:"seat_map_id"`
+	Section   string `db:"section" json:"section"`
+	Row       string `db:"row_label" json:"row"`
+	Number    int    `db:"number" json:"number"`
+}
+
+// EventSeat is a seat's availability for one event
+type EventSeat struct {
+	SeatID    string `db:"seat_id" json:"seat_id"`
+	Section   string `db:"section" json:"section"`
+	Row       string `db:"row_label" json:"row"`
+	Number    int    `db:"number" json:"number"`
+	Available bool   `db:"available" json:"available"`
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // TicketOrder represents a ticket purchase order
 type TicketOrder struct {
@@ -10,14 +13,41 @@ type TicketOrder struct {
 	Quantity   int       `db:"quantity" json:"quantity"`
 	TotalPrice float64   `db:"total_price" json:"total_price"`
 	Status     string    `db:"status" json:"status"`
+	Source     string    `db:"source" json:"source"` // purchase, or transfer for a ticket received from another user
+	TierID     *string   `db:"tier_id" json:"tier_id,omitempty"`
 	PaymentID  *string   `db:"payment_id" json:"payment_id,omitempty"`
 	CreatedAt  time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
 
+	// HoldExpiresAt is when a still-pending order releases its tickets
+	HoldExpiresAt *time.Time `db:"hold_expires_at" json:"hold_expires_at,omitempty"`
+
 	// Relationships (loaded via joins)
-	Event   *Event   `db:"-" json:"event,omitempty"`
-	User    *User    `db:"-" json:"user,omitempty"`
-	Tickets []Ticket `db:"-" json:"tickets,omitempty"`
+	Event   *Event               `db:"-" json:"event,omitempty"`
+	User    *User                `db:"-" json:"user,omitempty"`
+	Tickets []Ticket             `db:"-" json:"tickets,omitempty"`
+	History []OrderStatusHistory `db:"-" json:"history,omitempty"`
+}
+
+// OrderStatusHistory is one recorded change of an order's status
+type OrderStatusHistory struct {
+	ID         string    `db:"id" json:"id"`
+	OrderID    string    `db:"order_id" json:"order_id"`
+	FromStatus *string   `db:"from_status" json:"from_status"` // nil for the order's creation
+	ToStatus   string    `db:"to_status" json:"to_status"`
+	ChangedBy  *string   `db:"changed_by" json:"changed_by"` // nil for system changes
+	Reason     string    `db:"reason" json:"reason"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// OrderStatusChange describes a status update and why it happened. ActorID
+// is the user who caused it, or nil when the system did (e.g. the hold sweeper).
+type OrderStatusChange struct {
+	OrderID string
+	From    TicketOrderStatus
+	To      TicketOrderStatus
+	ActorID *string
+	Reason  string
 }
 
 // Ticket represents an individual ticket
@@ -26,6 +56,7 @@ type Ticket struct {
 	OrderID    string     `db:"order_id" json:"order_id"`
 	TicketCode string     `db:"ticket_code" json:"ticket_code"`
 	Status     string     `db:"status" json:"status"`
+	SeatID     *string    `db:"seat_id" json:"seat_id,omitempty"` // set for reserved seating
 	UsedAt     *time.Time `db:"used_at" json:"used_at,omitempty"`
 	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
 
@@ -33,6 +64,23 @@ type Ticket struct {
 	Order *TicketOrder `db:"-" json:"order,omitempty"`
 }
 
+// OrderStatusSummary aggregates an event's orders in one status (sales reporting)
+type OrderStatusSummary struct {
+	Status  string  `db:"status" json:"status"`
+	Orders  int     `db:"orders" json:"orders"`
+	Tickets int     `db:"tickets" json:"tickets"`
+	Revenue float64 `db:"revenue" json:"revenue"`
+}
+
+// OrderSource tells bought orders apart from the ones holding a ticket
+// received by transfer
+type OrderSource string
+
+const (
+	OrderSourcePurchase OrderSource = "purchase"
+	OrderSourceTransfer OrderSource = "transfer"
+)
+
 // TicketOrderStatus defines ticket order status types
 type TicketOrderStatus string
 
@@ -44,6 +92,44 @@ const (
 	OrderStatusRefunded  TicketOrderStatus = "refunded"
 )
 
+// orderTransitions lists where an order may move from each status.
+// Cancelled and refunded are final.
+var orderTransitions = map[TicketOrderStatus][]TicketOrderStatus{
+	OrderStatusPending:   {OrderStatusPaid, OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusPaid:      {OrderStatusConfirmed, OrderStatusCancelled, OrderStatusRefunded},
+	OrderStatusConfirmed: {OrderStatusRefunded},
+}
+
+// CanTransitionTo reports whether an order in status s may move to next
+func (s TicketOrderStatus) CanTransitionTo(next TicketOrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns a *TransitionError if an order in status s may
+// not move to next
+func (s TicketOrderStatus) ValidateTransition(next TicketOrderStatus) error {
+	if !s.CanTransitionTo(next) {
+		return &TransitionError{Entity: "order", From: string(s), To: string(next)}
+	}
+	return nil
+}
+
+// TransitionError is returned for a status change the state machine forbids
+type TransitionError struct {
+	Entity string // "order" or "ticket"
+	From   string
+	To     string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s cannot move from %s to %s", e.Entity, e.From, e.To)
+}
+
 // TicketStatus defines ticket status types
 type TicketStatus string
 
@@ -53,3 +139,39 @@ const (
 	TicketStatusCancelled   TicketStatus = "cancelled"
 	TicketStatusTransferred TicketStatus = "transferred"
 )
+
+// ticketTransitions lists where a ticket may move from each status. Only
+// valid tickets change; used, cancelled and transferred are final.
+var ticketTransitions = map[TicketStatus][]TicketStatus{
+	TicketStatusValid: {TicketStatusUsed, TicketStatusCancelled, TicketStatusTransferred},
+}
+
+// CanTransitionTo reports whether a ticket in status s may move to next
+func (s TicketStatus) CanTransitionTo(next TicketStatus) bool {
+	for _, allowed := range ticketTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition returns a *TransitionError if a ticket in status s may
+// not move to next
+func (s TicketStatus) ValidateTransition(next TicketStatus) error {
+	if !s.CanTransitionTo(next) {
+		return &TransitionError{Entity: "ticket", From: string(s), To: string(next)}
+	}
+	return nil
+}
+
+// TicketStatusesMovableTo lists the statuses from which a ticket may move to next
+func TicketStatusesMovableTo(next TicketStatus) []TicketStatus {
+	var from []TicketStatus
+	for status := range ticketTransitions {
+		if status.CanTransitionTo(next) {
+			from = append(from, status)
+		}
+	}
+	return from
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOrderStatus_ValidateTransition
+// Summary: Order state machine
+// Purpose: Verify allowed moves pass and forbidden ones return a *TransitionError naming both statuses
+func TestOrderStatus_ValidateTransition(t *testing.T) {
+	tests := []struct {
+		from    TicketOrderStatus
+		to      TicketOrderStatus
+		allowed bool
+	}{
+		{OrderStatusPending, OrderStatusPaid, true},
+		{OrderStatusPending, OrderStatusCancelled, true},
+		{OrderStatusPaid, OrderStatusConfirmed, true},
+		{OrderStatusConfirmed, OrderStatusRefunded, true},
+		{OrderStatusPending, OrderStatusRefunded, false},
+		{OrderStatusConfirmed, OrderStatusCancelled, false},
+		{OrderStatusRefunded, OrderStatusPaid, false},
+		{OrderStatusCancelled, OrderStatusPending, false},
+		{OrderStatusPaid, OrderStatusPaid, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := tt.from.ValidateTransition(tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+
+			var transitionErr *TransitionError
+			if assert.True(t, errors.As(err, &transitionErr)) {
+				assert.Equal(t, "order", transitionErr.Entity)
+				assert.Equal(t, string(tt.from), transitionErr.From)
+				assert.Equal(t, string(tt.to), transitionErr.To)
+			}
+		})
+	}
+}
+
+// TestTicketStatus_ValidateTransition
+// Summary: Ticket state machine
+// Purpose: Verify only valid tickets change status, and the statuses that can reach each target are listed
+func TestTicketStatus_ValidateTransition(t *testing.T) {
+	assert.NoError(t, TicketStatusValid.ValidateTransition(TicketStatusUsed))
+	assert.NoError(t, TicketStatusValid.ValidateTransition(TicketStatusCancelled))
+	assert.NoError(t, TicketStatusValid.ValidateTransition(TicketStatusTransferred))
+	assert.Error(t, TicketStatusUsed.ValidateTransition(TicketStatusValid))
+	assert.Error(t, TicketStatusUsed.ValidateTransition(TicketStatusCancelled))
+	assert.Error(t, TicketStatusCancelled.ValidateTransition(TicketStatusValid))
+
+	assert.Equal(t, []TicketStatus{TicketStatusValid}, TicketStatusesMovableTo(TicketStatusCancelled))
+	assert.Empty(t, TicketStatusesMovableTo(TicketStatusValid))
+}
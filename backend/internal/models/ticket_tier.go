@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// TicketTier is a priced slice of an event's tickets with its own inventory
+type TicketTier struct {
+	ID               string     `db:"id" json:"id"`
+	EventID          string     `db:"event_id" json:"event_id"`
+	Name             string     `db:"name" json:"name"`
+	Price            float64    `db:"price" json:"price"`
+	TotalTickets     int        `db:"total_tickets" json:"total_tickets"`
+	AvailableTickets int        `db:"available_tickets" json:"available_tickets"`
+	SalesStart       *time.Time `db:"sales_start" json:"sales_start,omitempty"`     // nil: on sale from creation
+	SalesEnd         *time.Time `db:"sales_end" json:"sales_end,omitempty"`         // nil: on sale until the event
+	MaxPerOrder      *int       `db:"max_per_order" json:"max_per_order,omitempty"` // nil: only the global purchase limit
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// OnSale reports whether the tier's sale window includes now
+func (t *TicketTier) OnSale(now time.Time) bool {
+	if t.SalesStart != nil && now.Before(*t.SalesStart) {
+		return false
+	}
+	if t.SalesEnd != nil && !now.Before(*t.SalesEnd) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// TicketTransfer records one hand-over of a ticket: FromTicketID was marked
+// transferred and ToTicketID issued to the recipient in its place
+type TicketTransfer struct {
+	ID           string    `db:"id" json:"id"`
+	EventID      string    `db:"event_id" json:"event_id"`
+	FromTicketID string    `db:"from_ticket_id" json:"from_ticket_id"`
+	ToTicketID   string    `db:"to_ticket_id" json:"to_ticket_id"`
+	FromUserID   *string   `db:"from_user_id" json:"from_user_id,omitempty"` // nil once the account is deleted
+	ToUserID     *string   `db:"to_user_id" json:"to_user_id,omitempty"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+
+	// Loaded via joins for the ownership chain
+	FromEmail *string `db:"from_email" json:"from_email,omitempty"`
+	ToEmail   *string `db:"to_email" json:"to_email,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Venue is a place events take place at. Its capacity bounds the total
+// tickets of every event held there.
+type Venue struct {
+	ID        string    `db:"id" json:"id"`
+	Name      string    `db:"name" json:"name"`
+	Address   string    `db:"address" json:"address"`
+	TimeZone  string    `db:"time_zone" json:"time_zone"` // IANA name, e.g. Asia/Jakarta
+	Capacity  int       `db:"capacity" json:"capacity"`
+	Latitude  *float64  `db:"latitude" json:"latitude,omitempty"`
+	Longitude *float64  `db:"longitude" json:"longitude,omitempty"`
+	CreatedBy *string   `db:"created_by" json:"created_by,omitempty"` // nil for admin-managed venues
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
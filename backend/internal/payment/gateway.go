@@ -0,0 +1,65 @@
+// Package payment abstracts the payment provider behind Gateway so the
+// purchase flow can run against a real provider or the local Simulator.
+package payment
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrDeclined means the provider refused the charge. It is final.
+	ErrDeclined = errors.New("payment declined")
+
+	// ErrTimeout means no answer arrived in time. The outcome is unknown:
+	// the provider may still process the request and report it later.
+	ErrTimeout = errors.New("payment gateway timed out")
+
+	// ErrTransactionNotFound is returned for an unknown transaction ID
+	ErrTransactionNotFound = errors.New("payment transaction not found")
+
+	// ErrInvalidState is returned when an operation does not apply to the
+	// transaction's current status (e.g. refunding an uncaptured charge)
+	ErrInvalidState = errors.New("operation not allowed in current transaction state")
+)
+
+// Status is a transaction's state at the provider
+type Status string
+
+const (
+	StatusAuthorized Status = "authorized"
+	StatusCaptured   Status = "captured"
+	StatusDeclined   Status = "declined"
+	StatusRefunded   Status = "refunded"
+	StatusVoided     Status = "voided"
+)
+
+// ChargeRequest authorizes Amount against the buyer. Reference is our order
+// ID; providers echo it back so results can be matched to the order.
+type ChargeRequest struct {
+	Reference string
+	Amount    float64
+	Currency  string
+}
+
+// Transaction is the provider's view of a payment
+type Transaction struct {
+	ID        string
+	Reference string
+	Amount    float64
+	Currency  string
+	Status    Status
+	UpdatedAt time.Time
+}
+
+// Gateway is implemented by every payment provider. Charge only authorizes;
+// funds move on Capture. An authorization that is never captured is released
+// with Void; captured funds are returned with Refund.
+type Gateway interface {
+	Charge(ctx context.Context, req ChargeRequest) (*Transaction, error)
+	Capture(ctx context.Context, transactionID string) (*Transaction, error)
+	Void(ctx context.Context, transactionID string) (*Transaction, error)
+	Refund(ctx context.Context, transactionID string, amount float64) (*Transaction, error)
+	Status(ctx context.Context, transactionID string) (*Transaction, error)
+}
@@ -0,0 +1,160 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SimulatorMode selects how the Simulator answers
+type SimulatorMode string
+
+const (
+	// ModeSucceed approves every request immediately
+	ModeSucceed SimulatorMode = "succeed"
+	// ModeDecline declines charges (other operations still succeed)
+	ModeDecline SimulatorMode = "decline"
+	// ModeTimeout never answers; calls return ErrTimeout when ctx ends
+	ModeTimeout SimulatorMode = "timeout"
+	// ModeDelay approves after the configured delay. If ctx ends first the
+	// call returns ErrTimeout but the request is still applied, like a real
+	// provider that answers after the caller gave up.
+	ModeDelay SimulatorMode = "delay"
+)
+
+// ValidSimulatorMode reports whether mode is one of the modes above
+func ValidSimulatorMode(mode string) bool {
+	switch SimulatorMode(mode) {
+	case ModeSucceed, ModeDecline, ModeTimeout, ModeDelay:
+		return true
+	}
+	return false
+}
+
+// Simulator is an in-process Gateway for development and tests. Transactions
+// live in memory.
+type Simulator struct {
+	mu           sync.Mutex
+	mode         SimulatorMode
+	delay        time.Duration
+	transactions map[string]*Transaction
+}
+
+// NewSimulator creates a simulator in the given mode. delay is only used by
+// ModeDelay.
+func NewSimulator(mode SimulatorMode, delay time.Duration) *Simulator {
+	return &Simulator{
+		mode:         mode,
+		delay:        delay,
+		transactions: make(map[string]*Transaction),
+	}
+}
+
+// SetMode switches how subsequent requests are answered
+func (s *Simulator) SetMode(mode SimulatorMode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode = mode
+}
+
+func (s *Simulator) Charge(ctx context.Context, req ChargeRequest) (*Transaction, error) {
+	return s.do(ctx, func() (*Transaction, error) {
+		txn := &Transaction{
+			ID:        "sim_" + uuid.NewString(),
+			Reference: req.Reference,
+			Amount:    req.Amount,
+			Currency:  req.Currency,
+			Status:    StatusAuthorized,
+			UpdatedAt: time.Now(),
+		}
+		if s.mode == ModeDecline {
+			txn.Status = StatusDeclined
+		}
+		s.transactions[txn.ID] = txn
+
+		if txn.Status == StatusDeclined {
+			return copyOf(txn), ErrDeclined
+		}
+		return copyOf(txn), nil
+	})
+}
+
+func (s *Simulator) Capture(ctx context.Context, transactionID string) (*Transaction, error) {
+	return s.transition(ctx, transactionID, StatusAuthorized, StatusCaptured)
+}
+
+func (s *Simulator) Void(ctx context.Context, transactionID string) (*Transaction, error) {
+	return s.transition(ctx, transactionID, StatusAuthorized, StatusVoided)
+}
+
+func (s *Simulator) Refund(ctx context.Context, transactionID string, amount float64) (*Transaction, error) {
+	return s.do(ctx, func() (*Transaction, error) {
+		txn, ok := s.transactions[transactionID]
+		if !ok {
+			return nil, ErrTransactionNotFound
+		}
+		if amount <= 0 || amount > txn.Amount {
+			return nil, fmt.Errorf("refund amount %.2f outside (0, %.2f]", amount, txn.Amount)
+		}
+		return s.apply(txn, StatusCaptured, StatusRefunded)
+	})
+}
+
+func (s *Simulator) Status(ctx context.Context, transactionID string) (*Transaction, error) {
+	return s.do(ctx, func() (*Transaction, error) {
+		txn, ok := s.transactions[transactionID]
+		if !ok {
+			return nil, ErrTransactionNotFound
+		}
+		return copyOf(txn), nil
+	})
+}
+
+func (s *Simulator) transition(ctx context.Context, transactionID string, from, to Status) (*Transaction, error) {
+	return s.do(ctx, func() (*Transaction, error) {
+		txn, ok := s.transactions[transactionID]
+		if !ok {
+			return nil, ErrTransactionNotFound
+		}
+		return s.apply(txn, from, to)
+	})
+}
+
+func (s *Simulator) apply(txn *Transaction, from, to Status) (*Transaction, error) {
+	if txn.Status != from {
+		return nil, fmt.Errorf("%w: transaction is %s", ErrInvalidState, txn.Status)
+	}
+	txn.Status = to
+	txn.UpdatedAt = time.Now()
+	return copyOf(txn), nil
+}
+
+// do applies op under the lock and then answers according to the mode
+func (s *Simulator) do(ctx context.Context, op func() (*Transaction, error)) (*Transaction, error) {
+	s.mu.Lock()
+	mode, delay := s.mode, s.delay
+	if mode == ModeTimeout {
+		s.mu.Unlock()
+		<-ctx.Done()
+		return nil, fmt.Errorf("%w: %v", ErrTimeout, ctx.Err())
+	}
+	txn, err := op()
+	s.mu.Unlock()
+
+	if mode == ModeDelay {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %v", ErrTimeout, ctx.Err())
+		}
+	}
+	return txn, err
+}
+
+func copyOf(txn *Transaction) *Transaction {
+	c := *txn
+	return &c
+}
@@ -0,0 +1,105 @@
+package payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSimulator_Lifecycle
+// Summary: Charge, capture, refund, void and status in succeed mode
+// Purpose: Verify transactions move authorized -> captured -> refunded (or authorized -> voided) and invalid steps are rejected
+func TestSimulator_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	sim := NewSimulator(ModeSucceed, 0)
+
+	txn, err := sim.Charge(ctx, ChargeRequest{Reference: "order-001", Amount: 500000, Currency: "IDR"})
+	require.NoError(t, err)
+	assert.Equal(t, StatusAuthorized, txn.Status)
+	assert.Equal(t, "order-001", txn.Reference)
+
+	_, err = sim.Refund(ctx, txn.ID, 500000)
+	assert.ErrorIs(t, err, ErrInvalidState, "uncaptured charges cannot be refunded")
+
+	txn, err = sim.Capture(ctx, txn.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCaptured, txn.Status)
+
+	_, err = sim.Capture(ctx, txn.ID)
+	assert.ErrorIs(t, err, ErrInvalidState)
+
+	_, err = sim.Refund(ctx, txn.ID, 600000)
+	assert.Error(t, err, "refund above the charged amount")
+
+	txn, err = sim.Refund(ctx, txn.ID, 500000)
+	require.NoError(t, err)
+	assert.Equal(t, StatusRefunded, txn.Status)
+
+	txn, err = sim.Status(ctx, txn.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusRefunded, txn.Status)
+
+	_, err = sim.Void(ctx, txn.ID)
+	assert.ErrorIs(t, err, ErrInvalidState, "captured charges are refunded, not voided")
+
+	authorized, err := sim.Charge(ctx, ChargeRequest{Reference: "order-002", Amount: 250000, Currency: "IDR"})
+	require.NoError(t, err)
+	voided, err := sim.Void(ctx, authorized.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusVoided, voided.Status)
+	_, err = sim.Capture(ctx, authorized.ID)
+	assert.ErrorIs(t, err, ErrInvalidState, "a voided authorization cannot be captured")
+
+	_, err = sim.Status(ctx, "sim_unknown")
+	assert.ErrorIs(t, err, ErrTransactionNotFound)
+}
+
+// TestSimulator_Modes
+// Summary: Decline, timeout and late responses
+// Purpose: Verify each mode returns the error the purchase flow relies on, and late answers are still applied
+func TestSimulator_Modes(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        SimulatorMode
+		delay       time.Duration
+		timeout     time.Duration
+		expectedErr error
+		recorded    Status
+	}{
+		{name: "succeed", mode: ModeSucceed, timeout: time.Second, recorded: StatusAuthorized},
+		{name: "decline", mode: ModeDecline, timeout: time.Second, expectedErr: ErrDeclined, recorded: StatusDeclined},
+		{name: "timeout", mode: ModeTimeout, timeout: 20 * time.Millisecond, expectedErr: ErrTimeout},
+		{name: "late but within deadline", mode: ModeDelay, delay: 10 * time.Millisecond, timeout: time.Second, recorded: StatusAuthorized},
+		{name: "late past deadline", mode: ModeDelay, delay: time.Second, timeout: 20 * time.Millisecond, expectedErr: ErrTimeout, recorded: StatusAuthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := NewSimulator(tt.mode, tt.delay)
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			_, err := sim.Charge(ctx, ChargeRequest{Reference: "order-001", Amount: 250000, Currency: "IDR"})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// What the provider ended up recording, regardless of what the caller saw
+			sim.SetMode(ModeSucceed)
+			var recorded []Status
+			for _, txn := range sim.transactions {
+				recorded = append(recorded, txn.Status)
+			}
+			if tt.recorded == "" {
+				assert.Empty(t, recorded)
+				return
+			}
+			assert.Equal(t, []Status{tt.recorded}, recorded)
+		})
+	}
+}
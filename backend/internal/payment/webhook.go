@@ -0,0 +1,90 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256>". The
+// MAC covers "<t>.<raw body>", so the timestamp cannot be swapped.
+const WebhookSignatureHeader = "X-Payment-Signature"
+
+// WebhookTolerance is how old a signed webhook may be. Older deliveries are
+// rejected even with a valid MAC, which bounds replays of captured requests.
+const WebhookTolerance = 5 * time.Minute
+
+// ErrInvalidSignature is returned for a missing, malformed, stale or wrong signature
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// EventType is the kind of payment update a webhook reports
+type EventType string
+
+const (
+	EventAuthorized EventType = "payment.authorized"
+	EventCaptured   EventType = "payment.captured"
+	EventDeclined   EventType = "payment.declined"
+	EventRefunded   EventType = "payment.refunded"
+)
+
+// WebhookEvent is the body of a payment webhook. Reference is the order ID
+// passed in ChargeRequest.
+type WebhookEvent struct {
+	ID            string    `json:"id"`
+	Type          EventType `json:"type"`
+	TransactionID string    `json:"transaction_id"`
+	Reference     string    `json:"reference"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SignWebhook builds the signature header value for payload at time ts
+func SignWebhook(secret string, payload []byte, ts time.Time) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", unix, webhookMAC(secret, unix, payload))
+}
+
+// VerifyWebhookSignature checks header against payload and rejects
+// signatures older (or newer) than WebhookTolerance
+func VerifyWebhookSignature(secret, header string, payload []byte, now time.Time) error {
+	var unix, mac string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			mac = value
+		}
+	}
+	if unix == "" || mac == "" {
+		return ErrInvalidSignature
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > WebhookTolerance || age < -WebhookTolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+
+	expected := webhookMAC(secret, unix, payload)
+	if !hmac.Equal([]byte(mac), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func webhookMAC(secret, unix string, payload []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(unix))
+	h.Write([]byte("."))
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,43 @@
+package payment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVerifyWebhookSignature
+// Summary: HMAC webhook signature checks
+// Purpose: Verify only an untampered body signed with our secret within the tolerance window is accepted
+func TestVerifyWebhookSignature(t *testing.T) {
+	secret := "whsec_test"
+	payload := []byte(`{"id":"evt_001","type":"payment.captured"}`)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		header  string
+		payload []byte
+		valid   bool
+	}{
+		{name: "valid", header: SignWebhook(secret, payload, now), payload: payload, valid: true},
+		{name: "slightly in the future", header: SignWebhook(secret, payload, now.Add(time.Minute)), payload: payload, valid: true},
+		{name: "tampered body", header: SignWebhook(secret, payload, now), payload: []byte(`{"id":"evt_001","type":"payment.refunded"}`)},
+		{name: "wrong secret", header: SignWebhook("other", payload, now), payload: payload},
+		{name: "stale timestamp", header: SignWebhook(secret, payload, now.Add(-WebhookTolerance-time.Second)), payload: payload},
+		{name: "missing header", header: "", payload: payload},
+		{name: "malformed header", header: "sha256=abc", payload: payload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(secret, tt.header, tt.payload, now)
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidSignature)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// CheckinScanRepository stores gate scans
+type CheckinScanRepository interface {
+	// Create records a scan. A scan with an ID keeps it, and ErrDuplicate is
+	// returned if that ID was already recorded; without one an ID is assigned.
+	// ErrDuplicate is also returned for a second admitted scan of a ticket.
+	Create(ctx context.Context, scan *models.CheckinScan) error
+	FindByID(ctx context.Context, id string) (*models.CheckinScan, error)
+	// FindAdmission returns the scan that admitted the ticket
+	FindAdmission(ctx context.Context, ticketID string) (*models.CheckinScan, error)
+	// UpdateResult changes what was decided for a scan
+	UpdateResult(ctx context.Context, id string, result models.ScanResult, reason string) error
+}
+
+type checkinScanRepository struct {
+	db dbtx
+}
+
+// NewCheckinScanRepository creates a new check-in scan repository instance
+func NewCheckinScanRepository(db *sqlx.DB) CheckinScanRepository {
+	return &checkinScanRepository{db: db}
+}
+
+const checkinScanColumns = `id, ticket_id, event_id, validator_id, device_id, gate, source, result, reason, scanned_at, received_at`
+
+func (r *checkinScanRepository) Create(ctx context.Context, scan *models.CheckinScan) error {
+	if scan.ID == "" {
+		scan.ID = uuid.New().String()
+	}
+	query := `
+		INSERT INTO checkin_scans (id, ticket_id, event_id, validator_id, device_id, gate, source, result, reason, scanned_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING received_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		scan.ID, scan.TicketID, scan.EventID, scan.ValidatorID, scan.DeviceID, scan.Gate,
+		scan.Source, scan.Result, scan.Reason, scan.ScannedAt,
+	).Scan(&scan.ReceivedAt)
+	return mapError(err)
+}
+
+func (r *checkinScanRepository) FindByID(ctx context.Context, id string) (*models.CheckinScan, error) {
+	return r.findOne(ctx, `SELECT `+checkinScanColumns+` FROM checkin_scans WHERE id = $1`, id)
+}
+
+func (r *checkinScanRepository) FindAdmission(ctx context.Context, ticketID string) (*models.CheckinScan, error) {
+	return r.findOne(ctx, `SELECT `+checkinScanColumns+` FROM checkin_scans WHERE ticket_id = $1 AND result = $2`,
+		ticketID, string(models.ScanResultAdmitted))
+}
+
+func (r *checkinScanRepository) UpdateResult(ctx context.Context, id string, result models.ScanResult, reason string) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE checkin_scans SET result = $2, reason = NULLIF($3, '') WHERE id = $1`,
+		id, string(result), reason)
+	if err != nil {
+		return mapError(err)
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *checkinScanRepository) findOne(ctx context.Context, query string, args ...interface{}) (*models.CheckinScan, error) {
+	var scan models.CheckinScan
+	if err := r.db.GetContext(ctx, &scan, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &scan, nil
+}
@@ -0,0 +1,78 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckinScanRepository_Integration
+// Summary: Gate scans and offline check-in against Postgres
+// Purpose: Verify scans keep device IDs, a ticket has only one admitted scan, an admission can be demoted,
+// and the snapshot query and used_at backdating only touch admissible tickets
+func TestCheckinScanRepository_Integration(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	tickets := NewTicketRepository(testDB)
+	scans := NewCheckinScanRepository(testDB)
+
+	user := createTestUser(t, "fajar@example.com")
+	event := createTestEvent(t, 100, nil)
+
+	order := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 2, TotalPrice: 500000}
+	require.NoError(t, tickets.CreateOrder(ctx, order))
+	require.NoError(t, tickets.UpdateOrderStatus(ctx, models.OrderStatusChange{OrderID: order.ID, From: models.OrderStatusPending, To: models.OrderStatusConfirmed}))
+	ticket := &models.Ticket{OrderID: order.ID, TicketCode: "TKT-SCAN-1"}
+	cancelled := &models.Ticket{OrderID: order.ID, TicketCode: "TKT-SCAN-2", Status: string(models.TicketStatusCancelled)}
+	require.NoError(t, tickets.CreateTickets(ctx, []*models.Ticket{ticket, cancelled}))
+
+	pending := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 1, TotalPrice: 250000}
+	require.NoError(t, tickets.CreateOrder(ctx, pending))
+	require.NoError(t, tickets.CreateTickets(ctx, []*models.Ticket{{OrderID: pending.ID, TicketCode: "TKT-SCAN-3"}}))
+
+	listed, err := tickets.ListCheckinTickets(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1, "cancelled tickets and unconfirmed orders are left out")
+	assert.Equal(t, ticket.ID, listed[0].ID)
+
+	scannedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, tickets.MarkTicketUsed(ctx, ticket.ID, scannedAt))
+	require.NoError(t, tickets.MarkTicketUsed(ctx, ticket.ID, scannedAt.Add(-time.Minute)), "used_at can move back")
+	assert.ErrorIs(t, tickets.MarkTicketUsed(ctx, cancelled.ID, scannedAt), ErrConflict)
+
+	deviceID := "gate-a-01"
+	first := &models.CheckinScan{
+		ID: "0b1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d", TicketID: &ticket.ID, EventID: event.ID, ValidatorID: &user.ID,
+		DeviceID: &deviceID, Source: string(models.ScanSourceOffline), Result: string(models.ScanResultAdmitted), ScannedAt: scannedAt,
+	}
+	require.NoError(t, scans.Create(ctx, first))
+	assert.ErrorIs(t, scans.Create(ctx, first), ErrDuplicate, "same scan ID uploaded again")
+
+	second := &models.CheckinScan{
+		TicketID: &ticket.ID, EventID: event.ID, Source: string(models.ScanSourceOnline),
+		Result: string(models.ScanResultAdmitted), ScannedAt: scannedAt.Add(time.Minute),
+	}
+	assert.ErrorIs(t, scans.Create(ctx, second), ErrDuplicate, "a ticket has one admitted scan")
+
+	admission, err := scans.FindAdmission(ctx, ticket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, admission.ID)
+	assert.Equal(t, deviceID, *admission.DeviceID)
+	assert.True(t, admission.ScannedAt.Equal(scannedAt))
+
+	require.NoError(t, scans.UpdateResult(ctx, first.ID, models.ScanResultDuplicate, "already_used"))
+	require.NoError(t, scans.Create(ctx, second))
+	_, err = scans.FindByID(ctx, second.ID)
+	require.NoError(t, err)
+
+	demoted, err := scans.FindByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.ScanResultDuplicate), demoted.Result)
+	assert.ErrorIs(t, scans.UpdateResult(ctx, "00000000-0000-0000-0000-000000000000", models.ScanResultDuplicate, ""), ErrNotFound)
+}
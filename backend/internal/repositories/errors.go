@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrNotFound is returned when a lookup matches no rows
+	ErrNotFound = errors.New("record not found")
+
+	// ErrConflict is returned when a conditional update finds the row already changed
+	ErrConflict = errors.New("record was modified concurrently")
+
+	// ErrDuplicate is returned when a write violates a unique constraint
+	ErrDuplicate = errors.New("record already exists")
+
+	// ErrCheckViolation is returned when a write violates a CHECK constraint
+	// (e.g. available_tickets >= 0)
+	ErrCheckViolation = errors.New("record violates a check constraint")
+
+	// ErrForeignKeyViolation is returned when a write or delete breaks a
+	// foreign key (e.g. deleting an event that orders still reference)
+	ErrForeignKeyViolation = errors.New("record violates a foreign key")
+
+	// ErrInsufficientTickets is returned when an event has fewer tickets left than requested
+	ErrInsufficientTickets = errors.New("not enough tickets available")
+)
+
+// Postgres error codes (https://www.postgresql.org/docs/current/errcodes-appendix.html)
+const (
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
+	pgForeignKeyViolation = "23503"
+)
+
+// mapError translates Postgres constraint errors into the typed errors above.
+// The constraint name is kept in the message; errors.Is still matches.
+func mapError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	switch pqErr.Code {
+	case pgUniqueViolation:
+		return fmt.Errorf("%w: %s", ErrDuplicate, pqErr.Constraint)
+	case pgCheckViolation:
+		return fmt.Errorf("%w: %s", ErrCheckViolation, pqErr.Constraint)
+	case pgForeignKeyViolation:
+		return fmt.Errorf("%w: %s", ErrForeignKeyViolation, pqErr.Constraint)
+	default:
+		return err
+	}
+}
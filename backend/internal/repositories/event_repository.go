@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"time"
+	"strings"
 
 	"github.com/baramulti/ticketing-system/backend/internal/models"
 	"github.com/jmoiron/sqlx"
@@ -12,15 +14,28 @@ import (
 // EventRepository defines data access methods for events
 type EventRepository interface {
 	FindByID(ctx context.Context, id string) (*models.Event, error)
-	List(ctx context.Context, limit, offset int) ([]*models.Event, error)
+	// FindByIDForUpdate locks the event row until the transaction ends. Only
+	// meaningful on a repository obtained from a UnitOfWork.
+	FindByIDForUpdate(ctx context.Context, id string) (*models.Event, error)
+	// List returns one page of the events matching q.Filter, in q's order
+	List(ctx context.Context, q models.EventQuery) ([]*models.Event, error)
+	// Count returns how many events match the filter
+	Count(ctx context.Context, filter models.EventFilter) (int, error)
+	// MaxTotalTicketsByVenue returns the largest total_tickets of the
+	// venue's events, or 0 if it has none
+	MaxTotalTicketsByVenue(ctx context.Context, venueID string) (int, error)
 	Create(ctx context.Context, event *models.Event) error
 	Update(ctx context.Context, event *models.Event) error
+	// Delete removes the event. It returns ErrForeignKeyViolation while orders
+	// still reference it.
 	Delete(ctx context.Context, id string) error
 	DecrementAvailableTickets(ctx context.Context, eventID string, quantity int) error
+	// IncrementAvailableTickets returns released tickets to the inventory
+	IncrementAvailableTickets(ctx context.Context, eventID string, quantity int) error
 }
 
 type eventRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 // NewEventRepository creates a new event repository instance
@@ -28,80 +43,232 @@ func NewEventRepository(db *sqlx.DB) EventRepository {
 	return &eventRepository{db: db}
 }
 
+const eventColumns = `id, title, COALESCE(description, '') AS description, event_date, venue_id,
+	ticket_price, total_tickets, available_tickets, organizer_id, transfers_enabled, seat_map_id, created_at, updated_at`
+
 func (r *eventRepository) FindByID(ctx context.Context, id string) (*models.Event, error) {
-	// TODO: implement actual database query
-	// Example: SELECT * FROM events WHERE id = $1
-	return nil, fmt.Errorf("not implemented")
-}
-
-func (r *eventRepository) List(ctx context.Context, limit, offset int) ([]*models.Event, error) {
-	// TODO: implement event listing with pagination
-	// Example: SELECT * FROM events ORDER BY event_date ASC LIMIT $1 OFFSET $2
-
-	// mocking data from database
-	var MockEvents = []*models.Event{
-		{
-			ID:               "evt-001",
-			Title:            "Jakarta Tech Conference 2025",
-			Description:      "A gathering of software engineers, architects, and tech leaders in Indonesia.",
-			EventDate:        time.Date(2025, 3, 14, 9, 0, 0, 0, time.Local),
-			Venue:            "Jakarta Convention Center",
-			TicketPrice:      250000,
-			TotalTickets:     500,
-			AvailableTickets: 320,
-			CreatedAt:        time.Now(),
-			UpdatedAt:        time.Now(),
-		},
-		{
-			ID:               "evt-002",
-			Title:            "Indie Music Festival",
-			Description:      "A festival featuring top indie bands from across Southeast Asia.",
-			EventDate:        time.Date(2025, 5, 21, 18, 30, 0, 0, time.Local),
-			Venue:            "Lapangan D Senayan",
-			TicketPrice:      150000,
-			TotalTickets:     1000,
-			AvailableTickets: 750,
-			CreatedAt:        time.Now(),
-			UpdatedAt:        time.Now(),
-		},
-		{
-			ID:               "evt-003",
-			Title:            "Startup Pitch Day",
-			Description:      "Pitch event for early-stage startups to meet investors and mentors.",
-			EventDate:        time.Date(2025, 7, 10, 10, 0, 0, 0, time.Local),
-			Venue:            "GoWork - Kemang",
-			TicketPrice:      100000,
-			TotalTickets:     200,
-			AvailableTickets: 120,
-			CreatedAt:        time.Now(),
-			UpdatedAt:        time.Now(),
-		},
-	}
-
-	return MockEvents, nil
+	return r.findByID(ctx, `SELECT `+eventColumns+` FROM events WHERE id = $1`, id)
+}
+
+func (r *eventRepository) FindByIDForUpdate(ctx context.Context, id string) (*models.Event, error) {
+	return r.findByID(ctx, `SELECT `+eventColumns+` FROM events WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *eventRepository) findByID(ctx context.Context, query, id string) (*models.Event, error) {
+	var event models.Event
+	if err := r.db.GetContext(ctx, &event, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// List pages by offset, or by keyset when q.After is set: the sort column
+// and id are compared as a row, so pages stay stable while events are added.
+func (r *eventRepository) List(ctx context.Context, q models.EventQuery) ([]*models.Event, error) {
+	column, direction, after := "event_date", "ASC", ">"
+	if q.SortBy == models.EventSortPrice {
+		column = "ticket_price"
+	}
+	if q.SortDesc {
+		direction, after = "DESC", "<"
+	}
+
+	where := newEventWhere(q.Filter)
+	offset := q.Offset
+	if q.After != nil {
+		var value interface{} = q.After.EventDate
+		if column == "ticket_price" {
+			value = q.After.TicketPrice
+		}
+		where.add("("+column+", id) "+after+" ($%d, $%d)", value, q.After.ID)
+		offset = 0
+	}
+
+	args := append(where.args, q.Limit, offset)
+	query := fmt.Sprintf(`SELECT %s FROM events%s ORDER BY %s %s, id %s LIMIT $%d OFFSET $%d`,
+		eventColumns, where.clause(), column, direction, direction, len(args)-1, len(args))
+
+	events := []*models.Event{}
+	if err := r.db.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *eventRepository) Count(ctx context.Context, filter models.EventFilter) (int, error) {
+	where := newEventWhere(filter)
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM events`+where.clause(), where.args...); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// eventWhere collects the conditions of an event listing with their
+// positional arguments
+type eventWhere struct {
+	conditions []string
+	args       []interface{}
+}
+
+func newEventWhere(f models.EventFilter) *eventWhere {
+	w := &eventWhere{}
+	if f.From != nil {
+		w.add("event_date >= $%d", *f.From)
+	}
+	if f.To != nil {
+		w.add("event_date <= $%d", *f.To)
+	}
+	if f.VenueID != "" {
+		w.add("venue_id = $%d", f.VenueID)
+	}
+	if f.MinPrice != nil {
+		w.add("ticket_price >= $%d", *f.MinPrice)
+	}
+	if f.MaxPrice != nil {
+		w.add("ticket_price <= $%d", *f.MaxPrice)
+	}
+	if f.Available != nil {
+		if *f.Available {
+			w.add("available_tickets > 0")
+		} else {
+			w.add("available_tickets = 0")
+		}
+	}
+	return w
+}
+
+// add appends a condition whose %d verbs are filled with the positions of args
+func (w *eventWhere) add(condition string, args ...interface{}) {
+	positions := make([]interface{}, len(args))
+	for i, arg := range args {
+		w.args = append(w.args, arg)
+		positions[i] = len(w.args)
+	}
+	w.conditions = append(w.conditions, fmt.Sprintf(condition, positions...))
+}
+
+func (w *eventWhere) clause() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+func (r *eventRepository) MaxTotalTicketsByVenue(ctx context.Context, venueID string) (int, error) {
+	var total int
+	query := `SELECT COALESCE(MAX(total_tickets), 0) FROM events WHERE venue_id = $1`
+	if err := r.db.GetContext(ctx, &total, query, venueID); err != nil {
+		return 0, err
+	}
+	return total, nil
 }
 
 func (r *eventRepository) Create(ctx context.Context, event *models.Event) error {
-	// TODO: implement event creation
-	// Example: INSERT INTO events (id, title, description, event_date, ...) VALUES (...)
-	return fmt.Errorf("not implemented")
+	query := `
+		INSERT INTO events (title, description, event_date, venue_id, ticket_price,
+			total_tickets, available_tickets, organizer_id, transfers_enabled)
+		VALUES (:title, :description, :event_date, :venue_id, :ticket_price,
+			:total_tickets, :available_tickets, :organizer_id, :transfers_enabled)
+		RETURNING id, created_at, updated_at`
+	rows, err := sqlx.NamedQueryContext(ctx, r.db, query, event)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&event.ID, &event.CreatedAt, &event.UpdatedAt)
+	}
+	return rows.Err()
 }
 
+// Update overwrites the editable fields. Ownership (organizer_id) is never
+// changed here.
 func (r *eventRepository) Update(ctx context.Context, event *models.Event) error {
-	// TODO: implement event update
-	// Example: UPDATE events SET title = $1, description = $2, ... WHERE id = $n
-	return fmt.Errorf("not implemented")
+	query := `
+		UPDATE events
+		SET title = $1, description = $2, event_date = $3, venue_id = $4, ticket_price = $5,
+			total_tickets = $6, available_tickets = $7, transfers_enabled = $8, seat_map_id = $9, updated_at = NOW()
+		WHERE id = $10
+		RETURNING updated_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		event.Title, event.Description, event.EventDate, event.VenueID, event.TicketPrice,
+		event.TotalTickets, event.AvailableTickets, event.TransfersEnabled, event.SeatMapID, event.ID,
+	).Scan(&event.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return mapError(err)
 }
 
 func (r *eventRepository) Delete(ctx context.Context, id string) error {
-	// TODO: implement event deletion
-	// Example: DELETE FROM events WHERE id = $1
-	return fmt.Errorf("not implemented")
+	result, err := r.db.ExecContext(ctx, `DELETE FROM events WHERE id = $1`, id)
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
+// DecrementAvailableTickets takes quantity tickets from the event's inventory.
+// The guarded UPDATE never lets available_tickets go negative; callers that
+// need read-then-write consistency should lock the row first.
 func (r *eventRepository) DecrementAvailableTickets(ctx context.Context, eventID string, quantity int) error {
-	// TODO: implement ticket inventory decrement with row-level locking
-	// Example: UPDATE events SET available_tickets = available_tickets - $1 WHERE id = $2 AND available_tickets >= $1
-	// Important: Use FOR UPDATE lock in transaction to prevent double-booking
-	return fmt.Errorf("not implemented")
+	query := `
+		UPDATE events
+		SET available_tickets = available_tickets - $1, updated_at = NOW()
+		WHERE id = $2 AND available_tickets >= $1`
+	result, err := r.db.ExecContext(ctx, query, quantity, eventID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	// Nothing updated: either the event is gone or it is sold out
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)`, eventID); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrInsufficientTickets
+}
+
+func (r *eventRepository) IncrementAvailableTickets(ctx context.Context, eventID string, quantity int) error {
+	query := `
+		UPDATE events
+		SET available_tickets = LEAST(available_tickets + $1, total_tickets), updated_at = NOW()
+		WHERE id = $2`
+	result, err := r.db.ExecContext(ctx, query, quantity, eventID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
@@ -0,0 +1,233 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEventRepository_Integration_CRUD
+// Summary: Event create/find/update/list/delete against Postgres
+// Purpose: Verify round-tripped fields, list ordering and ErrNotFound/ErrCheckViolation mapping
+func TestEventRepository_Integration_CRUD(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewEventRepository(testDB)
+
+	organizer := createTestUser(t, "organizer@example.com")
+	event := createTestEvent(t, 100, &organizer.ID)
+
+	found, err := repo.FindByID(ctx, event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, event.Title, found.Title)
+	assert.Equal(t, organizer.ID, *found.OrganizerID)
+	assert.True(t, event.EventDate.Equal(found.EventDate))
+
+	found.AvailableTickets = -1
+	assert.ErrorIs(t, repo.Update(ctx, found), ErrCheckViolation)
+	found.AvailableTickets = found.TotalTickets + 1
+	assert.ErrorIs(t, repo.Update(ctx, found), ErrCheckViolation)
+
+	found.AvailableTickets = 80
+	istora := createTestVenue(t, "Istora Senayan", 7000)
+	found.VenueID = istora.ID
+	require.NoError(t, repo.Update(ctx, found))
+
+	later := createTestEvent(t, 10, nil)
+	later.EventDate = later.EventDate.AddDate(0, 1, 0)
+	require.NoError(t, repo.Update(ctx, later))
+
+	events, err := repo.List(ctx, models.EventQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, event.ID, events[0].ID, "events are listed by date")
+	assert.Equal(t, istora.ID, events[0].VenueID)
+
+	events, err = repo.List(ctx, models.EventQuery{Limit: 10, Offset: 1})
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	require.NoError(t, repo.Delete(ctx, event.ID))
+	_, err = repo.FindByID(ctx, event.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, event.ID), ErrNotFound)
+}
+
+// TestEventRepository_Integration_DeleteWithOrders
+// Summary: Deleting an event that has orders against Postgres
+// Purpose: Verify orders keep their event (ErrForeignKeyViolation), only cancelled orders and pending ones
+// whose hold expired are cleared, live holds are reported and kept, refunded orders are kept like sold
+// ones, and the event can go once only unpaid orders are left
+func TestEventRepository_Integration_DeleteWithOrders(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewEventRepository(testDB)
+	tickets := NewTicketRepository(testDB)
+
+	user := createTestUser(t, "indah@example.com")
+	event := createTestEvent(t, 100, nil)
+
+	expiredAt := time.Now().Add(-time.Minute)
+	pending := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 1, TotalPrice: 250000, HoldExpiresAt: &expiredAt}
+	require.NoError(t, tickets.CreateOrder(ctx, pending))
+	heldUntil := time.Now().Add(15 * time.Minute)
+	held := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 1, TotalPrice: 250000, HoldExpiresAt: &heldUntil}
+	require.NoError(t, tickets.CreateOrder(ctx, held))
+	confirmed := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 2, TotalPrice: 500000, Status: string(models.OrderStatusConfirmed)}
+	require.NoError(t, tickets.CreateOrder(ctx, confirmed))
+
+	sold, err := tickets.HasSoldOrders(ctx, event.ID)
+	require.NoError(t, err)
+	assert.True(t, sold)
+	assert.ErrorIs(t, repo.Delete(ctx, event.ID), ErrForeignKeyViolation)
+	active, err := tickets.HasActiveHolds(ctx, event.ID)
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	require.NoError(t, tickets.DeleteUnsoldOrdersByEvent(ctx, event.ID))
+	_, err = tickets.FindOrderByID(ctx, pending.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = tickets.FindOrderByID(ctx, held.ID)
+	require.NoError(t, err, "a live hold may still be charged")
+	_, err = tickets.FindOrderByID(ctx, confirmed.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, tickets.UpdateOrderStatus(ctx, models.OrderStatusChange{
+		OrderID: confirmed.ID, From: models.OrderStatusConfirmed, To: models.OrderStatusRefunded,
+	}))
+	sold, err = tickets.HasSoldOrders(ctx, event.ID)
+	require.NoError(t, err)
+	assert.True(t, sold, "refunded orders keep their history")
+	require.NoError(t, tickets.DeleteUnsoldOrdersByEvent(ctx, event.ID))
+	_, err = tickets.FindOrderByID(ctx, confirmed.ID)
+	require.NoError(t, err)
+
+	unsold := createTestEvent(t, 100, nil)
+	cancelled := &models.TicketOrder{EventID: unsold.ID, UserID: user.ID, Quantity: 1, TotalPrice: 250000}
+	require.NoError(t, tickets.CreateOrder(ctx, cancelled))
+	require.NoError(t, tickets.UpdateOrderStatus(ctx, models.OrderStatusChange{
+		OrderID: cancelled.ID, From: models.OrderStatusPending, To: models.OrderStatusCancelled,
+	}))
+	sold, err = tickets.HasSoldOrders(ctx, unsold.ID)
+	require.NoError(t, err)
+	assert.False(t, sold)
+	active, err = tickets.HasActiveHolds(ctx, unsold.ID)
+	require.NoError(t, err)
+	assert.False(t, active)
+	require.NoError(t, tickets.DeleteUnsoldOrdersByEvent(ctx, unsold.ID))
+	require.NoError(t, repo.Delete(ctx, unsold.ID))
+}
+
+// TestEventRepository_Integration_DecrementAvailableTickets
+// Summary: Guarded inventory decrement against Postgres
+// Purpose: Verify the decrement never goes negative and reports sold-out vs missing events
+func TestEventRepository_Integration_DecrementAvailableTickets(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewEventRepository(testDB)
+	event := createTestEvent(t, 3, nil)
+
+	require.NoError(t, repo.DecrementAvailableTickets(ctx, event.ID, 2))
+	assert.ErrorIs(t, repo.DecrementAvailableTickets(ctx, event.ID, 2), ErrInsufficientTickets)
+	require.NoError(t, repo.DecrementAvailableTickets(ctx, event.ID, 1))
+	assert.ErrorIs(t, repo.DecrementAvailableTickets(ctx, "00000000-0000-0000-0000-000000000000", 1), ErrNotFound)
+
+	found, err := repo.FindByID(ctx, event.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, found.AvailableTickets)
+}
+
+// TestEventRepository_Integration_Listing
+// Summary: Filtering, sorting, counting and keyset paging events against Postgres
+// Purpose: Verify each filter narrows both the page and the count, price sorts break ties by id,
+// and a cursor continues right after its event
+func TestEventRepository_Integration_Listing(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewEventRepository(testDB)
+
+	venue := createTestVenue(t, "Istora Senayan", 7000)
+	cheap := createTestEvent(t, 300, nil)
+	soldOut := createTestEvent(t, 100, nil)
+	pricey := createTestEvent(t, 1200, nil)
+	cheap.VenueID, cheap.TicketPrice = venue.ID, 100000
+	soldOut.TicketPrice, soldOut.AvailableTickets = 100000, 0
+	soldOut.EventDate = soldOut.EventDate.AddDate(0, 0, 1)
+	pricey.VenueID, pricey.TicketPrice = venue.ID, 750000
+	pricey.EventDate = pricey.EventDate.AddDate(0, 0, 2)
+	for _, event := range []*models.Event{cheap, soldOut, pricey} {
+		require.NoError(t, repo.Update(ctx, event))
+	}
+
+	available, to, maxPrice := true, soldOut.EventDate, 500000.0
+	filters := []struct {
+		name     string
+		filter   models.EventFilter
+		expected []string
+	}{
+		{name: "none", expected: []string{cheap.ID, soldOut.ID, pricey.ID}},
+		{name: "venue", filter: models.EventFilter{VenueID: venue.ID}, expected: []string{cheap.ID, pricey.ID}},
+		{name: "date range", filter: models.EventFilter{From: &soldOut.EventDate, To: &to}, expected: []string{soldOut.ID}},
+		{name: "price range", filter: models.EventFilter{MaxPrice: &maxPrice}, expected: []string{cheap.ID, soldOut.ID}},
+		{name: "available", filter: models.EventFilter{Available: &available}, expected: []string{cheap.ID, pricey.ID}},
+	}
+	for _, f := range filters {
+		events, err := repo.List(ctx, models.EventQuery{Filter: f.filter, SortBy: models.EventSortDate, Limit: 10})
+		require.NoError(t, err, f.name)
+		ids := make([]string, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		assert.Equal(t, f.expected, ids, f.name)
+		total, err := repo.Count(ctx, f.filter)
+		require.NoError(t, err, f.name)
+		assert.Equal(t, len(f.expected), total, f.name)
+	}
+
+	byPrice := models.EventQuery{SortBy: models.EventSortPrice, SortDesc: true, Limit: 2}
+	page, err := repo.List(ctx, byPrice)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, pricey.ID, page[0].ID, "events are listed by price, highest first")
+
+	last := page[1]
+	byPrice.After = &models.EventCursor{EventDate: last.EventDate, TicketPrice: last.TicketPrice, ID: last.ID}
+	byPrice.Offset = 5
+	rest, err := repo.List(ctx, byPrice)
+	require.NoError(t, err)
+	require.Len(t, rest, 1, "the cursor ignores the offset")
+	assert.NotEqual(t, last.ID, rest[0].ID)
+	assert.Equal(t, 100000.0, rest[0].TicketPrice)
+}
+
+// TestEventRepository_Integration_ByVenue
+// Summary: Largest event total of a venue against Postgres
+// Purpose: Verify the largest total of the venue's events is reported and venues without events report 0
+func TestEventRepository_Integration_ByVenue(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewEventRepository(testDB)
+
+	venue := createTestVenue(t, "Istora Senayan", 7000)
+	empty := createTestVenue(t, "Tennis Indoor Senayan", 3000)
+	first := createTestEvent(t, 300, nil)
+	second := createTestEvent(t, 1200, nil)
+	createTestEvent(t, 5000, nil)
+	for _, event := range []*models.Event{first, second} {
+		event.VenueID = venue.ID
+		require.NoError(t, repo.Update(ctx, event))
+	}
+
+	largest, err := repo.MaxTotalTicketsByVenue(ctx, venue.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1200, largest)
+	largest, err = repo.MaxTotalTicketsByVenue(ctx, empty.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, largest)
+}
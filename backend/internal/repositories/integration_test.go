@@ -0,0 +1,57 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/testutil/pgtest"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+var testDB *sqlx.DB
+
+func TestMain(m *testing.M) {
+	pgtest.Run(m)
+}
+
+// resetDB clears the tables and (re)binds testDB for the test
+func resetDB(t *testing.T) {
+	t.Helper()
+	testDB = pgtest.DB(t)
+	pgtest.Reset(t)
+}
+
+func createTestUser(t *testing.T, email string) *models.User {
+	t.Helper()
+	user := &models.User{Email: email, PasswordHash: "hash", IsActive: true}
+	require.NoError(t, NewUserRepository(testDB).Create(context.Background(), user))
+	return user
+}
+
+func createTestVenue(t *testing.T, name string, capacity int) *models.Venue {
+	t.Helper()
+	venue := &models.Venue{Name: name, TimeZone: "Asia/Jakarta", Capacity: capacity}
+	require.NoError(t, NewVenueRepository(testDB).Create(context.Background(), venue))
+	return venue
+}
+
+func createTestEvent(t *testing.T, available int, organizerID *string) *models.Event {
+	t.Helper()
+	venue := createTestVenue(t, "Jakarta Convention Center", 10000)
+	event := &models.Event{
+		Title:            "Jakarta Tech Conference",
+		EventDate:        time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second),
+		VenueID:          venue.ID,
+		TicketPrice:      250000,
+		TotalTickets:     available,
+		AvailableTickets: available,
+		OrganizerID:      organizerID,
+	}
+	require.NoError(t, NewEventRepository(testDB).Create(context.Background(), event))
+	return event
+}
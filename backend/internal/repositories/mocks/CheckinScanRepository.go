@@ -0,0 +1,125 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/baramulti/ticketing-system/backend/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CheckinScanRepository is an autogenerated mock type for the CheckinScanRepository type
+type CheckinScanRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, scan
+func (_m *CheckinScanRepository) Create(ctx context.Context, scan *models.CheckinScan) error {
+	ret := _m.Called(ctx, scan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CheckinScan) error); ok {
+		r0 = rf(ctx, scan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAdmission provides a mock function with given fields: ctx, ticketID
+func (_m *CheckinScanRepository) FindAdmission(ctx context.Context, ticketID string) (*models.CheckinScan, error) {
+	ret := _m.Called(ctx, ticketID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAdmission")
+	}
+
+	var r0 *models.CheckinScan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CheckinScan, error)); ok {
+		return rf(ctx, ticketID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CheckinScan); ok {
+		r0 = rf(ctx, ticketID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CheckinScan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ticketID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *CheckinScanRepository) FindByID(ctx context.Context, id string) (*models.CheckinScan, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.CheckinScan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CheckinScan, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CheckinScan); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CheckinScan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateResult provides a mock function with given fields: ctx, id, result, reason
+func (_m *CheckinScanRepository) UpdateResult(ctx context.Context, id string, result models.ScanResult, reason string) error {
+	ret := _m.Called(ctx, id, result, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ScanResult, string) error); ok {
+		r0 = rf(ctx, id, result, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCheckinScanRepository creates a new instance of CheckinScanRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCheckinScanRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CheckinScanRepository {
+	mock := &CheckinScanRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
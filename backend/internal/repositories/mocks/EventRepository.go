@@ -14,6 +14,34 @@ type EventRepository struct {
 	mock.Mock
 }
 
+// Count provides a mock function with given fields: ctx, filter
+func (_m *EventRepository) Count(ctx context.Context, filter models.EventFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.EventFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.EventFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.EventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, event
 func (_m *EventRepository) Create(ctx context.Context, event *models.Event) error {
 	ret := _m.Called(ctx, event)
@@ -98,9 +126,57 @@ func (_m *EventRepository) FindByID(ctx context.Context, id string) (*models.Eve
 	return r0, r1
 }
 
-// List provides a mock function with given fields: ctx, limit, offset
-func (_m *EventRepository) List(ctx context.Context, limit int, offset int) ([]*models.Event, error) {
-	ret := _m.Called(ctx, limit, offset)
+// FindByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *EventRepository) FindByIDForUpdate(ctx context.Context, id string) (*models.Event, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIDForUpdate")
+	}
+
+	var r0 *models.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Event, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementAvailableTickets provides a mock function with given fields: ctx, eventID, quantity
+func (_m *EventRepository) IncrementAvailableTickets(ctx context.Context, eventID string, quantity int) error {
+	ret := _m.Called(ctx, eventID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementAvailableTickets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, eventID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx, q
+func (_m *EventRepository) List(ctx context.Context, q models.EventQuery) ([]*models.Event, error) {
+	ret := _m.Called(ctx, q)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -108,19 +184,47 @@ func (_m *EventRepository) List(ctx context.Context, limit int, offset int) ([]*
 
 	var r0 []*models.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*models.Event, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, models.EventQuery) ([]*models.Event, error)); ok {
+		return rf(ctx, q)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*models.Event); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, models.EventQuery) []*models.Event); ok {
+		r0 = rf(ctx, q)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, models.EventQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MaxTotalTicketsByVenue provides a mock function with given fields: ctx, venueID
+func (_m *EventRepository) MaxTotalTicketsByVenue(ctx context.Context, venueID string) (int, error) {
+	ret := _m.Called(ctx, venueID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaxTotalTicketsByVenue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, venueID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, venueID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, venueID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/baramulti/ticketing-system/backend/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentWebhookRepository is an autogenerated mock type for the PaymentWebhookRepository type
+type PaymentWebhookRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, event
+func (_m *PaymentWebhookRepository) Create(ctx context.Context, event *models.PaymentWebhookEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PaymentWebhookEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPaymentWebhookRepository creates a new instance of PaymentWebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentWebhookRepository {
+	mock := &PaymentWebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
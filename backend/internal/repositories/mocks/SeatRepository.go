@@ -0,0 +1,219 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/baramulti/ticketing-system/backend/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SeatRepository is an autogenerated mock type for the SeatRepository type
+type SeatRepository struct {
+	mock.Mock
+}
+
+// CreateSeatMap provides a mock function with given fields: ctx, seatMap
+func (_m *SeatRepository) CreateSeatMap(ctx context.Context, seatMap *models.SeatMap) error {
+	ret := _m.Called(ctx, seatMap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeatMap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SeatMap) error); ok {
+		r0 = rf(ctx, seatMap)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSeats provides a mock function with given fields: ctx, seats
+func (_m *SeatRepository) CreateSeats(ctx context.Context, seats []*models.Seat) error {
+	ret := _m.Called(ctx, seats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSeats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Seat) error); ok {
+		r0 = rf(ctx, seats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindSeatMapByID provides a mock function with given fields: ctx, id
+func (_m *SeatRepository) FindSeatMapByID(ctx context.Context, id string) (*models.SeatMap, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSeatMapByID")
+	}
+
+	var r0 *models.SeatMap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.SeatMap, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.SeatMap); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SeatMap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HoldSeats provides a mock function with given fields: ctx, eventID, orderID, seatIDs
+func (_m *SeatRepository) HoldSeats(ctx context.Context, eventID string, orderID string, seatIDs []string) error {
+	ret := _m.Called(ctx, eventID, orderID, seatIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HoldSeats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, eventID, orderID, seatIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListEventSeats provides a mock function with given fields: ctx, eventID
+func (_m *SeatRepository) ListEventSeats(ctx context.Context, eventID string) ([]*models.EventSeat, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEventSeats")
+	}
+
+	var r0 []*models.EventSeat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.EventSeat, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.EventSeat); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.EventSeat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSeats provides a mock function with given fields: ctx, seatMapID
+func (_m *SeatRepository) ListSeats(ctx context.Context, seatMapID string) ([]*models.Seat, error) {
+	ret := _m.Called(ctx, seatMapID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSeats")
+	}
+
+	var r0 []*models.Seat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Seat, error)); ok {
+		return rf(ctx, seatMapID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Seat); ok {
+		r0 = rf(ctx, seatMapID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Seat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, seatMapID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseSeatsByOrder provides a mock function with given fields: ctx, orderID
+func (_m *SeatRepository) ReleaseSeatsByOrder(ctx context.Context, orderID string) error {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseSeatsByOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceEventSeats provides a mock function with given fields: ctx, eventID, seatMapID
+func (_m *SeatRepository) ReplaceEventSeats(ctx context.Context, eventID string, seatMapID string) (int, error) {
+	ret := _m.Called(ctx, eventID, seatMapID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceEventSeats")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return rf(ctx, eventID, seatMapID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = rf(ctx, eventID, seatMapID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, eventID, seatMapID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSeatRepository creates a new instance of SeatRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSeatRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SeatRepository {
+	mock := &SeatRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
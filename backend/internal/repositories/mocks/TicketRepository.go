@@ -8,7 +8,7 @@ import (
 	models "github.com/baramulti/ticketing-system/backend/internal/models"
 	mock "github.com/stretchr/testify/mock"
 
-	sql "database/sql"
+	time "time"
 )
 
 // TicketRepository is an autogenerated mock type for the TicketRepository type
@@ -16,6 +16,36 @@ type TicketRepository struct {
 	mock.Mock
 }
 
+// CheckInTicket provides a mock function with given fields: ctx, ticketID, eventID
+func (_m *TicketRepository) CheckInTicket(ctx context.Context, ticketID string, eventID string) (*models.Ticket, error) {
+	ret := _m.Called(ctx, ticketID, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckInTicket")
+	}
+
+	var r0 *models.Ticket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Ticket, error)); ok {
+		return rf(ctx, ticketID, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Ticket); ok {
+		r0 = rf(ctx, ticketID, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Ticket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, ticketID, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateOrder provides a mock function with given fields: ctx, order
 func (_m *TicketRepository) CreateOrder(ctx context.Context, order *models.TicketOrder) error {
 	ret := _m.Called(ctx, order)
@@ -52,6 +82,72 @@ func (_m *TicketRepository) CreateTickets(ctx context.Context, tickets []*models
 	return r0
 }
 
+// CreateTransfer provides a mock function with given fields: ctx, transfer
+func (_m *TicketRepository) CreateTransfer(ctx context.Context, transfer *models.TicketTransfer) error {
+	ret := _m.Called(ctx, transfer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransfer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TicketTransfer) error); ok {
+		r0 = rf(ctx, transfer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUnsoldOrdersByEvent provides a mock function with given fields: ctx, eventID
+func (_m *TicketRepository) DeleteUnsoldOrdersByEvent(ctx context.Context, eventID string) error {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnsoldOrdersByEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindExpiredHoldsForUpdate provides a mock function with given fields: ctx, limit
+func (_m *TicketRepository) FindExpiredHoldsForUpdate(ctx context.Context, limit int) ([]*models.TicketOrder, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExpiredHoldsForUpdate")
+	}
+
+	var r0 []*models.TicketOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*models.TicketOrder, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.TicketOrder); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TicketOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindOrderByID provides a mock function with given fields: ctx, id
 func (_m *TicketRepository) FindOrderByID(ctx context.Context, id string) (*models.TicketOrder, error) {
 	ret := _m.Called(ctx, id)
@@ -82,6 +178,126 @@ func (_m *TicketRepository) FindOrderByID(ctx context.Context, id string) (*mode
 	return r0, r1
 }
 
+// FindOrderByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *TicketRepository) FindOrderByIDForUpdate(ctx context.Context, id string) (*models.TicketOrder, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrderByIDForUpdate")
+	}
+
+	var r0 *models.TicketOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TicketOrder, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TicketOrder); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TicketOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTicketByCode provides a mock function with given fields: ctx, code
+func (_m *TicketRepository) FindTicketByCode(ctx context.Context, code string) (*models.Ticket, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTicketByCode")
+	}
+
+	var r0 *models.Ticket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Ticket, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Ticket); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Ticket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTicketByID provides a mock function with given fields: ctx, id
+func (_m *TicketRepository) FindTicketByID(ctx context.Context, id string) (*models.Ticket, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTicketByID")
+	}
+
+	var r0 *models.Ticket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Ticket, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Ticket); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Ticket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTicketByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *TicketRepository) FindTicketByIDForUpdate(ctx context.Context, id string) (*models.Ticket, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTicketByIDForUpdate")
+	}
+
+	var r0 *models.Ticket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Ticket, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Ticket); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Ticket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindTicketsByOrderID provides a mock function with given fields: ctx, orderID
 func (_m *TicketRepository) FindTicketsByOrderID(ctx context.Context, orderID string) ([]*models.Ticket, error) {
 	ret := _m.Called(ctx, orderID)
@@ -112,6 +328,122 @@ func (_m *TicketRepository) FindTicketsByOrderID(ctx context.Context, orderID st
 	return r0, r1
 }
 
+// HasActiveHolds provides a mock function with given fields: ctx, eventID
+func (_m *TicketRepository) HasActiveHolds(ctx context.Context, eventID string) (bool, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasActiveHolds")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasSoldOrders provides a mock function with given fields: ctx, eventID
+func (_m *TicketRepository) HasSoldOrders(ctx context.Context, eventID string) (bool, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasSoldOrders")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCheckinTickets provides a mock function with given fields: ctx, eventID
+func (_m *TicketRepository) ListCheckinTickets(ctx context.Context, eventID string) ([]*models.Ticket, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCheckinTickets")
+	}
+
+	var r0 []*models.Ticket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Ticket, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Ticket); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Ticket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOrderHistory provides a mock function with given fields: ctx, orderID
+func (_m *TicketRepository) ListOrderHistory(ctx context.Context, orderID string) ([]*models.OrderStatusHistory, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrderHistory")
+	}
+
+	var r0 []*models.OrderStatusHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.OrderStatusHistory, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.OrderStatusHistory); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OrderStatusHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListOrdersByUserID provides a mock function with given fields: ctx, userID
 func (_m *TicketRepository) ListOrdersByUserID(ctx context.Context, userID string) ([]*models.TicketOrder, error) {
 	ret := _m.Called(ctx, userID)
@@ -142,17 +474,113 @@ func (_m *TicketRepository) ListOrdersByUserID(ctx context.Context, userID strin
 	return r0, r1
 }
 
-// UpdateOrderStatus provides a mock function with given fields: ctx, orderID, status
-func (_m *TicketRepository) UpdateOrderStatus(ctx context.Context, orderID string, status models.TicketOrderStatus) error {
-	ret := _m.Called(ctx, orderID, status)
+// ListTransferChain provides a mock function with given fields: ctx, ticketID
+func (_m *TicketRepository) ListTransferChain(ctx context.Context, ticketID string) ([]*models.TicketTransfer, error) {
+	ret := _m.Called(ctx, ticketID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransferChain")
+	}
+
+	var r0 []*models.TicketTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.TicketTransfer, error)); ok {
+		return rf(ctx, ticketID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.TicketTransfer); ok {
+		r0 = rf(ctx, ticketID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TicketTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ticketID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkTicketUsed provides a mock function with given fields: ctx, ticketID, usedAt
+func (_m *TicketRepository) MarkTicketUsed(ctx context.Context, ticketID string, usedAt time.Time) error {
+	ret := _m.Called(ctx, ticketID, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkTicketUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, ticketID, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SummarizeOrdersByEvent provides a mock function with given fields: ctx, eventID
+func (_m *TicketRepository) SummarizeOrdersByEvent(ctx context.Context, eventID string) ([]*models.OrderStatusSummary, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeOrdersByEvent")
+	}
+
+	var r0 []*models.OrderStatusSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.OrderStatusSummary, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.OrderStatusSummary); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OrderStatusSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrderPayment provides a mock function with given fields: ctx, change, paymentID
+func (_m *TicketRepository) UpdateOrderPayment(ctx context.Context, change models.OrderStatusChange, paymentID string) error {
+	ret := _m.Called(ctx, change, paymentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderStatusChange, string) error); ok {
+		r0 = rf(ctx, change, paymentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, change
+func (_m *TicketRepository) UpdateOrderStatus(ctx context.Context, change models.OrderStatusChange) error {
+	ret := _m.Called(ctx, change)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateOrderStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.TicketOrderStatus) error); ok {
-		r0 = rf(ctx, orderID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderStatusChange) error); ok {
+		r0 = rf(ctx, change)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -160,17 +588,17 @@ func (_m *TicketRepository) UpdateOrderStatus(ctx context.Context, orderID strin
 	return r0
 }
 
-// WithTransaction provides a mock function with given fields: ctx, fn
-func (_m *TicketRepository) WithTransaction(ctx context.Context, fn func(*sql.Tx) error) error {
-	ret := _m.Called(ctx, fn)
+// UpdateTicketStatus provides a mock function with given fields: ctx, ticketID, from, to
+func (_m *TicketRepository) UpdateTicketStatus(ctx context.Context, ticketID string, from models.TicketStatus, to models.TicketStatus) error {
+	ret := _m.Called(ctx, ticketID, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for WithTransaction")
+		panic("no return value specified for UpdateTicketStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, func(*sql.Tx) error) error); ok {
-		r0 = rf(ctx, fn)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TicketStatus, models.TicketStatus) error); ok {
+		r0 = rf(ctx, ticketID, from, to)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -178,6 +606,34 @@ func (_m *TicketRepository) WithTransaction(ctx context.Context, fn func(*sql.Tx
 	return r0
 }
 
+// UpdateTicketsStatusByOrderID provides a mock function with given fields: ctx, orderID, status
+func (_m *TicketRepository) UpdateTicketsStatusByOrderID(ctx context.Context, orderID string, status models.TicketStatus) (int, error) {
+	ret := _m.Called(ctx, orderID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTicketsStatusByOrderID")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TicketStatus) (int, error)); ok {
+		return rf(ctx, orderID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TicketStatus) int); ok {
+		r0 = rf(ctx, orderID, status)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.TicketStatus) error); ok {
+		r1 = rf(ctx, orderID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTicketRepository creates a new instance of TicketRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTicketRepository(t interface {
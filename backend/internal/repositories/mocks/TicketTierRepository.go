@@ -0,0 +1,209 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/baramulti/ticketing-system/backend/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TicketTierRepository is an autogenerated mock type for the TicketTierRepository type
+type TicketTierRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, tier
+func (_m *TicketTierRepository) Create(ctx context.Context, tier *models.TicketTier) error {
+	ret := _m.Called(ctx, tier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TicketTier) error); ok {
+		r0 = rf(ctx, tier)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DecrementAvailableTickets provides a mock function with given fields: ctx, tierID, quantity
+func (_m *TicketTierRepository) DecrementAvailableTickets(ctx context.Context, tierID string, quantity int) error {
+	ret := _m.Called(ctx, tierID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecrementAvailableTickets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, tierID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *TicketTierRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *TicketTierRepository) FindByID(ctx context.Context, id string) (*models.TicketTier, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *models.TicketTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.TicketTier, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TicketTier); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TicketTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementAvailableTickets provides a mock function with given fields: ctx, tierID, quantity
+func (_m *TicketTierRepository) IncrementAvailableTickets(ctx context.Context, tierID string, quantity int) error {
+	ret := _m.Called(ctx, tierID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementAvailableTickets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, tierID, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListByEventID provides a mock function with given fields: ctx, eventID
+func (_m *TicketTierRepository) ListByEventID(ctx context.Context, eventID string) ([]*models.TicketTier, error) {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByEventID")
+	}
+
+	var r0 []*models.TicketTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.TicketTier, error)); ok {
+		return rf(ctx, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.TicketTier); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TicketTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByEventIDs provides a mock function with given fields: ctx, eventIDs
+func (_m *TicketTierRepository) ListByEventIDs(ctx context.Context, eventIDs []string) ([]*models.TicketTier, error) {
+	ret := _m.Called(ctx, eventIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByEventIDs")
+	}
+
+	var r0 []*models.TicketTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*models.TicketTier, error)); ok {
+		return rf(ctx, eventIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*models.TicketTier); ok {
+		r0 = rf(ctx, eventIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TicketTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, eventIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, tier
+func (_m *TicketTierRepository) Update(ctx context.Context, tier *models.TicketTier) error {
+	ret := _m.Called(ctx, tier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TicketTier) error); ok {
+		r0 = rf(ctx, tier)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTicketTierRepository creates a new instance of TicketTierRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTicketTierRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TicketTierRepository {
+	mock := &TicketTierRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
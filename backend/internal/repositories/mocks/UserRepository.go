@@ -14,6 +14,24 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// AssignRole provides a mock function with given fields: ctx, userID, roleName, assignedBy
+func (_m *UserRepository) AssignRole(ctx context.Context, userID string, roleName string, assignedBy *string) error {
+	ret := _m.Called(ctx, userID, roleName, assignedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *string) error); ok {
+		r0 = rf(ctx, userID, roleName, assignedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, user
 func (_m *UserRepository) Create(ctx context.Context, user *models.User) error {
 	ret := _m.Called(ctx, user)
@@ -110,6 +128,66 @@ func (_m *UserRepository) FindByID(ctx context.Context, id string) (*models.User
 	return r0, r1
 }
 
+// FindPermissionsByUserID provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) FindPermissionsByUserID(ctx context.Context, userID string) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPermissionsByUserID")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRolesByUserID provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) FindRolesByUserID(ctx context.Context, userID string) ([]*models.Role, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRolesByUserID")
+	}
+
+	var r0 []*models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.Role, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.Role); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, limit, offset
 func (_m *UserRepository) List(ctx context.Context, limit int, offset int) ([]*models.User, error) {
 	ret := _m.Called(ctx, limit, offset)
@@ -140,6 +218,24 @@ func (_m *UserRepository) List(ctx context.Context, limit int, offset int) ([]*m
 	return r0, r1
 }
 
+// RevokeRole provides a mock function with given fields: ctx, userID, roleName
+func (_m *UserRepository) RevokeRole(ctx context.Context, userID string, roleName string) error {
+	ret := _m.Called(ctx, userID, roleName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, roleName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, user
 func (_m *UserRepository) Update(ctx context.Context, user *models.User) error {
 	ret := _m.Called(ctx, user)
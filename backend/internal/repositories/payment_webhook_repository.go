@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// PaymentWebhookRepository stores processed payment webhook events
+type PaymentWebhookRepository interface {
+	// Create records an event. It returns ErrDuplicate if the event ID was
+	// already recorded.
+	Create(ctx context.Context, event *models.PaymentWebhookEvent) error
+}
+
+type paymentWebhookRepository struct {
+	db dbtx
+}
+
+// NewPaymentWebhookRepository creates a new payment webhook repository instance
+func NewPaymentWebhookRepository(db *sqlx.DB) PaymentWebhookRepository {
+	return &paymentWebhookRepository{db: db}
+}
+
+func (r *paymentWebhookRepository) Create(ctx context.Context, event *models.PaymentWebhookEvent) error {
+	query := `
+		INSERT INTO payment_webhook_events (id, event_type, transaction_id, reference, payload)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING received_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		event.ID, event.EventType, event.TransactionID, event.Reference, event.Payload,
+	).Scan(&event.ReceivedAt)
+	return mapError(err)
+}
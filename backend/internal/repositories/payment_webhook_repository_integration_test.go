@@ -0,0 +1,33 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaymentWebhookRepository_Integration_Create
+// Summary: Webhook event de-duplication against Postgres
+// Purpose: Verify a second delivery with the same gateway event ID maps to ErrDuplicate
+func TestPaymentWebhookRepository_Integration_Create(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewPaymentWebhookRepository(testDB)
+
+	event := &models.PaymentWebhookEvent{
+		ID:            "evt_001",
+		EventType:     "payment.captured",
+		TransactionID: "sim_txn_001",
+		Reference:     "6f1c2a9e-3d4b-4c5a-9e8f-7a6b5c4d3e2f",
+		Payload:       []byte(`{"id":"evt_001"}`),
+	}
+	require.NoError(t, repo.Create(ctx, event))
+	assert.False(t, event.ReceivedAt.IsZero())
+
+	assert.ErrorIs(t, repo.Create(ctx, event), ErrDuplicate)
+}
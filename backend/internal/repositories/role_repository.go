@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// RoleRepository defines data access methods for roles, permissions and the
+// role_permissions mapping. User role assignments live in UserRepository.
+type RoleRepository interface {
+	// List returns every role with its permissions loaded
+	List(ctx context.Context) ([]*models.Role, error)
+	FindByID(ctx context.Context, id string) (*models.Role, error)
+	Create(ctx context.Context, role *models.Role) error
+
+	ListPermissions(ctx context.Context) ([]*models.Permission, error)
+	CreatePermission(ctx context.Context, permission *models.Permission) error
+
+	// Role permissions (role_permissions)
+	AttachPermission(ctx context.Context, roleID, permissionName string) error
+	DetachPermission(ctx context.Context, roleID, permissionName string) error
+}
+
+type roleRepository struct {
+	db *sqlx.DB
+}
+
+// NewRoleRepository creates a new role repository instance
+func NewRoleRepository(db *sqlx.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+const roleColumns = `id, name, COALESCE(description, '') AS description, is_active, created_at, updated_at`
+
+const permissionColumns = `id, name, resource, action, COALESCE(description, '') AS description, created_at`
+
+func (r *roleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	roles := []*models.Role{}
+	if err := r.db.SelectContext(ctx, &roles, `SELECT `+roleColumns+` FROM roles ORDER BY name`); err != nil {
+		return nil, err
+	}
+
+	// Load every mapping in one query instead of one per role
+	var rows []struct {
+		RoleID string `db:"role_id"`
+		models.Permission
+	}
+	query := `
+		SELECT rp.role_id, p.id, p.name, p.resource, p.action,
+			COALESCE(p.description, '') AS description, p.created_at
+		FROM role_permissions rp
+		JOIN permissions p ON p.id = rp.permission_id
+		ORDER BY p.name`
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*models.Role, len(roles))
+	for _, role := range roles {
+		role.Permissions = []*models.Permission{}
+		byID[role.ID] = role
+	}
+	for i := range rows {
+		if role, ok := byID[rows[i].RoleID]; ok {
+			permission := rows[i].Permission
+			role.Permissions = append(role.Permissions, &permission)
+		}
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) FindByID(ctx context.Context, id string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.GetContext(ctx, &role, `SELECT `+roleColumns+` FROM roles WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *models.Role) error {
+	query := `
+		INSERT INTO roles (name, description, is_active)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at`
+	err := r.db.QueryRowxContext(ctx, query, role.Name, role.Description, role.IsActive).
+		Scan(&role.ID, &role.CreatedAt, &role.UpdatedAt)
+	return mapError(err)
+}
+
+func (r *roleRepository) ListPermissions(ctx context.Context) ([]*models.Permission, error) {
+	permissions := []*models.Permission{}
+	query := `SELECT ` + permissionColumns + ` FROM permissions ORDER BY name`
+	if err := r.db.SelectContext(ctx, &permissions, query); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *roleRepository) CreatePermission(ctx context.Context, permission *models.Permission) error {
+	query := `
+		INSERT INTO permissions (name, resource, action, description)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		permission.Name, permission.Resource, permission.Action, permission.Description,
+	).Scan(&permission.ID, &permission.CreatedAt)
+	return mapError(err)
+}
+
+// AttachPermission grants a permission (by name) to a role. Attaching a
+// permission the role already has is a no-op.
+func (r *roleRepository) AttachPermission(ctx context.Context, roleID, permissionName string) error {
+	var permissionID string
+	err := r.db.GetContext(ctx, &permissionID, `SELECT id FROM permissions WHERE name = $1`, permissionName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	query := `
+		INSERT INTO role_permissions (role_id, permission_id)
+		VALUES ($1, $2)
+		ON CONFLICT (role_id, permission_id) DO NOTHING`
+	_, err = r.db.ExecContext(ctx, query, roleID, permissionID)
+	return err
+}
+
+// DetachPermission removes a permission (by name) from a role. It returns
+// ErrNotFound if the role did not have it.
+func (r *roleRepository) DetachPermission(ctx context.Context, roleID, permissionName string) error {
+	query := `
+		DELETE FROM role_permissions rp
+		USING permissions p
+		WHERE rp.permission_id = p.id AND rp.role_id = $1 AND p.name = $2`
+	result, err := r.db.ExecContext(ctx, query, roleID, permissionName)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// SeatRepository defines data access methods for seat maps and the seat
+// inventory of events with reserved seating
+type SeatRepository interface {
+	CreateSeatMap(ctx context.Context, seatMap *models.SeatMap) error
+	// CreateSeats inserts all seats of a map in a single statement. ErrDuplicate
+	// is returned if a position appears twice.
+	CreateSeats(ctx context.Context, seats []*models.Seat) error
+	FindSeatMapByID(ctx context.Context, id string) (*models.SeatMap, error)
+	// ListSeats returns a map's seats by section, row and number
+	ListSeats(ctx context.Context, seatMapID string) ([]*models.Seat, error)
+
+	// ReplaceEventSeats drops the event's seat inventory and stocks it with
+	// every seat of the map, all free. It returns the number of seats.
+	ReplaceEventSeats(ctx context.Context, eventID, seatMapID string) (int, error)
+	// ListEventSeats returns the event's seats with their availability
+	ListEventSeats(ctx context.Context, eventID string) ([]*models.EventSeat, error)
+	// HoldSeats gives the seats to the order in one conditional update. If any
+	// of them is taken or not part of the event, nothing is held and
+	// ErrConflict is returned.
+	HoldSeats(ctx context.Context, eventID, orderID string, seatIDs []string) error
+	// ReleaseSeatsByOrder frees the seats held by the order, if any, except
+	// those whose ticket is still in use (valid, used or transferred)
+	ReleaseSeatsByOrder(ctx context.Context, orderID string) error
+}
+
+type seatRepository struct {
+	db dbtx
+}
+
+// NewSeatRepository creates a new seat repository instance
+func NewSeatRepository(db *sqlx.DB) SeatRepository {
+	return &seatRepository{db: db}
+}
+
+func (r *seatRepository) CreateSeatMap(ctx context.Context, seatMap *models.SeatMap) error {
+	query := `
+		INSERT INTO seat_maps (name, venue_id, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at`
+	err := r.db.QueryRowxContext(ctx, query, seatMap.Name, seatMap.VenueID, seatMap.CreatedBy).
+		Scan(&seatMap.ID, &seatMap.CreatedAt, &seatMap.UpdatedAt)
+	return mapError(err)
+}
+
+// seatPosition identifies a seat within its map
+type seatPosition struct {
+	section string
+	row     string
+	number  int
+}
+
+// CreateSeats passes the seats as arrays so large maps stay within the
+// statement's parameter limit
+func (r *seatRepository) CreateSeats(ctx context.Context, seats []*models.Seat) error {
+	if len(seats) == 0 {
+		return nil
+	}
+
+	seatMapID := seats[0].SeatMapID
+	sections := make([]string, len(seats))
+	rows := make([]string, len(seats))
+	numbers := make([]int64, len(seats))
+	byPosition := make(map[seatPosition]*models.Seat, len(seats))
+	for i, seat := range seats {
+		sections[i], rows[i], numbers[i] = seat.Section, seat.Row, int64(seat.Number)
+		byPosition[seatPosition{seat.Section, seat.Row, seat.Number}] = seat
+	}
+
+	query := `
+		INSERT INTO seats (seat_map_id, section, row_label, number)
+		SELECT $1, * FROM unnest($2::text[], $3::text[], $4::int[])
+		RETURNING id, section, row_label, number`
+	result, err := r.db.QueryxContext(ctx, query, seatMapID, pq.Array(sections), pq.Array(rows), pq.Array(numbers))
+	if err != nil {
+		return mapError(err)
+	}
+	defer result.Close()
+
+	for result.Next() {
+		var id string
+		var pos seatPosition
+		if err := result.Scan(&id, &pos.section, &pos.row, &pos.number); err != nil {
+			return err
+		}
+		if seat, ok := byPosition[pos]; ok {
+			seat.ID = id
+		}
+	}
+	return mapError(result.Err())
+}
+
+func (r *seatRepository) FindSeatMapByID(ctx context.Context, id string) (*models.SeatMap, error) {
+	var seatMap models.SeatMap
+	query := `SELECT id, name, venue_id, created_by, created_at, updated_at FROM seat_maps WHERE id = $1`
+	if err := r.db.GetContext(ctx, &seatMap, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &seatMap, nil
+}
+
+func (r *seatRepository) ListSeats(ctx context.Context, seatMapID string) ([]*models.Seat, error) {
+	seats := []*models.Seat{}
+	query := `
+		SELECT id, seat_map_id, section, row_label, number
+		FROM seats
+		WHERE seat_map_id = $1
+		ORDER BY section, row_label, number`
+	if err := r.db.SelectContext(ctx, &seats, query, seatMapID); err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+func (r *seatRepository) ReplaceEventSeats(ctx context.Context, eventID, seatMapID string) (int, error) {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM event_seats WHERE event_id = $1`, eventID); err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO event_seats (event_id, seat_id)
+		SELECT $1, id FROM seats WHERE seat_map_id = $2`
+	result, err := r.db.ExecContext(ctx, query, eventID, seatMapID)
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+func (r *seatRepository) ListEventSeats(ctx context.Context, eventID string) ([]*models.EventSeat, error) {
+	seats := []*models.EventSeat{}
+	query := `
+		SELECT s.id AS seat_id, s.section, s.row_label, s.number, es.order_id IS NULL AS available
+		FROM event_seats es
+		JOIN seats s ON s.id = es.seat_id
+		WHERE es.event_id = $1
+		ORDER BY s.section, s.row_label, s.number`
+	if err := r.db.SelectContext(ctx, &seats, query, eventID); err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+func (r *seatRepository) HoldSeats(ctx context.Context, eventID, orderID string, seatIDs []string) error {
+	query := `
+		UPDATE event_seats
+		SET order_id = $1, updated_at = NOW()
+		WHERE event_id = $2 AND seat_id = ANY($3) AND order_id IS NULL`
+	result, err := r.db.ExecContext(ctx, query, orderID, eventID, pq.Array(seatIDs))
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(affected) != len(seatIDs) {
+		// The caller's transaction rolls back the seats that were held
+		return ErrConflict
+	}
+	return nil
+}
+
+func (r *seatRepository) ReleaseSeatsByOrder(ctx context.Context, orderID string) error {
+	query := `
+		UPDATE event_seats es SET order_id = NULL, updated_at = NOW()
+		WHERE es.order_id = $1
+		AND NOT EXISTS (
+			SELECT 1 FROM tickets t
+			WHERE t.order_id = $1 AND t.seat_id = es.seat_id AND t.status <> $2
+		)`
+	_, err := r.db.ExecContext(ctx, query, orderID, string(models.TicketStatusCancelled))
+	return err
+}
@@ -0,0 +1,116 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSeatRepository_Integration_SeatMap
+// Summary: Seat map and seat creation against Postgres
+// Purpose: Verify seats get their IDs back, are listed by section, row and number, and a position
+// cannot appear twice in a map (ErrDuplicate)
+func TestSeatRepository_Integration_SeatMap(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewSeatRepository(testDB)
+
+	venue := createTestVenue(t, "Jakarta Convention Center", 10000)
+	seatMap := &models.SeatMap{Name: "Plenary Hall", VenueID: venue.ID}
+	require.NoError(t, repo.CreateSeatMap(ctx, seatMap))
+	seats := []*models.Seat{
+		{SeatMapID: seatMap.ID, Section: "Floor", Row: "B", Number: 1},
+		{SeatMapID: seatMap.ID, Section: "Floor", Row: "A", Number: 2},
+		{SeatMapID: seatMap.ID, Section: "Floor", Row: "A", Number: 1},
+	}
+	require.NoError(t, repo.CreateSeats(ctx, seats))
+	for _, seat := range seats {
+		assert.NotEmpty(t, seat.ID)
+	}
+
+	listed, err := repo.ListSeats(ctx, seatMap.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.Equal(t, seats[2].ID, listed[0].ID)
+	assert.Equal(t, seats[0].ID, listed[2].ID)
+
+	err = repo.CreateSeats(ctx, []*models.Seat{{SeatMapID: seatMap.ID, Section: "Floor", Row: "A", Number: 1}})
+	assert.ErrorIs(t, err, ErrDuplicate)
+
+	_, err = repo.FindSeatMapByID(ctx, "00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// TestSeatRepository_Integration_HoldSeats
+// Summary: Per-event seat holds against Postgres
+// Purpose: Verify an event is stocked with the map's seats, a hold takes all requested seats or none,
+// seats of other events are refused, and releasing an order frees its seats except those of used tickets
+func TestSeatRepository_Integration_HoldSeats(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewSeatRepository(testDB)
+	tickets := NewTicketRepository(testDB)
+
+	venue := createTestVenue(t, "Jakarta Convention Center", 10000)
+	seatMap := &models.SeatMap{Name: "Plenary Hall", VenueID: venue.ID}
+	require.NoError(t, repo.CreateSeatMap(ctx, seatMap))
+	seats := []*models.Seat{
+		{SeatMapID: seatMap.ID, Section: "Floor", Row: "A", Number: 1},
+		{SeatMapID: seatMap.ID, Section: "Floor", Row: "A", Number: 2},
+	}
+	require.NoError(t, repo.CreateSeats(ctx, seats))
+
+	event := createTestEvent(t, 2, nil)
+	other := createTestEvent(t, 2, nil)
+	count, err := repo.ReplaceEventSeats(ctx, event.ID, seatMap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	count, err = repo.ReplaceEventSeats(ctx, event.ID, seatMap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "replacing the inventory does not duplicate seats")
+
+	user := createTestUser(t, "seats@example.com")
+	first := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 1, TotalPrice: 250000}
+	require.NoError(t, tickets.CreateOrder(ctx, first))
+	second := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 2, TotalPrice: 500000}
+	require.NoError(t, tickets.CreateOrder(ctx, second))
+
+	require.NoError(t, repo.HoldSeats(ctx, event.ID, first.ID, []string{seats[0].ID}))
+	assert.ErrorIs(t, repo.HoldSeats(ctx, other.ID, second.ID, []string{seats[1].ID}), ErrConflict)
+
+	// Inside a transaction the partial hold of seats[1] is rolled back with it
+	tx, err := testDB.Beginx()
+	require.NoError(t, err)
+	txRepo := &seatRepository{db: tx}
+	assert.ErrorIs(t, txRepo.HoldSeats(ctx, event.ID, second.ID, []string{seats[0].ID, seats[1].ID}), ErrConflict)
+	require.NoError(t, tx.Rollback())
+
+	listed, err := repo.ListEventSeats(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.False(t, listed[0].Available)
+	assert.True(t, listed[1].Available)
+
+	require.NoError(t, repo.ReleaseSeatsByOrder(ctx, first.ID))
+	require.NoError(t, repo.HoldSeats(ctx, event.ID, second.ID, []string{seats[0].ID, seats[1].ID}))
+
+	// Closing an order whose first ticket was already used only frees the other seat
+	require.NoError(t, tickets.CreateTickets(ctx, []*models.Ticket{
+		{OrderID: second.ID, TicketCode: "TKT-SEAT-1", SeatID: &seats[0].ID, Status: string(models.TicketStatusUsed)},
+		{OrderID: second.ID, TicketCode: "TKT-SEAT-2", SeatID: &seats[1].ID},
+	}))
+	released, err := tickets.UpdateTicketsStatusByOrderID(ctx, second.ID, models.TicketStatusCancelled)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+	require.NoError(t, repo.ReleaseSeatsByOrder(ctx, second.ID))
+	listed, err = repo.ListEventSeats(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.False(t, listed[0].Available, "the used ticket keeps its seat")
+	assert.True(t, listed[1].Available)
+}
@@ -3,10 +3,15 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // TicketRepository defines data access methods for tickets and orders
@@ -14,19 +19,70 @@ type TicketRepository interface {
 	// Order operations
 	CreateOrder(ctx context.Context, order *models.TicketOrder) error
 	FindOrderByID(ctx context.Context, id string) (*models.TicketOrder, error)
+	// FindOrderByIDForUpdate locks the order row until the transaction ends
+	FindOrderByIDForUpdate(ctx context.Context, id string) (*models.TicketOrder, error)
 	ListOrdersByUserID(ctx context.Context, userID string) ([]*models.TicketOrder, error)
-	UpdateOrderStatus(ctx context.Context, orderID string, status models.TicketOrderStatus) error
+	// FindExpiredHoldsForUpdate locks up to limit pending orders whose hold has
+	// passed. Rows locked by another transaction are skipped, so several API
+	// instances can sweep at once.
+	FindExpiredHoldsForUpdate(ctx context.Context, limit int) ([]*models.TicketOrder, error)
+	// UpdateOrderStatus moves an order from one status to another and records
+	// the change in order_status_history. It returns a *models.TransitionError
+	// for a move the state machine forbids, and ErrConflict if the order is no
+	// longer in status change.From.
+	UpdateOrderStatus(ctx context.Context, change models.OrderStatusChange) error
+	// UpdateOrderPayment is UpdateOrderStatus that also records the gateway transaction
+	UpdateOrderPayment(ctx context.Context, change models.OrderStatusChange, paymentID string) error
+	// ListOrderHistory returns an order's status changes, oldest first
+	ListOrderHistory(ctx context.Context, orderID string) ([]*models.OrderStatusHistory, error)
+	// HasSoldOrders reports whether the event has paid, confirmed or refunded orders
+	HasSoldOrders(ctx context.Context, eventID string) (bool, error)
+	// HasActiveHolds reports whether the event has pending orders whose hold
+	// has not expired, i.e. purchases that may still be charged
+	HasActiveHolds(ctx context.Context, eventID string) (bool, error)
+	// DeleteUnsoldOrdersByEvent deletes the event's cancelled orders and its
+	// pending orders whose hold expired, with their tickets and history
+	DeleteUnsoldOrdersByEvent(ctx context.Context, eventID string) error
 
 	// Ticket operations
 	CreateTickets(ctx context.Context, tickets []*models.Ticket) error
+	FindTicketByID(ctx context.Context, id string) (*models.Ticket, error)
+	// FindTicketByIDForUpdate locks the ticket row until the transaction ends
+	FindTicketByIDForUpdate(ctx context.Context, id string) (*models.Ticket, error)
+	FindTicketByCode(ctx context.Context, code string) (*models.Ticket, error)
 	FindTicketsByOrderID(ctx context.Context, orderID string) ([]*models.Ticket, error)
+	// CheckInTicket moves a valid ticket of a confirmed order for eventID to
+	// used in one statement, so two gates scanning the same ticket cannot
+	// both admit it. It returns ErrConflict if the ticket cannot be admitted.
+	CheckInTicket(ctx context.Context, ticketID, eventID string) (*models.Ticket, error)
+	// MarkTicketUsed sets a valid or already used ticket to used at usedAt.
+	// Offline scans use it to record, or move back, when the ticket was first
+	// scanned. It returns ErrConflict for a ticket in any other status.
+	MarkTicketUsed(ctx context.Context, ticketID string, usedAt time.Time) error
+	// ListCheckinTickets returns the valid and used tickets of an event's
+	// confirmed orders, the ones a gate may see
+	ListCheckinTickets(ctx context.Context, eventID string) ([]*models.Ticket, error)
+	// UpdateTicketsStatusByOrderID moves every ticket of the order that may
+	// reach status to it and returns how many moved; tickets already in a
+	// final status (used, transferred) are left alone
+	UpdateTicketsStatusByOrderID(ctx context.Context, orderID string, status models.TicketStatus) (int, error)
+	// UpdateTicketStatus moves one ticket between statuses. It returns a
+	// *models.TransitionError for a move the state machine forbids, and
+	// ErrConflict if the ticket is no longer in status from.
+	UpdateTicketStatus(ctx context.Context, ticketID string, from, to models.TicketStatus) error
 
-	// Transaction helper
-	WithTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error
+	// Transfers
+	CreateTransfer(ctx context.Context, transfer *models.TicketTransfer) error
+	// ListTransferChain returns every transfer that led to or from the
+	// ticket, oldest first, with the users' emails
+	ListTransferChain(ctx context.Context, ticketID string) ([]*models.TicketTransfer, error)
+
+	// Reporting
+	SummarizeOrdersByEvent(ctx context.Context, eventID string) ([]*models.OrderStatusSummary, error)
 }
 
 type ticketRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 // NewTicketRepository creates a new ticket repository instance
@@ -34,42 +90,405 @@ func NewTicketRepository(db *sqlx.DB) TicketRepository {
 	return &ticketRepository{db: db}
 }
 
+const orderColumns = `id, event_id, user_id, quantity, total_price, status, source, tier_id, payment_id, created_at, updated_at, hold_expires_at`
+
+const ticketColumns = `id, order_id, ticket_code, status, seat_id, used_at, created_at`
+
 func (r *ticketRepository) CreateOrder(ctx context.Context, order *models.TicketOrder) error {
-	// TODO: implement order creation
-	return fmt.Errorf("not implemented")
+	if order.Status == "" {
+		order.Status = string(models.OrderStatusPending)
+	}
+	if order.Source == "" {
+		order.Source = string(models.OrderSourcePurchase)
+	}
+	reason := "order placed"
+	if order.Source == string(models.OrderSourceTransfer) {
+		reason = "ticket received by transfer"
+	}
+
+	// The creation is the first entry in the order's status history
+	query := `
+		WITH created AS (
+			INSERT INTO ticket_orders (event_id, user_id, quantity, total_price, status, source, tier_id, payment_id, hold_expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id, user_id, status, created_at, updated_at
+		), history AS (
+			INSERT INTO order_status_history (order_id, to_status, changed_by, reason)
+			SELECT id, status, user_id, $10 FROM created
+		)
+		SELECT id, created_at, updated_at FROM created`
+	err := r.db.QueryRowxContext(ctx, query,
+		order.EventID, order.UserID, order.Quantity, order.TotalPrice, order.Status, order.Source,
+		order.TierID, order.PaymentID, order.HoldExpiresAt, reason,
+	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+	return mapError(err)
 }
 
 func (r *ticketRepository) FindOrderByID(ctx context.Context, id string) (*models.TicketOrder, error) {
-	// TODO: implement order lookup
-	return nil, fmt.Errorf("not implemented")
+	return r.findOrder(ctx, `SELECT `+orderColumns+` FROM ticket_orders WHERE id = $1`, id)
+}
+
+func (r *ticketRepository) FindOrderByIDForUpdate(ctx context.Context, id string) (*models.TicketOrder, error) {
+	return r.findOrder(ctx, `SELECT `+orderColumns+` FROM ticket_orders WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *ticketRepository) findOrder(ctx context.Context, query, id string) (*models.TicketOrder, error) {
+	var order models.TicketOrder
+	if err := r.db.GetContext(ctx, &order, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
 }
 
 func (r *ticketRepository) ListOrdersByUserID(ctx context.Context, userID string) ([]*models.TicketOrder, error) {
-	// TODO: implement user's orders lookup
-	return nil, fmt.Errorf("not implemented")
+	orders := []*models.TicketOrder{}
+	query := `SELECT ` + orderColumns + ` FROM ticket_orders WHERE user_id = $1 ORDER BY created_at DESC`
+	if err := r.db.SelectContext(ctx, &orders, query, userID); err != nil {
+		return nil, err
+	}
+	return orders, nil
 }
 
-func (r *ticketRepository) UpdateOrderStatus(ctx context.Context, orderID string, status models.TicketOrderStatus) error {
-	// TODO: implement order status update
-	return fmt.Errorf("not implemented")
+func (r *ticketRepository) FindExpiredHoldsForUpdate(ctx context.Context, limit int) ([]*models.TicketOrder, error) {
+	orders := []*models.TicketOrder{}
+	query := `
+		SELECT ` + orderColumns + `
+		FROM ticket_orders
+		WHERE status = $1 AND hold_expires_at < NOW()
+		ORDER BY hold_expires_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`
+	if err := r.db.SelectContext(ctx, &orders, query, string(models.OrderStatusPending), limit); err != nil {
+		return nil, err
+	}
+	return orders, nil
 }
 
+func (r *ticketRepository) UpdateOrderStatus(ctx context.Context, change models.OrderStatusChange) error {
+	return r.updateOrder(ctx, change, `status = $3`)
+}
+
+func (r *ticketRepository) UpdateOrderPayment(ctx context.Context, change models.OrderStatusChange, paymentID string) error {
+	return r.updateOrder(ctx, change, `status = $3, payment_id = $6`, paymentID)
+}
+
+func (r *ticketRepository) ListOrderHistory(ctx context.Context, orderID string) ([]*models.OrderStatusHistory, error) {
+	history := []*models.OrderStatusHistory{}
+	query := `
+		SELECT id, order_id, from_status, to_status, changed_by, reason, created_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY created_at, id`
+	if err := r.db.SelectContext(ctx, &history, query, orderID); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// updateOrder runs a conditional order update together with its history row
+// and tells a missing order (ErrNotFound) apart from one whose status has
+// moved on (ErrConflict). set is the SET clause; $1-$5 are the change's order
+// ID, from, to, actor and reason, extra arguments follow from $6.
+func (r *ticketRepository) updateOrder(ctx context.Context, change models.OrderStatusChange, set string, extra ...interface{}) error {
+	if err := change.From.ValidateTransition(change.To); err != nil {
+		return err
+	}
+
+	query := `
+		WITH updated AS (
+			UPDATE ticket_orders SET ` + set + `, updated_at = NOW()
+			WHERE id = $1 AND status = $2
+			RETURNING id
+		)
+		INSERT INTO order_status_history (order_id, from_status, to_status, changed_by, reason)
+		SELECT id, $2, $3, $4, $5 FROM updated`
+	args := append([]interface{}{change.OrderID, string(change.From), string(change.To), change.ActorID, change.Reason}, extra...)
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM ticket_orders WHERE id = $1)`, change.OrderID); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrConflict
+}
+
+// CreateTickets inserts all tickets in a single statement. IDs are generated
+// here so each returned row can be matched back to its ticket.
 func (r *ticketRepository) CreateTickets(ctx context.Context, tickets []*models.Ticket) error {
-	// TODO: implement bulk ticket creation
-	return fmt.Errorf("not implemented")
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(tickets))
+	args := make([]interface{}, 0, len(tickets)*5)
+	byID := make(map[string]*models.Ticket, len(tickets))
+	for i, ticket := range tickets {
+		if ticket.ID == "" {
+			ticket.ID = uuid.New().String()
+		}
+		if ticket.Status == "" {
+			ticket.Status = string(models.TicketStatusValid)
+		}
+		byID[ticket.ID] = ticket
+
+		n := i * 5
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5))
+		args = append(args, ticket.ID, ticket.OrderID, ticket.TicketCode, ticket.Status, ticket.SeatID)
+	}
+
+	query := `INSERT INTO tickets (id, order_id, ticket_code, status, seat_id) VALUES ` +
+		strings.Join(values, ", ") + ` RETURNING id, created_at`
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			return err
+		}
+		if ticket, ok := byID[id]; ok {
+			ticket.CreatedAt = createdAt
+		}
+	}
+	return mapError(rows.Err())
+}
+
+func (r *ticketRepository) FindTicketByID(ctx context.Context, id string) (*models.Ticket, error) {
+	return r.findTicket(ctx, `SELECT `+ticketColumns+` FROM tickets WHERE id = $1`, id)
+}
+
+func (r *ticketRepository) FindTicketByIDForUpdate(ctx context.Context, id string) (*models.Ticket, error) {
+	return r.findTicket(ctx, `SELECT `+ticketColumns+` FROM tickets WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *ticketRepository) FindTicketByCode(ctx context.Context, code string) (*models.Ticket, error) {
+	return r.findTicket(ctx, `SELECT `+ticketColumns+` FROM tickets WHERE ticket_code = $1`, code)
+}
+
+func (r *ticketRepository) findTicket(ctx context.Context, query, arg string) (*models.Ticket, error) {
+	var ticket models.Ticket
+	if err := r.db.GetContext(ctx, &ticket, query, arg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *ticketRepository) CheckInTicket(ctx context.Context, ticketID, eventID string) (*models.Ticket, error) {
+	var ticket models.Ticket
+	query := `
+		UPDATE tickets t SET status = $3, used_at = NOW()
+		FROM ticket_orders o
+		WHERE t.id = $1 AND t.status = $4
+			AND o.id = t.order_id AND o.event_id = $2 AND o.status = $5
+		RETURNING t.id, t.order_id, t.ticket_code, t.status, t.seat_id, t.used_at, t.created_at`
+	err := r.db.GetContext(ctx, &ticket, query, ticketID, eventID,
+		string(models.TicketStatusUsed), string(models.TicketStatusValid), string(models.OrderStatusConfirmed))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrConflict
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *ticketRepository) MarkTicketUsed(ctx context.Context, ticketID string, usedAt time.Time) error {
+	query := `UPDATE tickets SET status = $2, used_at = $3 WHERE id = $1 AND status = ANY($4)`
+	res, err := r.db.ExecContext(ctx, query, ticketID, string(models.TicketStatusUsed), usedAt,
+		pq.Array([]string{string(models.TicketStatusValid), string(models.TicketStatusUsed)}))
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func (r *ticketRepository) ListCheckinTickets(ctx context.Context, eventID string) ([]*models.Ticket, error) {
+	tickets := []*models.Ticket{}
+	query := `
+		SELECT t.id, t.order_id, t.ticket_code, t.status, t.seat_id, t.used_at, t.created_at
+		FROM tickets t
+		JOIN ticket_orders o ON o.id = t.order_id
+		WHERE o.event_id = $1 AND o.status = $2 AND t.status = ANY($3)
+		ORDER BY t.id`
+	err := r.db.SelectContext(ctx, &tickets, query, eventID, string(models.OrderStatusConfirmed),
+		pq.Array([]string{string(models.TicketStatusValid), string(models.TicketStatusUsed)}))
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
 }
 
 func (r *ticketRepository) FindTicketsByOrderID(ctx context.Context, orderID string) ([]*models.Ticket, error) {
-	// TODO: implement tickets lookup by order
-	return nil, fmt.Errorf("not implemented")
-}
-
-func (r *ticketRepository) WithTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
-	// TODO: implement transaction wrapper
-	// tx, err := r.db.BeginTx(ctx, nil)
-	// if err != nil { return err }
-	// defer tx.Rollback()
-	// if err := fn(tx); err != nil { return err }
-	// return tx.Commit()
-	return fmt.Errorf("not implemented")
+	tickets := []*models.Ticket{}
+	query := `SELECT ` + ticketColumns + ` FROM tickets WHERE order_id = $1 ORDER BY created_at, id`
+	if err := r.db.SelectContext(ctx, &tickets, query, orderID); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *ticketRepository) UpdateTicketsStatusByOrderID(ctx context.Context, orderID string, status models.TicketStatus) (int, error) {
+	sources := models.TicketStatusesMovableTo(status)
+	if len(sources) == 0 {
+		return 0, &models.TransitionError{Entity: "ticket", From: "any status", To: string(status)}
+	}
+	from := make([]string, len(sources))
+	for i, source := range sources {
+		from[i] = string(source)
+	}
+
+	query := `UPDATE tickets SET status = $1 WHERE order_id = $2 AND status = ANY($3)`
+	res, err := r.db.ExecContext(ctx, query, string(status), orderID, pq.Array(from))
+	if err != nil {
+		return 0, err
+	}
+	moved, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), nil
+}
+
+func (r *ticketRepository) UpdateTicketStatus(ctx context.Context, ticketID string, from, to models.TicketStatus) error {
+	if err := from.ValidateTransition(to); err != nil {
+		return err
+	}
+
+	res, err := r.db.ExecContext(ctx, `UPDATE tickets SET status = $3 WHERE id = $1 AND status = $2`,
+		ticketID, string(from), string(to))
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func (r *ticketRepository) CreateTransfer(ctx context.Context, transfer *models.TicketTransfer) error {
+	query := `
+		INSERT INTO ticket_transfers (event_id, from_ticket_id, to_ticket_id, from_user_id, to_user_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		transfer.EventID, transfer.FromTicketID, transfer.ToTicketID, transfer.FromUserID, transfer.ToUserID,
+	).Scan(&transfer.ID, &transfer.CreatedAt)
+	return mapError(err)
+}
+
+func (r *ticketRepository) ListTransferChain(ctx context.Context, ticketID string) ([]*models.TicketTransfer, error) {
+	transfers := []*models.TicketTransfer{}
+	// Walk back to the purchased ticket and forward to the current one
+	query := `
+		WITH RECURSIVE earlier AS (
+			SELECT * FROM ticket_transfers WHERE to_ticket_id = $1
+			UNION ALL
+			SELECT t.* FROM ticket_transfers t JOIN earlier e ON t.to_ticket_id = e.from_ticket_id
+		), later AS (
+			SELECT * FROM ticket_transfers WHERE from_ticket_id = $1
+			UNION ALL
+			SELECT t.* FROM ticket_transfers t JOIN later l ON t.from_ticket_id = l.to_ticket_id
+		), chain AS (
+			SELECT * FROM earlier UNION SELECT * FROM later
+		)
+		SELECT c.id, c.event_id, c.from_ticket_id, c.to_ticket_id, c.from_user_id, c.to_user_id, c.created_at,
+			fu.email AS from_email, tu.email AS to_email
+		FROM chain c
+		LEFT JOIN users fu ON fu.id = c.from_user_id
+		LEFT JOIN users tu ON tu.id = c.to_user_id
+		ORDER BY c.created_at, c.id`
+	if err := r.db.SelectContext(ctx, &transfers, query, ticketID); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// soldOrderStatuses are the statuses of orders whose money was taken. Refunded
+// orders count too: their history is the refund's audit trail.
+var soldOrderStatuses = []string{
+	string(models.OrderStatusPaid), string(models.OrderStatusConfirmed), string(models.OrderStatusRefunded),
+}
+
+func (r *ticketRepository) HasSoldOrders(ctx context.Context, eventID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM ticket_orders WHERE event_id = $1 AND status = ANY($2))`
+	if err := r.db.GetContext(ctx, &exists, query, eventID, pq.Array(soldOrderStatuses)); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *ticketRepository) HasActiveHolds(ctx context.Context, eventID string) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM ticket_orders
+			WHERE event_id = $1 AND status = $2 AND hold_expires_at > NOW()
+		)`
+	if err := r.db.GetContext(ctx, &exists, query, eventID, string(models.OrderStatusPending)); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *ticketRepository) DeleteUnsoldOrdersByEvent(ctx context.Context, eventID string) error {
+	// A pending order without a hold predates hold expiry and is never swept
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM ticket_orders
+		WHERE event_id = $1
+			AND (status = $2 OR (status = $3 AND (hold_expires_at IS NULL OR hold_expires_at <= NOW())))`,
+		eventID, string(models.OrderStatusCancelled), string(models.OrderStatusPending))
+	return mapError(err)
+}
+
+// SummarizeOrdersByEvent groups an event's bought orders by status; orders
+// holding transferred tickets are left out so no ticket is counted twice
+func (r *ticketRepository) SummarizeOrdersByEvent(ctx context.Context, eventID string) ([]*models.OrderStatusSummary, error) {
+	summaries := []*models.OrderStatusSummary{}
+	query := `
+		SELECT status, COUNT(*) AS orders, COALESCE(SUM(quantity), 0) AS tickets,
+			COALESCE(SUM(total_price), 0) AS revenue
+		FROM ticket_orders
+		WHERE event_id = $1 AND source = $2
+		GROUP BY status
+		ORDER BY status`
+	if err := r.db.SelectContext(ctx, &summaries, query, eventID, string(models.OrderSourcePurchase)); err != nil {
+		return nil, err
+	}
+	return summaries, nil
 }
@@ -0,0 +1,261 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTicketRepository_Integration_Orders
+// Summary: Orders and tickets against Postgres
+// Purpose: Verify order round-trips, batch ticket insert, duplicate codes and the sales summary
+func TestTicketRepository_Integration_Orders(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewTicketRepository(testDB)
+
+	user := createTestUser(t, "citra@example.com")
+	event := createTestEvent(t, 100, nil)
+
+	order := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 2, TotalPrice: 500000}
+	require.NoError(t, repo.CreateOrder(ctx, order))
+	assert.Equal(t, string(models.OrderStatusPending), order.Status)
+
+	tickets := []*models.Ticket{
+		{OrderID: order.ID, TicketCode: "TKT-0001"},
+		{OrderID: order.ID, TicketCode: "TKT-0002"},
+	}
+	require.NoError(t, repo.CreateTickets(ctx, tickets))
+	for _, ticket := range tickets {
+		assert.NotEmpty(t, ticket.ID)
+		assert.False(t, ticket.CreatedAt.IsZero())
+	}
+
+	err := repo.CreateTickets(ctx, []*models.Ticket{{OrderID: order.ID, TicketCode: "TKT-0001"}})
+	assert.ErrorIs(t, err, ErrDuplicate)
+
+	stored, err := repo.FindTicketsByOrderID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored, 2)
+
+	require.NoError(t, repo.UpdateOrderStatus(ctx, models.OrderStatusChange{OrderID: order.ID, From: models.OrderStatusPending, To: models.OrderStatusPaid}))
+	assert.ErrorIs(t, repo.UpdateOrderStatus(ctx, models.OrderStatusChange{OrderID: order.ID, From: models.OrderStatusPending, To: models.OrderStatusPaid}), ErrConflict,
+		"the order already left pending")
+	found, err := repo.FindOrderByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.OrderStatusPaid), found.Status)
+
+	var transitionErr *models.TransitionError
+	assert.ErrorAs(t, repo.UpdateOrderStatus(ctx, models.OrderStatusChange{OrderID: order.ID, From: models.OrderStatusPaid, To: models.OrderStatusPending}), &transitionErr)
+
+	history, err := repo.ListOrderHistory(ctx, order.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2, "creation and the one successful update")
+	assert.Nil(t, history[0].FromStatus)
+	assert.Equal(t, "order placed", history[0].Reason)
+	require.NotNil(t, history[0].ChangedBy)
+	assert.Equal(t, user.ID, *history[0].ChangedBy)
+	require.NotNil(t, history[1].FromStatus)
+	assert.Equal(t, string(models.OrderStatusPending), *history[1].FromStatus)
+	assert.Equal(t, string(models.OrderStatusPaid), history[1].ToStatus)
+	assert.Nil(t, history[1].ChangedBy)
+
+	_, err = repo.FindOrderByID(ctx, "00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, repo.UpdateOrderStatus(ctx, models.OrderStatusChange{OrderID: "00000000-0000-0000-0000-000000000000", From: models.OrderStatusPending, To: models.OrderStatusPaid}), ErrNotFound)
+
+	orders, err := repo.ListOrdersByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, orders, 1)
+
+	summaries, err := repo.SummarizeOrdersByEvent(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, 2, summaries[0].Tickets)
+	assert.Equal(t, 500000.0, summaries[0].Revenue)
+}
+
+// TestTicketRepository_Integration_ExpiredHolds
+// Summary: Expired hold lookup against Postgres
+// Purpose: Verify only pending orders past their hold are returned and rows locked by another sweeper are skipped
+func TestTicketRepository_Integration_ExpiredHolds(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewTicketRepository(testDB)
+
+	user := createTestUser(t, "dewi@example.com")
+	event := createTestEvent(t, 100, nil)
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	newOrder := func(holdExpiresAt time.Time) *models.TicketOrder {
+		order := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 1, TotalPrice: 250000, HoldExpiresAt: &holdExpiresAt}
+		require.NoError(t, repo.CreateOrder(ctx, order))
+		return order
+	}
+	expired := newOrder(past)
+	newOrder(future)
+	paid := newOrder(past)
+	require.NoError(t, repo.UpdateOrderStatus(ctx, models.OrderStatusChange{OrderID: paid.ID, From: models.OrderStatusPending, To: models.OrderStatusPaid}))
+
+	orders, err := repo.FindExpiredHoldsForUpdate(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, expired.ID, orders[0].ID)
+
+	// A second sweeper must not block on, or return, a hold the first one claimed
+	err = NewUnitOfWork(testDB).Do(ctx, func(first TxRepositories) error {
+		claimed, err := first.Tickets.FindExpiredHoldsForUpdate(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+
+		return NewUnitOfWork(testDB).Do(ctx, func(second TxRepositories) error {
+			others, err := second.Tickets.FindExpiredHoldsForUpdate(ctx, 10)
+			require.NoError(t, err)
+			assert.Empty(t, others)
+			return nil
+		})
+	})
+	require.NoError(t, err)
+}
+
+// TestTicketRepository_Integration_CheckIn
+// Summary: Gate check-in against Postgres
+// Purpose: Verify only valid tickets of confirmed orders for the scanned event are admitted, and only once
+// when two gates scan the same ticket at the same time
+func TestTicketRepository_Integration_CheckIn(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewTicketRepository(testDB)
+
+	user := createTestUser(t, "eka@example.com")
+	event := createTestEvent(t, 100, nil)
+	otherEvent := createTestEvent(t, 100, nil)
+
+	newTicket := func(code string, confirm bool) *models.Ticket {
+		order := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 1, TotalPrice: 250000}
+		require.NoError(t, repo.CreateOrder(ctx, order))
+		if confirm {
+			require.NoError(t, repo.UpdateOrderStatus(ctx, models.OrderStatusChange{OrderID: order.ID, From: models.OrderStatusPending, To: models.OrderStatusConfirmed}))
+		}
+		ticket := &models.Ticket{OrderID: order.ID, TicketCode: code}
+		require.NoError(t, repo.CreateTickets(ctx, []*models.Ticket{ticket}))
+		return ticket
+	}
+	confirmed := newTicket("TKT-CHECKIN-1", true)
+	pending := newTicket("TKT-CHECKIN-2", false)
+
+	found, err := repo.FindTicketByCode(ctx, "TKT-CHECKIN-1")
+	require.NoError(t, err)
+	assert.Equal(t, confirmed.ID, found.ID)
+	_, err = repo.FindTicketByCode(ctx, "TKT-MISSING")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = repo.CheckInTicket(ctx, confirmed.ID, otherEvent.ID)
+	assert.ErrorIs(t, err, ErrConflict, "wrong event")
+	_, err = repo.CheckInTicket(ctx, pending.ID, event.ID)
+	assert.ErrorIs(t, err, ErrConflict, "order not confirmed")
+
+	results := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := repo.CheckInTicket(ctx, confirmed.ID, event.ID)
+			results <- err
+		}()
+	}
+	var admitted, refused int
+	for range 2 {
+		if err := <-results; err == nil {
+			admitted++
+		} else {
+			assert.ErrorIs(t, err, ErrConflict)
+			refused++
+		}
+	}
+	assert.Equal(t, 1, admitted)
+	assert.Equal(t, 1, refused)
+
+	used, err := repo.FindTicketByID(ctx, confirmed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.TicketStatusUsed), used.Status)
+	assert.NotNil(t, used.UsedAt)
+}
+
+// TestTicketRepository_Integration_Transfers
+// Summary: Ticket transfers against Postgres
+// Purpose: Verify a ticket moves from valid to transferred only once, the chain is walked in both
+// directions from any ticket in it, and received orders stay out of the sales summary
+func TestTicketRepository_Integration_Transfers(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewTicketRepository(testDB)
+
+	users := []*models.User{
+		createTestUser(t, "fajar@example.com"),
+		createTestUser(t, "gita@example.com"),
+		createTestUser(t, "hana@example.com"),
+	}
+	event := createTestEvent(t, 100, nil)
+
+	order := &models.TicketOrder{EventID: event.ID, UserID: users[0].ID, Quantity: 1, TotalPrice: 250000, Status: string(models.OrderStatusConfirmed)}
+	require.NoError(t, repo.CreateOrder(ctx, order))
+	assert.Equal(t, string(models.OrderSourcePurchase), order.Source)
+	tickets := []*models.Ticket{{OrderID: order.ID, TicketCode: "TKT-TRANSFER-0"}}
+	require.NoError(t, repo.CreateTickets(ctx, tickets))
+
+	// Hand the ticket from the buyer to the second user, then on to the third
+	for i, to := range users[1:] {
+		from := tickets[i]
+		require.NoError(t, repo.UpdateTicketStatus(ctx, from.ID, models.TicketStatusValid, models.TicketStatusTransferred))
+
+		received := &models.TicketOrder{EventID: event.ID, UserID: to.ID, Quantity: 1, Status: string(models.OrderStatusConfirmed), Source: string(models.OrderSourceTransfer)}
+		require.NoError(t, repo.CreateOrder(ctx, received))
+		issued := &models.Ticket{OrderID: received.ID, TicketCode: fmt.Sprintf("TKT-TRANSFER-%d", i+1)}
+		require.NoError(t, repo.CreateTickets(ctx, []*models.Ticket{issued}))
+		tickets = append(tickets, issued)
+
+		require.NoError(t, repo.CreateTransfer(ctx, &models.TicketTransfer{
+			EventID: event.ID, FromTicketID: from.ID, ToTicketID: issued.ID, FromUserID: &users[i].ID, ToUserID: &to.ID,
+		}))
+	}
+
+	err := repo.UpdateTicketStatus(ctx, tickets[0].ID, models.TicketStatusValid, models.TicketStatusTransferred)
+	assert.ErrorIs(t, err, ErrConflict, "already transferred")
+	err = repo.CreateTransfer(ctx, &models.TicketTransfer{EventID: event.ID, FromTicketID: tickets[0].ID, ToTicketID: tickets[2].ID})
+	assert.ErrorIs(t, err, ErrDuplicate, "a ticket is handed over once")
+
+	for _, ticket := range tickets {
+		chain, err := repo.ListTransferChain(ctx, ticket.ID)
+		require.NoError(t, err)
+		require.Len(t, chain, 2)
+		assert.Equal(t, tickets[0].ID, chain[0].FromTicketID)
+		assert.Equal(t, "fajar@example.com", *chain[0].FromEmail)
+		assert.Equal(t, tickets[2].ID, chain[1].ToTicketID)
+		assert.Equal(t, "hana@example.com", *chain[1].ToEmail)
+	}
+
+	summaries, err := repo.SummarizeOrdersByEvent(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, 1, summaries[0].Orders)
+}
+
+// TestTicketRepository_Integration_CheckViolation
+// Summary: Order quantity CHECK constraint against Postgres
+// Purpose: Verify a non-positive quantity maps to ErrCheckViolation
+func TestTicketRepository_Integration_CheckViolation(t *testing.T) {
+	resetDB(t)
+	user := createTestUser(t, "dewi@example.com")
+	event := createTestEvent(t, 10, nil)
+
+	order := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 0}
+	err := NewTicketRepository(testDB).CreateOrder(context.Background(), order)
+	assert.ErrorIs(t, err, ErrCheckViolation)
+}
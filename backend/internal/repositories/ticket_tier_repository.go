@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// TicketTierRepository defines data access methods for an event's ticket tiers
+type TicketTierRepository interface {
+	// Create stores a tier. ErrDuplicate is returned if the event already has
+	// a tier with the same name.
+	Create(ctx context.Context, tier *models.TicketTier) error
+	FindByID(ctx context.Context, id string) (*models.TicketTier, error)
+	// ListByEventID returns the event's tiers, cheapest first
+	ListByEventID(ctx context.Context, eventID string) ([]*models.TicketTier, error)
+	// ListByEventIDs returns the tiers of several events, cheapest first
+	ListByEventIDs(ctx context.Context, eventIDs []string) ([]*models.TicketTier, error)
+	Update(ctx context.Context, tier *models.TicketTier) error
+	// Delete removes the tier. It returns ErrForeignKeyViolation while orders
+	// still reference it.
+	Delete(ctx context.Context, id string) error
+	// DecrementAvailableTickets takes quantity tickets from the tier. It
+	// returns ErrInsufficientTickets if the tier has fewer left.
+	DecrementAvailableTickets(ctx context.Context, tierID string, quantity int) error
+	// IncrementAvailableTickets returns released tickets to the tier
+	IncrementAvailableTickets(ctx context.Context, tierID string, quantity int) error
+}
+
+type ticketTierRepository struct {
+	db dbtx
+}
+
+// NewTicketTierRepository creates a new ticket tier repository instance
+func NewTicketTierRepository(db *sqlx.DB) TicketTierRepository {
+	return &ticketTierRepository{db: db}
+}
+
+const tierColumns = `id, event_id, name, price, total_tickets, available_tickets,
+	sales_start, sales_end, max_per_order, created_at, updated_at`
+
+func (r *ticketTierRepository) Create(ctx context.Context, tier *models.TicketTier) error {
+	query := `
+		INSERT INTO ticket_tiers (event_id, name, price, total_tickets, available_tickets,
+			sales_start, sales_end, max_per_order)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		tier.EventID, tier.Name, tier.Price, tier.TotalTickets, tier.AvailableTickets,
+		tier.SalesStart, tier.SalesEnd, tier.MaxPerOrder,
+	).Scan(&tier.ID, &tier.CreatedAt, &tier.UpdatedAt)
+	return mapError(err)
+}
+
+func (r *ticketTierRepository) FindByID(ctx context.Context, id string) (*models.TicketTier, error) {
+	var tier models.TicketTier
+	if err := r.db.GetContext(ctx, &tier, `SELECT `+tierColumns+` FROM ticket_tiers WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *ticketTierRepository) ListByEventID(ctx context.Context, eventID string) ([]*models.TicketTier, error) {
+	return r.ListByEventIDs(ctx, []string{eventID})
+}
+
+func (r *ticketTierRepository) ListByEventIDs(ctx context.Context, eventIDs []string) ([]*models.TicketTier, error) {
+	tiers := []*models.TicketTier{}
+	query := `SELECT ` + tierColumns + ` FROM ticket_tiers WHERE event_id = ANY($1) ORDER BY event_id, price, name`
+	if err := r.db.SelectContext(ctx, &tiers, query, pq.Array(eventIDs)); err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+func (r *ticketTierRepository) Update(ctx context.Context, tier *models.TicketTier) error {
+	query := `
+		UPDATE ticket_tiers
+		SET name = $1, price = $2, total_tickets = $3, available_tickets = $4,
+			sales_start = $5, sales_end = $6, max_per_order = $7, updated_at = NOW()
+		WHERE id = $8
+		RETURNING updated_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		tier.Name, tier.Price, tier.TotalTickets, tier.AvailableTickets,
+		tier.SalesStart, tier.SalesEnd, tier.MaxPerOrder, tier.ID,
+	).Scan(&tier.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return mapError(err)
+}
+
+func (r *ticketTierRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM ticket_tiers WHERE id = $1`, id)
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *ticketTierRepository) DecrementAvailableTickets(ctx context.Context, tierID string, quantity int) error {
+	query := `
+		UPDATE ticket_tiers
+		SET available_tickets = available_tickets - $1, updated_at = NOW()
+		WHERE id = $2 AND available_tickets >= $1`
+	result, err := r.db.ExecContext(ctx, query, quantity, tierID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	// Nothing updated: either the tier is gone or it is sold out
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM ticket_tiers WHERE id = $1)`, tierID); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrInsufficientTickets
+}
+
+func (r *ticketTierRepository) IncrementAvailableTickets(ctx context.Context, tierID string, quantity int) error {
+	query := `
+		UPDATE ticket_tiers
+		SET available_tickets = LEAST(available_tickets + $1, total_tickets), updated_at = NOW()
+		WHERE id = $2`
+	result, err := r.db.ExecContext(ctx, query, quantity, tierID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTicketTierRepository_Integration_CRUD
+// Summary: Tier create/list/update/delete against Postgres
+// Purpose: Verify tiers list cheapest first per event, names are unique per event (ErrDuplicate),
+// sale windows are checked (ErrCheckViolation) and tiers with orders are kept (ErrForeignKeyViolation)
+func TestTicketTierRepository_Integration_CRUD(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewTicketTierRepository(testDB)
+	tickets := NewTicketRepository(testDB)
+
+	event := createTestEvent(t, 100, nil)
+	other := createTestEvent(t, 50, nil)
+	vip := &models.TicketTier{EventID: event.ID, Name: "VIP", Price: 750000, TotalTickets: 20, AvailableTickets: 20}
+	require.NoError(t, repo.Create(ctx, vip))
+	regular := &models.TicketTier{EventID: event.ID, Name: "Regular", Price: 250000, TotalTickets: 80, AvailableTickets: 80}
+	require.NoError(t, repo.Create(ctx, regular))
+	require.NoError(t, repo.Create(ctx, &models.TicketTier{EventID: other.ID, Name: "VIP", Price: 500000, TotalTickets: 50, AvailableTickets: 50}))
+	assert.ErrorIs(t, repo.Create(ctx, &models.TicketTier{EventID: event.ID, Name: "VIP", Price: 1, TotalTickets: 1, AvailableTickets: 1}), ErrDuplicate)
+
+	tiers, err := repo.ListByEventID(ctx, event.ID)
+	require.NoError(t, err)
+	require.Len(t, tiers, 2)
+	assert.Equal(t, regular.ID, tiers[0].ID, "tiers are listed cheapest first")
+
+	tiers, err = repo.ListByEventIDs(ctx, []string{event.ID, other.ID})
+	require.NoError(t, err)
+	assert.Len(t, tiers, 3)
+
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(-time.Hour)
+	vip.SalesStart, vip.SalesEnd = &start, &end
+	assert.ErrorIs(t, repo.Update(ctx, vip), ErrCheckViolation)
+	end = start.Add(30 * 24 * time.Hour)
+	limit := 2
+	vip.MaxPerOrder = &limit
+	require.NoError(t, repo.Update(ctx, vip))
+
+	found, err := repo.FindByID(ctx, vip.ID)
+	require.NoError(t, err)
+	assert.True(t, start.Equal(*found.SalesStart))
+	assert.Equal(t, 2, *found.MaxPerOrder)
+
+	user := createTestUser(t, "tier@example.com")
+	order := &models.TicketOrder{EventID: event.ID, UserID: user.ID, TierID: &vip.ID, Quantity: 1, TotalPrice: 750000}
+	require.NoError(t, tickets.CreateOrder(ctx, order))
+	assert.ErrorIs(t, repo.Delete(ctx, vip.ID), ErrForeignKeyViolation)
+
+	require.NoError(t, repo.Delete(ctx, regular.ID))
+	assert.ErrorIs(t, repo.Delete(ctx, regular.ID), ErrNotFound)
+	_, err = repo.FindByID(ctx, regular.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// TestTicketTierRepository_Integration_AvailableTickets
+// Summary: Guarded tier inventory changes against Postgres
+// Purpose: Verify the decrement never goes negative and reports sold-out vs missing tiers, and
+// released tickets never take the tier above its total
+func TestTicketTierRepository_Integration_AvailableTickets(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewTicketTierRepository(testDB)
+
+	event := createTestEvent(t, 100, nil)
+	tier := &models.TicketTier{EventID: event.ID, Name: "Early bird", Price: 150000, TotalTickets: 3, AvailableTickets: 3}
+	require.NoError(t, repo.Create(ctx, tier))
+
+	require.NoError(t, repo.DecrementAvailableTickets(ctx, tier.ID, 2))
+	assert.ErrorIs(t, repo.DecrementAvailableTickets(ctx, tier.ID, 2), ErrInsufficientTickets)
+	assert.ErrorIs(t, repo.DecrementAvailableTickets(ctx, "00000000-0000-0000-0000-000000000000", 1), ErrNotFound)
+
+	require.NoError(t, repo.IncrementAvailableTickets(ctx, tier.ID, 5))
+	found, err := repo.FindByID(ctx, tier.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, found.AvailableTickets)
+	assert.ErrorIs(t, repo.IncrementAvailableTickets(ctx, "00000000-0000-0000-0000-000000000000", 1), ErrNotFound)
+}
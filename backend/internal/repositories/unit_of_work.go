@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// dbtx is the query surface shared by *sqlx.DB and *sqlx.Tx, so a repository
+// can run either standalone or inside a unit of work.
+type dbtx interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// TxRepositories are repositories bound to one transaction
+type TxRepositories struct {
+	Events          EventRepository
+	Tickets         TicketRepository
+	PaymentWebhooks PaymentWebhookRepository
+	CheckinScans    CheckinScanRepository
+	Tiers           TicketTierRepository
+	Seats           SeatRepository
+	Venues          VenueRepository
+	Users           UserRepository
+}
+
+// UnitOfWork runs several repository calls atomically
+type UnitOfWork interface {
+	// Do runs fn in a transaction. It commits if fn returns nil and rolls
+	// back otherwise; fn's error is returned unchanged.
+	Do(ctx context.Context, fn func(tx TxRepositories) error) error
+}
+
+type unitOfWork struct {
+	db *sqlx.DB
+}
+
+// NewUnitOfWork creates a unit of work backed by db
+func NewUnitOfWork(db *sqlx.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(tx TxRepositories) error) error {
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	repos := TxRepositories{
+		Events:          &eventRepository{db: tx},
+		Tickets:         &ticketRepository{db: tx},
+		PaymentWebhooks: &paymentWebhookRepository{db: tx},
+		CheckinScans:    &checkinScanRepository{db: tx},
+		Tiers:           &ticketTierRepository{db: tx},
+		Seats:           &seatRepository{db: tx},
+		Venues:          &venueRepository{db: tx},
+		Users:           &userRepository{db: tx},
+	}
+	if err := fn(repos); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
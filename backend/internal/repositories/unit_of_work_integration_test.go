@@ -0,0 +1,56 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUnitOfWork_Integration
+// Summary: Commit and rollback of repositories sharing one transaction
+// Purpose: Verify writes through TxRepositories are all kept on success and all discarded on error
+func TestUnitOfWork_Integration(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	uow := NewUnitOfWork(testDB)
+	user := createTestUser(t, "eko@example.com")
+	event := createTestEvent(t, 10, nil)
+
+	purchase := func(tx TxRepositories) error {
+		if err := tx.Events.DecrementAvailableTickets(ctx, event.ID, 2); err != nil {
+			return err
+		}
+		order := &models.TicketOrder{EventID: event.ID, UserID: user.ID, Quantity: 2, TotalPrice: 500000}
+		return tx.Tickets.CreateOrder(ctx, order)
+	}
+
+	errBoom := errors.New("boom")
+	err := uow.Do(ctx, func(tx TxRepositories) error {
+		if err := purchase(tx); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+	assertInventory(t, event.ID, 10, 0)
+
+	require.NoError(t, uow.Do(ctx, purchase))
+	assertInventory(t, event.ID, 8, 1)
+}
+
+func assertInventory(t *testing.T, eventID string, available, orders int) {
+	t.Helper()
+	event, err := NewEventRepository(testDB).FindByID(context.Background(), eventID)
+	require.NoError(t, err)
+	assert.Equal(t, available, event.AvailableTickets)
+
+	var count int
+	require.NoError(t, testDB.Get(&count, `SELECT COUNT(*) FROM ticket_orders WHERE event_id = $1`, eventID))
+	assert.Equal(t, orders, count)
+}
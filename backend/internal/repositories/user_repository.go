@@ -2,7 +2,8 @@ package repositories
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"errors"
 
 	"github.com/baramulti/ticketing-system/backend/internal/models"
 	"github.com/jmoiron/sqlx"
@@ -16,10 +17,18 @@ type UserRepository interface {
 	Update(ctx context.Context, user *models.User) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, limit, offset int) ([]*models.User, error)
+
+	// Role assignments (user_roles)
+	FindRolesByUserID(ctx context.Context, userID string) ([]*models.Role, error)
+	AssignRole(ctx context.Context, userID, roleName string, assignedBy *string) error
+	RevokeRole(ctx context.Context, userID, roleName string) error
+
+	// FindPermissionsByUserID resolves user_roles -> role_permissions -> permissions
+	FindPermissionsByUserID(ctx context.Context, userID string) ([]string, error)
 }
 
 type userRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 // NewUserRepository creates a new user repository instance
@@ -28,35 +37,152 @@ func NewUserRepository(db *sqlx.DB) UserRepository {
 }
 
 func (r *userRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
-	// TODO: implement actual database query
-	return nil, fmt.Errorf("not implemented")
+	var user models.User
+	query := `SELECT id, email, password_hash, is_active, created_at, updated_at FROM users WHERE id = $1`
+	if err := r.db.GetContext(ctx, &user, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
 }
 
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
-	// TODO: implement actual database query
-	return nil, fmt.Errorf("not implemented")
+	var user models.User
+	query := `SELECT id, email, password_hash, is_active, created_at, updated_at FROM users WHERE email = $1`
+	if err := r.db.GetContext(ctx, &user, query, email); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
 }
 
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
-	// TODO: implement user creation
-	// Example: INSERT INTO users (id, email, password_hash, role) VALUES ($1, $2, $3, $4)
-	return fmt.Errorf("not implemented")
+	query := `
+		INSERT INTO users (email, password_hash, is_active)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at`
+	err := r.db.QueryRowxContext(ctx, query, user.Email, user.PasswordHash, user.IsActive).
+		Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	return mapError(err)
 }
 
 func (r *userRepository) Update(ctx context.Context, user *models.User) error {
-	// TODO: implement user update
-	// Example: UPDATE users SET email = $1, updated_at = $2 WHERE id = $3
-	return fmt.Errorf("not implemented")
+	query := `
+		UPDATE users
+		SET email = $1, password_hash = $2, is_active = $3, updated_at = NOW()
+		WHERE id = $4
+		RETURNING updated_at`
+	err := r.db.QueryRowxContext(ctx, query, user.Email, user.PasswordHash, user.IsActive, user.ID).
+		Scan(&user.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return mapError(err)
 }
 
 func (r *userRepository) Delete(ctx context.Context, id string) error {
-	// TODO: implement user deletion
-	// Example: DELETE FROM users WHERE id = $1
-	return fmt.Errorf("not implemented")
+	result, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
-	// TODO: implement user listing with pagination
-	// Example: SELECT * FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2
-	return nil, fmt.Errorf("not implemented")
+	users := []*models.User{}
+	query := `
+		SELECT id, email, password_hash, is_active, created_at, updated_at
+		FROM users
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+	if err := r.db.SelectContext(ctx, &users, query, limit, offset); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) FindRolesByUserID(ctx context.Context, userID string) ([]*models.Role, error) {
+	roles := []*models.Role{}
+	query := `
+		SELECT r.id, r.name, COALESCE(r.description, '') AS description, r.is_active, r.created_at, r.updated_at
+		FROM roles r
+		JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = $1 AND r.is_active = true
+		ORDER BY r.name`
+	if err := r.db.SelectContext(ctx, &roles, query, userID); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// FindPermissionsByUserID returns the distinct permission names granted to a
+// user through their active roles
+func (r *userRepository) FindPermissionsByUserID(ctx context.Context, userID string) ([]string, error) {
+	permissions := []string{}
+	query := `
+		SELECT DISTINCT p.name
+		FROM permissions p
+		JOIN role_permissions rp ON rp.permission_id = p.id
+		JOIN roles r ON r.id = rp.role_id
+		JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = $1 AND r.is_active = true
+		ORDER BY p.name`
+	if err := r.db.SelectContext(ctx, &permissions, query, userID); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// AssignRole grants a role (by name) to a user. Assigning a role the user
+// already holds is a no-op.
+func (r *userRepository) AssignRole(ctx context.Context, userID, roleName string, assignedBy *string) error {
+	var roleID string
+	err := r.db.GetContext(ctx, &roleID, `SELECT id FROM roles WHERE name = $1 AND is_active = true`, roleName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	query := `
+		INSERT INTO user_roles (user_id, role_id, assigned_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, role_id) DO NOTHING`
+	_, err = r.db.ExecContext(ctx, query, userID, roleID, assignedBy)
+	return err
+}
+
+// RevokeRole removes a role (by name) from a user. It returns ErrNotFound if
+// the user did not hold the role.
+func (r *userRepository) RevokeRole(ctx context.Context, userID, roleName string) error {
+	query := `
+		DELETE FROM user_roles ur
+		USING roles r
+		WHERE ur.role_id = r.id AND ur.user_id = $1 AND r.name = $2`
+	result, err := r.db.ExecContext(ctx, query, userID, roleName)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
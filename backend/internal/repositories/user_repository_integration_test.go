@@ -0,0 +1,73 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserRepository_Integration_CRUD
+// Summary: User create/find/update/delete against Postgres
+// Purpose: Verify lookups return ErrNotFound and a duplicate email maps to ErrDuplicate
+func TestUserRepository_Integration_CRUD(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewUserRepository(testDB)
+
+	user := createTestUser(t, "andi@example.com")
+	assert.NotEmpty(t, user.ID)
+	assert.False(t, user.CreatedAt.IsZero())
+
+	found, err := repo.FindByEmail(ctx, "andi@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, found.ID)
+
+	err = repo.Create(ctx, &models.User{Email: "andi@example.com", PasswordHash: "hash", IsActive: true})
+	assert.ErrorIs(t, err, ErrDuplicate)
+
+	user.IsActive = false
+	require.NoError(t, repo.Update(ctx, user))
+	found, err = repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.False(t, found.IsActive)
+
+	require.NoError(t, repo.Delete(ctx, user.ID))
+	_, err = repo.FindByID(ctx, user.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, user.ID), ErrNotFound)
+	assert.ErrorIs(t, repo.Update(ctx, user), ErrNotFound)
+}
+
+// TestUserRepository_Integration_Roles
+// Summary: Role assignment and permission resolution against Postgres
+// Purpose: Verify seeded role permissions resolve through user_roles and revocation removes them
+func TestUserRepository_Integration_Roles(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewUserRepository(testDB)
+	user := createTestUser(t, "budi@example.com")
+
+	require.NoError(t, repo.AssignRole(ctx, user.ID, models.RoleOrganizer, nil))
+	assert.ErrorIs(t, repo.AssignRole(ctx, user.ID, "no_such_role", nil), ErrNotFound)
+
+	roles, err := repo.FindRolesByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, models.RoleOrganizer, roles[0].Name)
+
+	permissions, err := repo.FindPermissionsByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Contains(t, permissions, models.PermEventCreate)
+
+	require.NoError(t, repo.RevokeRole(ctx, user.ID, models.RoleOrganizer))
+	assert.ErrorIs(t, repo.RevokeRole(ctx, user.ID, models.RoleOrganizer), ErrNotFound)
+
+	permissions, err = repo.FindPermissionsByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, permissions)
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// VenueRepository defines data access methods for venues
+type VenueRepository interface {
+	Create(ctx context.Context, venue *models.Venue) error
+	FindByID(ctx context.Context, id string) (*models.Venue, error)
+	// FindByIDForUpdate locks the venue row until the transaction ends, so
+	// its capacity cannot change meanwhile. Only meaningful on a repository
+	// obtained from a UnitOfWork.
+	FindByIDForUpdate(ctx context.Context, id string) (*models.Venue, error)
+	// ListByIDs returns the venues with the given ids; unknown ids are skipped
+	ListByIDs(ctx context.Context, ids []string) ([]*models.Venue, error)
+	// List returns venues by name
+	List(ctx context.Context, limit, offset int) ([]*models.Venue, error)
+	Count(ctx context.Context) (int, error)
+	Update(ctx context.Context, venue *models.Venue) error
+	// Delete removes the venue. It returns ErrForeignKeyViolation while events
+	// still take place there.
+	Delete(ctx context.Context, id string) error
+}
+
+type venueRepository struct {
+	db dbtx
+}
+
+// NewVenueRepository creates a new venue repository instance
+func NewVenueRepository(db *sqlx.DB) VenueRepository {
+	return &venueRepository{db: db}
+}
+
+const venueColumns = `id, name, address, time_zone, capacity, latitude, longitude, created_by, created_at, updated_at`
+
+func (r *venueRepository) Create(ctx context.Context, venue *models.Venue) error {
+	query := `
+		INSERT INTO venues (name, address, time_zone, capacity, latitude, longitude, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		venue.Name, venue.Address, venue.TimeZone, venue.Capacity, venue.Latitude, venue.Longitude, venue.CreatedBy,
+	).Scan(&venue.ID, &venue.CreatedAt, &venue.UpdatedAt)
+	return mapError(err)
+}
+
+func (r *venueRepository) FindByID(ctx context.Context, id string) (*models.Venue, error) {
+	return r.findByID(ctx, `SELECT `+venueColumns+` FROM venues WHERE id = $1`, id)
+}
+
+func (r *venueRepository) FindByIDForUpdate(ctx context.Context, id string) (*models.Venue, error) {
+	return r.findByID(ctx, `SELECT `+venueColumns+` FROM venues WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *venueRepository) findByID(ctx context.Context, query, id string) (*models.Venue, error) {
+	var venue models.Venue
+	if err := r.db.GetContext(ctx, &venue, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *venueRepository) ListByIDs(ctx context.Context, ids []string) ([]*models.Venue, error) {
+	venues := []*models.Venue{}
+	query := `SELECT ` + venueColumns + ` FROM venues WHERE id = ANY($1)`
+	if err := r.db.SelectContext(ctx, &venues, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+func (r *venueRepository) List(ctx context.Context, limit, offset int) ([]*models.Venue, error) {
+	venues := []*models.Venue{}
+	query := `SELECT ` + venueColumns + ` FROM venues ORDER BY name, id LIMIT $1 OFFSET $2`
+	if err := r.db.SelectContext(ctx, &venues, query, limit, offset); err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+func (r *venueRepository) Count(ctx context.Context) (int, error) {
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM venues`); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// Update overwrites the editable fields. Ownership (created_by) is never
+// changed here.
+func (r *venueRepository) Update(ctx context.Context, venue *models.Venue) error {
+	query := `
+		UPDATE venues
+		SET name = $1, address = $2, time_zone = $3, capacity = $4, latitude = $5, longitude = $6, updated_at = NOW()
+		WHERE id = $7
+		RETURNING updated_at`
+	err := r.db.QueryRowxContext(ctx, query,
+		venue.Name, venue.Address, venue.TimeZone, venue.Capacity, venue.Latitude, venue.Longitude, venue.ID,
+	).Scan(&venue.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return mapError(err)
+}
+
+func (r *venueRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM venues WHERE id = $1`, id)
+	if err != nil {
+		return mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVenueRepository_Integration_CRUD
+// Summary: Venue create/find/list/update/delete against Postgres
+// Purpose: Verify round-tripped coordinates, name ordering, the coordinates check (ErrCheckViolation)
+// and that venues with events are kept (ErrForeignKeyViolation)
+func TestVenueRepository_Integration_CRUD(t *testing.T) {
+	resetDB(t)
+	ctx := context.Background()
+	repo := NewVenueRepository(testDB)
+
+	owner := createTestUser(t, "venues@example.com")
+	lat, lng := -6.2146, 106.8019
+	jcc := &models.Venue{
+		Name: "Jakarta Convention Center", Address: "Jl. Gatot Subroto, Senayan", TimeZone: "Asia/Jakarta",
+		Capacity: 5000, Latitude: &lat, Longitude: &lng, CreatedBy: &owner.ID,
+	}
+	require.NoError(t, repo.Create(ctx, jcc))
+	gbk := createTestVenue(t, "Gelora Bung Karno", 77000)
+
+	found, err := repo.FindByID(ctx, jcc.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Jakarta", found.TimeZone)
+	assert.InDelta(t, lat, *found.Latitude, 1e-9)
+	assert.Equal(t, owner.ID, *found.CreatedBy)
+
+	venues, err := repo.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, venues, 2)
+	assert.Equal(t, gbk.ID, venues[0].ID, "venues are listed by name")
+
+	venues, err = repo.ListByIDs(ctx, []string{jcc.ID, "00000000-0000-0000-0000-000000000000"})
+	require.NoError(t, err)
+	assert.Len(t, venues, 1)
+
+	found.Longitude = nil
+	assert.ErrorIs(t, repo.Update(ctx, found), ErrCheckViolation)
+	found.Latitude = nil
+	found.Capacity = 4500
+	require.NoError(t, repo.Update(ctx, found))
+
+	event := createTestEvent(t, 100, nil)
+	event.VenueID = jcc.ID
+	require.NoError(t, NewEventRepository(testDB).Update(ctx, event))
+	assert.ErrorIs(t, repo.Delete(ctx, jcc.ID), ErrForeignKeyViolation)
+
+	require.NoError(t, repo.Delete(ctx, gbk.ID))
+	assert.ErrorIs(t, repo.Delete(ctx, gbk.ID), ErrNotFound)
+	_, err = repo.FindByID(ctx, gbk.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -5,11 +5,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func setupAuthRoutes(rg *gin.RouterGroup, h *handlers.AuthHandler) {
+func setupAuthRoutes(rg *gin.RouterGroup, h *handlers.AuthHandler, authMW gin.HandlerFunc) {
 	auth := rg.Group("/auth")
 	{
 		auth.POST("/login", h.Login)
 		auth.POST("/register", h.Register)
-		// TODO: add /logout, /refresh-token endpoints
+		auth.POST("/refresh", h.Refresh)
+		auth.POST("/logout", authMW, h.Logout)
 	}
-}
\ No newline at end of file
+}
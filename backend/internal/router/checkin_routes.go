@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/baramulti/ticketing-system/backend/internal/handlers"
+	"github.com/baramulti/ticketing-system/backend/internal/middleware"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func setupCheckinRoutes(rg *gin.RouterGroup, h *handlers.CheckinHandler, authMW gin.HandlerFunc, permSvc services.PermissionService) {
+	// Gate scanners (validator role)
+	validate := middleware.RequirePermission(permSvc, models.PermTicketValidate)
+	rg.POST("/checkin", authMW, validate, h.CheckIn)
+
+	// Offline scanning: download a snapshot before doors open, upload scans after
+	checkin := rg.Group("/checkin")
+	checkin.Use(authMW, validate)
+	{
+		checkin.GET("/events/:id/snapshot", h.Snapshot)
+		checkin.POST("/sync", h.Sync)
+	}
+}
@@ -1,23 +1,39 @@
 package router
 
 import (
-	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/handlers"
 	"github.com/baramulti/ticketing-system/backend/internal/middleware"
 	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
-func setupEventRoutes(rg *gin.RouterGroup, h *handlers.EventHandler, jwtCfg config.JWTConfig) {
+func setupEventRoutes(rg *gin.RouterGroup, h *handlers.EventHandler, authMW, idempotencyMW gin.HandlerFunc, permSvc services.PermissionService) {
 	events := rg.Group("/events")
 	{
 		// Public routes
 		events.GET("", h.List)
 		events.GET("/:id", h.GetByID)
 
-		// Protected routes (admin only)
-		events.POST("", middleware.AuthMiddleware(jwtCfg), middleware.RequireRole(models.RoleAdmin), h.Create)
-		events.PUT("/:id", middleware.AuthMiddleware(jwtCfg), middleware.RequireRole(models.RoleAdmin), h.Update)
-		events.DELETE("/:id", middleware.AuthMiddleware(jwtCfg), middleware.RequireRole(models.RoleAdmin), h.Delete)
+		// Protected routes (event management: admins, and organizers for their own events).
+		// Writes honour an Idempotency-Key so clients can retry them safely.
+		events.POST("", authMW, middleware.RequirePermission(permSvc, models.PermEventCreate), idempotencyMW, h.Create)
+		events.PATCH("/:id", authMW, middleware.RequirePermission(permSvc, models.PermEventUpdate), idempotencyMW, h.Update)
+		events.PUT("/:id", authMW, middleware.RequirePermission(permSvc, models.PermEventUpdate), idempotencyMW, h.Update) // same partial update as PATCH
+		events.DELETE("/:id", authMW, middleware.RequirePermission(permSvc, models.PermEventDelete), idempotencyMW, h.Delete)
+
+		// Sales are visible to whoever manages the event; ownership is checked in the service
+		events.GET("/:id/sales", authMW, middleware.RequirePermission(permSvc, models.PermEventUpdate), h.GetSales)
+
+		// Ticket tiers are part of the event and managed with it; the public event responses list them
+		events.POST("/:id/tiers", authMW, middleware.RequirePermission(permSvc, models.PermEventUpdate), idempotencyMW, h.CreateTier)
+		events.PATCH("/:id/tiers/:tierId", authMW, middleware.RequirePermission(permSvc, models.PermEventUpdate), idempotencyMW, h.UpdateTier)
+		events.DELETE("/:id/tiers/:tierId", authMW, middleware.RequirePermission(permSvc, models.PermEventUpdate), idempotencyMW, h.DeleteTier)
+
+		// Reserved seating: the event's ticket counts follow the seat map
+		events.PUT("/:id/seat-map", authMW, middleware.RequirePermission(permSvc, models.PermEventUpdate), idempotencyMW, h.AssignSeatMap)
 	}
-}
\ No newline at end of file
+
+	// Public: the events held at a venue
+	rg.GET("/venues/:id/events", h.ListByVenue)
+}
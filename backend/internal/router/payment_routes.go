@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/baramulti/ticketing-system/backend/internal/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func setupPaymentRoutes(rg *gin.RouterGroup, h *handlers.PaymentHandler) {
+	payments := rg.Group("/payments")
+	{
+		// Called by the payment gateway; authenticated by HMAC signature
+		payments.POST("/webhook", h.Webhook)
+	}
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/baramulti/ticketing-system/backend/internal/handlers"
+	"github.com/baramulti/ticketing-system/backend/internal/middleware"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func setupRoleRoutes(rg *gin.RouterGroup, h *handlers.RoleHandler, authMW gin.HandlerFunc, permSvc services.PermissionService) {
+	manage := middleware.RequirePermission(permSvc, models.PermRoleManage)
+
+	roles := rg.Group("/roles")
+	roles.Use(authMW, manage) // Role administration only
+	{
+		roles.GET("", h.ListRoles)
+		roles.POST("", h.CreateRole)
+		roles.POST("/:id/permissions", h.AttachPermission)
+		roles.DELETE("/:id/permissions/:permission", h.DetachPermission)
+	}
+
+	permissions := rg.Group("/permissions")
+	permissions.Use(authMW, manage)
+	{
+		permissions.GET("", h.ListPermissions)
+		permissions.POST("", h.CreatePermission)
+	}
+}
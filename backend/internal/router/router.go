@@ -1,20 +1,33 @@
 package router
 
 import (
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
 	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/handlers"
 	"github.com/baramulti/ticketing-system/backend/internal/middleware"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	customvalidator "github.com/baramulti/ticketing-system/backend/pkg/validator"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/rs/zerolog"
 )
 
 type RouterConfig struct {
-	Config        *config.Config
-	Logger        zerolog.Logger
-	AuthHandler   *handlers.AuthHandler
-	EventHandler  *handlers.EventHandler
-	TicketHandler *handlers.TicketHandler
-	UserHandler   *handlers.UserHandler
+	Config            *config.Config
+	Logger            zerolog.Logger
+	AuthService       services.AuthService
+	PermissionService services.PermissionService
+	IdempotencyStore  cache.IdempotencyStore
+	AuthHandler       *handlers.AuthHandler
+	EventHandler      *handlers.EventHandler
+	TicketHandler     *handlers.TicketHandler
+	UserHandler       *handlers.UserHandler
+	RoleHandler       *handlers.RoleHandler
+	PaymentHandler    *handlers.PaymentHandler
+	CheckinHandler    *handlers.CheckinHandler
+	SeatHandler       *handlers.SeatHandler
+	VenueHandler      *handlers.VenueHandler
 }
 
 func Setup(cfg *RouterConfig) *gin.Engine {
@@ -22,6 +35,13 @@ func Setup(cfg *RouterConfig) *gin.Engine {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Custom validation tags (permission_name, role_name) for request binding
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		if err := customvalidator.RegisterCustom(v); err != nil {
+			cfg.Logger.Fatal().Err(err).Msg("failed to register custom validators")
+		}
+	}
+
 	r := gin.New()
 
 	// Global middleware
@@ -34,13 +54,26 @@ func Setup(cfg *RouterConfig) *gin.Engine {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// Public keys for verifying access tokens
+	r.GET("/.well-known/jwks.json", cfg.AuthHandler.JWKS)
+	// Public keys for verifying ticket QR passes
+	r.GET("/.well-known/ticket-pass-keys.json", cfg.TicketHandler.PassKeys)
+
+	authMW := middleware.AuthMiddleware(cfg.AuthService)
+	idempotencyMW := middleware.Idempotency(cfg.IdempotencyStore, cfg.Config.Idempotency, cfg.Logger)
+
 	// API v1 routes
 	api := r.Group("/api/v1")
 	{
-		setupAuthRoutes(api, cfg.AuthHandler)
-		setupEventRoutes(api, cfg.EventHandler, cfg.Config.JWT)
-		setupTicketRoutes(api, cfg.TicketHandler, cfg.Config.JWT)
-		setupUserRoutes(api, cfg.UserHandler, cfg.Config.JWT)
+		setupAuthRoutes(api, cfg.AuthHandler, authMW)
+		setupEventRoutes(api, cfg.EventHandler, authMW, idempotencyMW, cfg.PermissionService)
+		setupTicketRoutes(api, cfg.TicketHandler, authMW, idempotencyMW, cfg.PermissionService)
+		setupUserRoutes(api, cfg.UserHandler, authMW, cfg.PermissionService)
+		setupRoleRoutes(api, cfg.RoleHandler, authMW, cfg.PermissionService)
+		setupPaymentRoutes(api, cfg.PaymentHandler)
+		setupCheckinRoutes(api, cfg.CheckinHandler, authMW, cfg.PermissionService)
+		setupSeatRoutes(api, cfg.SeatHandler, authMW, idempotencyMW, cfg.PermissionService)
+		setupVenueRoutes(api, cfg.VenueHandler, authMW, idempotencyMW, cfg.PermissionService)
 	}
 
 	return r
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/baramulti/ticketing-system/backend/internal/handlers"
+	"github.com/baramulti/ticketing-system/backend/internal/middleware"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func setupSeatRoutes(rg *gin.RouterGroup, h *handlers.SeatHandler, authMW, idempotencyMW gin.HandlerFunc, permSvc services.PermissionService) {
+	seatMaps := rg.Group("/seat-maps")
+	{
+		// Public: layouts carry nothing private
+		seatMaps.GET("/:id", h.GetSeatMap)
+
+		// Whoever can create events can describe the venues they use
+		seatMaps.POST("", authMW, middleware.RequirePermission(permSvc, models.PermEventCreate), idempotencyMW, h.CreateSeatMap)
+	}
+
+	// Public seat availability, cheap enough to poll
+	rg.GET("/events/:id/seats", h.EventSeats)
+}
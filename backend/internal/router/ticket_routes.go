@@ -1,18 +1,24 @@
 package router
 
 import (
-	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/handlers"
 	"github.com/baramulti/ticketing-system/backend/internal/middleware"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
-func setupTicketRoutes(rg *gin.RouterGroup, h *handlers.TicketHandler, jwtCfg config.JWTConfig) {
+func setupTicketRoutes(rg *gin.RouterGroup, h *handlers.TicketHandler, authMW, idempotencyMW gin.HandlerFunc, permSvc services.PermissionService) {
 	tickets := rg.Group("/tickets")
-	tickets.Use(middleware.AuthMiddleware(jwtCfg)) // All ticket routes require auth
+	tickets.Use(authMW) // All ticket routes require auth
 	{
-		tickets.POST("/purchase", h.Purchase)
-		tickets.GET("/my-orders", h.GetUserOrders)
-		// TODO: add /orders/:id for order details
+		tickets.POST("/purchase", middleware.RequirePermission(permSvc, models.PermTicketPurchase), idempotencyMW, h.Purchase)
+		tickets.GET("/my-orders", middleware.RequirePermission(permSvc, models.PermTicketRead), h.GetUserOrders)
+		tickets.GET("/:id/qr", middleware.RequirePermission(permSvc, models.PermTicketRead), h.QR)
+		tickets.POST("/:id/transfer", middleware.RequirePermission(permSvc, models.PermTicketPurchase), idempotencyMW, h.Transfer)
+		tickets.GET("/:id/transfers", middleware.RequirePermission(permSvc, models.PermTicketRead), h.TransferChain)
+		tickets.GET("/orders/:id", middleware.RequirePermission(permSvc, models.PermTicketRead), h.GetOrder)
+		tickets.POST("/orders/:id/cancel", middleware.RequirePermission(permSvc, models.PermTicketPurchase), idempotencyMW, h.CancelOrder)
+		tickets.POST("/orders/:id/refund", middleware.RequirePermission(permSvc, models.PermTicketRefund), idempotencyMW, h.RefundOrder)
 	}
-}
\ No newline at end of file
+}
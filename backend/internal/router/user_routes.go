@@ -1,22 +1,26 @@
 package router
 
 import (
-	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/handlers"
 	"github.com/baramulti/ticketing-system/backend/internal/middleware"
 	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
-func setupUserRoutes(rg *gin.RouterGroup, h *handlers.UserHandler, jwtCfg config.JWTConfig) {
+func setupUserRoutes(rg *gin.RouterGroup, h *handlers.UserHandler, authMW gin.HandlerFunc, permSvc services.PermissionService) {
 	users := rg.Group("/users")
-	users.Use(middleware.AuthMiddleware(jwtCfg)) // All user routes require auth
+	users.Use(authMW) // All user routes require auth
 	{
 		users.GET("/me", h.GetMe)
 		users.PUT("/me", h.Update)
 
-		// Admin only routes
-		users.POST("", middleware.RequireRole(models.RoleAdmin), h.Create)
-		users.DELETE("/:id", middleware.RequireRole(models.RoleAdmin), h.Delete)
+		// User management (admin permissions by default)
+		users.POST("", middleware.RequirePermission(permSvc, models.PermUserCreate), h.Create)
+		users.DELETE("/:id", middleware.RequirePermission(permSvc, models.PermUserDelete), h.Delete)
+
+		// Role assignments
+		users.POST("/:id/roles", middleware.RequirePermission(permSvc, models.PermRoleManage), h.AssignRole)
+		users.DELETE("/:id/roles/:role", middleware.RequirePermission(permSvc, models.PermRoleManage), h.RevokeRole)
 	}
-}
\ No newline at end of file
+}
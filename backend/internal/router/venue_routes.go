@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/baramulti/ticketing-system/backend/internal/handlers"
+	"github.com/baramulti/ticketing-system/backend/internal/middleware"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+func setupVenueRoutes(rg *gin.RouterGroup, h *handlers.VenueHandler, authMW, idempotencyMW gin.HandlerFunc, permSvc services.PermissionService) {
+	venues := rg.Group("/venues")
+	{
+		// Public routes
+		venues.GET("", h.List)
+		venues.GET("/:id", h.GetByID)
+
+		// Protected routes (admins, and organizers for the venues they created)
+		venues.POST("", authMW, middleware.RequirePermission(permSvc, models.PermVenueCreate), idempotencyMW, h.Create)
+		venues.PATCH("/:id", authMW, middleware.RequirePermission(permSvc, models.PermVenueUpdate), idempotencyMW, h.Update)
+		venues.DELETE("/:id", authMW, middleware.RequirePermission(permSvc, models.PermVenueDelete), idempotencyMW, h.Delete)
+	}
+}
@@ -0,0 +1,26 @@
+package services
+
+import "github.com/baramulti/ticketing-system/backend/internal/models"
+
+// Actor is the authenticated user performing an operation. Services use it
+// for ownership checks that route-level permissions cannot express. Its roles
+// are the ones in user_roles when the request was authenticated, not the
+// possibly stale ones in the access token.
+type Actor struct {
+	UserID string
+	Roles  []string
+}
+
+func (a Actor) HasRole(role string) bool {
+	for _, r := range a.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the actor may override ownership checks
+func (a Actor) IsAdmin() bool {
+	return a.HasRole(models.RoleAdmin)
+}
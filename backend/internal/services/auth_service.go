@@ -2,9 +2,16 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
 	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/dto"
 	"github.com/baramulti/ticketing-system/backend/internal/models"
@@ -12,120 +19,330 @@ import (
 	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService interface {
 	Login(ctx context.Context, req *dto.LoginRequest) (*dto.AuthResponse, error)
 	Register(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error)
+	Refresh(ctx context.Context, req *dto.RefreshRequest) (*dto.AuthResponse, error)
+	Logout(ctx context.Context, accessToken string, req *dto.LogoutRequest) error
 	ValidateToken(ctx context.Context, token string) (*models.User, error)
+	JWKS() *jwtutil.JWKS
 }
 
 type authService struct {
-	userRepo  repositories.UserRepository
-	jwtConfig config.JWTConfig
-	log       zerolog.Logger
+	userRepo         repositories.UserRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	uow              repositories.UnitOfWork
+	revocations      cache.RevocationStore
+	keys             *jwtutil.KeySet
+	jwtConfig        config.JWTConfig
+	log              zerolog.Logger
 }
 
-func NewAuthService(userRepo repositories.UserRepository, jwtConfig config.JWTConfig, log zerolog.Logger) AuthService {
+func NewAuthService(
+	userRepo repositories.UserRepository,
+	refreshTokenRepo repositories.RefreshTokenRepository,
+	uow repositories.UnitOfWork,
+	revocations cache.RevocationStore,
+	keys *jwtutil.KeySet,
+	jwtConfig config.JWTConfig,
+	log zerolog.Logger,
+) AuthService {
 	return &authService{
-		userRepo:  userRepo,
-		jwtConfig: jwtConfig,
-		log:       log,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		uow:              uow,
+		revocations:      revocations,
+		keys:             keys,
+		jwtConfig:        jwtConfig,
+		log:              log,
 	}
 }
 
 func (s *authService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.AuthResponse, error) {
-	// TODO: Production flow:
-	// 1. Find user by email: user, err := s.userRepo.FindByEmail(ctx, req.Email)
-	// 2. Compare password: bcrypt.CompareHashAndPassword(user.PasswordHash, req.Password)
-	// 3. Load user roles from database
+	email := normalizeEmail(req.Email)
 
-	s.log.Info().Str("email", req.Email).Msg("login attempt (stub)")
-
-	// STUB: Mock user for development
-	mockUser := &models.User{
-		ID:        uuid.New().String(),
-		Email:     req.Email,
-		IsActive:  true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			// Burn a hash comparison so unknown emails take as long as wrong passwords
+			_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(req.Password))
+			return nil, ErrInvalidCredentials
+		}
+		s.log.Error().Err(err).Msg("failed to find user by email")
+		return nil, fmt.Errorf("failed to login")
 	}
 
-	// Mock role - check if admin email
-	roles := []string{models.RoleUser}
-	if req.Email == "admin@example.com" {
-		roles = []string{models.RoleAdmin}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		s.log.Info().Str("user_id", user.ID).Msg("login failed: wrong password")
+		return nil, ErrInvalidCredentials
 	}
 
-	// Generate real JWT token
-	expiry, _ := time.ParseDuration(s.jwtConfig.Expiry)
-	token, err := jwtutil.GenerateToken(mockUser.ID, mockUser.Email, roles, s.jwtConfig.Secret, expiry)
-	if err != nil {
-		s.log.Error().Err(err).Msg("failed to generate token")
-		return nil, fmt.Errorf("failed to generate token")
+	if !user.IsActive {
+		return nil, ErrAccountDisabled
 	}
 
-	return &dto.AuthResponse{
-		Token: token,
-		User:  mockUser,
-	}, nil
+	return s.issueTokens(ctx, user, uuid.New().String())
 }
 
 func (s *authService) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error) {
-	// TODO: Production flow:
-	// 1. Check email uniqueness
-	// 2. Hash password with bcrypt
-	// 3. Create user in database
-	// 4. Assign default role
+	email := normalizeEmail(req.Email)
+
+	// Registration always grants the default role; anything else needs an admin
+	if req.Role != "" && req.Role != models.RoleUser {
+		s.log.Warn().Str("email", email).Str("role", req.Role).Msg("registration with elevated role refused")
+		return nil, ErrRoleNotSelfAssignable
+	}
+
+	// Check email uniqueness
+	_, err := s.userRepo.FindByEmail(ctx, email)
+	if err == nil {
+		return nil, ErrEmailAlreadyRegistered
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		s.log.Error().Err(err).Msg("failed to check email uniqueness")
+		return nil, fmt.Errorf("failed to register")
+	}
 
-	s.log.Info().Str("email", req.Email).Msg("registration attempt (stub)")
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to hash password")
+		return nil, fmt.Errorf("failed to register")
+	}
 
-	// STUB: Mock new user
 	newUser := &models.User{
-		ID:        uuid.New().String(),
-		Email:     req.Email,
-		IsActive:  true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Email:        email,
+		PasswordHash: string(hash),
+		IsActive:     true,
+	}
+	// The user and its role are stored together, so a failed role insert
+	// does not leave a role-less account holding the email
+	err = s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		if err := tx.Users.Create(ctx, newUser); err != nil {
+			return err
+		}
+		return tx.Users.AssignRole(ctx, newUser.ID, models.RoleUser, nil)
+	})
+	if err != nil {
+		// Lost a race with a concurrent registration for the same email
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, ErrEmailAlreadyRegistered
+		}
+		s.log.Error().Err(err).Str("email", email).Msg("failed to create user")
+		return nil, fmt.Errorf("failed to register")
 	}
 
-	// Default role is "user"
-	role := models.RoleUser
-	if req.Role != "" {
-		role = req.Role
+	s.log.Info().Str("user_id", newUser.ID).Msg("user registered")
+
+	return s.issueTokens(ctx, newUser, uuid.New().String())
+}
+
+// Refresh exchanges a refresh token for a new access/refresh token pair.
+// Every refresh rotates the token; presenting a token that was already
+// rotated revokes its whole family, since it means the token leaked.
+func (s *authService) Refresh(ctx context.Context, req *dto.RefreshRequest) (*dto.AuthResponse, error) {
+	stored, err := s.refreshTokenRepo.FindByHash(ctx, hashRefreshToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		s.log.Error().Err(err).Msg("failed to find refresh token")
+		return nil, fmt.Errorf("failed to refresh token")
+	}
+
+	if stored.RevokedAt != nil {
+		s.revokeFamily(ctx, stored, "refresh token reuse detected")
+		return nil, ErrRefreshTokenReused
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		s.log.Error().Err(err).Str("user_id", stored.UserID).Msg("failed to load user for refresh")
+		return nil, fmt.Errorf("failed to refresh token")
+	}
+
+	if !user.IsActive {
+		s.revokeFamily(ctx, stored, "refresh for inactive user")
+		return nil, ErrAccountDisabled
+	}
+
+	plain, next := s.newRefreshToken(user.ID, stored.FamilyID)
+	if err := s.refreshTokenRepo.Rotate(ctx, stored.ID, next); err != nil {
+		if errors.Is(err, repositories.ErrConflict) {
+			// Lost a race against another refresh with the same token
+			s.revokeFamily(ctx, stored, "concurrent refresh token reuse detected")
+			return nil, ErrRefreshTokenReused
+		}
+		s.log.Error().Err(err).Msg("failed to rotate refresh token")
+		return nil, fmt.Errorf("failed to refresh token")
+	}
+
+	return s.issueAccessToken(ctx, user, plain)
+}
+
+// Logout revokes the presented access token until it expires and, when given,
+// the refresh token family it belongs to.
+func (s *authService) Logout(ctx context.Context, accessToken string, req *dto.LogoutRequest) error {
+	claims, err := s.keys.ValidateToken(accessToken)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if err := s.revocations.Revoke(ctx, claims.ID, ttl); err != nil {
+		s.log.Error().Err(err).Str("user_id", claims.UserID).Msg("failed to revoke access token")
+		return fmt.Errorf("failed to logout")
+	}
+
+	if req != nil && req.RefreshToken != "" {
+		stored, err := s.refreshTokenRepo.FindByHash(ctx, hashRefreshToken(req.RefreshToken))
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			s.log.Error().Err(err).Msg("failed to find refresh token")
+			return fmt.Errorf("failed to logout")
+		}
+		// Ignore refresh tokens that belong to someone else
+		if stored != nil && stored.UserID == claims.UserID {
+			if err := s.refreshTokenRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+				s.log.Error().Err(err).Str("family_id", stored.FamilyID).Msg("failed to revoke refresh token family")
+				return fmt.Errorf("failed to logout")
+			}
+		}
+	}
+
+	s.log.Info().Str("user_id", claims.UserID).Msg("user logged out")
+	return nil
+}
+
+// ValidateToken checks the JWT signature and expiry, rejects revoked tokens and
+// tokens of users that were deactivated, and returns the user with its current
+// roles. The roles come from user_roles rather than the token's claims, so a
+// revoked role stops counting before the token expires.
+func (s *authService) ValidateToken(ctx context.Context, token string) (*models.User, error) {
+	claims, err := s.keys.ValidateToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	revoked, err := s.revocations.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		// Fail closed: a revocation store outage must not let revoked tokens through
+		s.log.Error().Err(err).Msg("failed to check token revocation")
+		return nil, fmt.Errorf("failed to validate token")
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrInvalidToken
+		}
+		s.log.Error().Err(err).Str("user_id", claims.UserID).Msg("failed to load user for token")
+		return nil, fmt.Errorf("failed to validate token")
+	}
+	if !user.IsActive {
+		return nil, ErrAccountDisabled
+	}
+
+	user.Roles, err = s.userRepo.FindRolesByUserID(ctx, user.ID)
+	if err != nil {
+		s.log.Error().Err(err).Str("user_id", user.ID).Msg("failed to load user roles for token")
+		return nil, fmt.Errorf("failed to validate token")
+	}
+
+	return user, nil
+}
+
+// JWKS returns the public keys that verify access tokens
+func (s *authService) JWKS() *jwtutil.JWKS {
+	return s.keys.JWKS()
+}
+
+// issueTokens starts (or continues) a refresh token family and issues an access token
+func (s *authService) issueTokens(ctx context.Context, user *models.User, familyID string) (*dto.AuthResponse, error) {
+	plain, refreshToken := s.newRefreshToken(user.ID, familyID)
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		s.log.Error().Err(err).Str("user_id", user.ID).Msg("failed to store refresh token")
+		return nil, fmt.Errorf("failed to generate token")
+	}
+
+	return s.issueAccessToken(ctx, user, plain)
+}
+
+// issueAccessToken loads the user's roles from user_roles and signs a JWT carrying them
+func (s *authService) issueAccessToken(ctx context.Context, user *models.User, refreshToken string) (*dto.AuthResponse, error) {
+	roles, err := s.userRepo.FindRolesByUserID(ctx, user.ID)
+	if err != nil {
+		s.log.Error().Err(err).Str("user_id", user.ID).Msg("failed to load user roles")
+		return nil, fmt.Errorf("failed to load user roles")
+	}
+	user.Roles = roles
+
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
 	}
 
-	// Generate JWT
 	expiry, _ := time.ParseDuration(s.jwtConfig.Expiry)
-	token, err := jwtutil.GenerateToken(newUser.ID, newUser.Email, []string{role}, s.jwtConfig.Secret, expiry)
+	token, err := s.keys.GenerateToken(user.ID, user.Email, roleNames, expiry)
 	if err != nil {
+		s.log.Error().Err(err).Msg("failed to generate token")
 		return nil, fmt.Errorf("failed to generate token")
 	}
 
 	return &dto.AuthResponse{
-		Token: token,
-		User:  newUser,
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(expiry.Seconds()),
+		User:         user,
 	}, nil
 }
 
-func (s *authService) ValidateToken(ctx context.Context, token string) (*models.User, error) {
-	// Validate JWT signature and expiry
-	claims, err := jwtutil.ValidateToken(token, s.jwtConfig.Secret)
-	if err != nil {
-		return nil, fmt.Errorf("invalid token: %w", err)
+// newRefreshToken generates an opaque refresh token. Only the hash is persisted.
+func (s *authService) newRefreshToken(userID, familyID string) (string, *models.RefreshToken) {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf) // crypto/rand.Read never returns an error
+
+	plain := base64.RawURLEncoding.EncodeToString(buf)
+	expiry, _ := time.ParseDuration(s.jwtConfig.RefreshExpiry)
+
+	return plain, &models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashRefreshToken(plain),
+		ExpiresAt: time.Now().Add(expiry),
 	}
+}
 
-	// TODO: Fetch user from database to check if still active
-	// user, err := s.userRepo.FindByID(ctx, claims.UserID)
+func (s *authService) revokeFamily(ctx context.Context, token *models.RefreshToken, reason string) {
+	s.log.Warn().
+		Str("user_id", token.UserID).
+		Str("family_id", token.FamilyID).
+		Msg(reason + ", revoking token family")
 
-	// STUB: Return mock user from claims
-	user := &models.User{
-		ID:        claims.UserID,
-		Email:     claims.Email,
-		IsActive:  true,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		s.log.Error().Err(err).Str("family_id", token.FamilyID).Msg("failed to revoke refresh token family")
 	}
+}
 
-	return user, nil
-}
\ No newline at end of file
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// dummyPasswordHash is a valid bcrypt hash used to equalize login timing for unknown emails
+const dummyPasswordHash = "$2a$10$ns32Mq8GcoZ7.Zke.QBkgOVvBmGebYA2mN2LQmo14rIe7rnznmn6C"
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
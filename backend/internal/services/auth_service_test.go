@@ -2,62 +2,130 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
 	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/dto"
 	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
 	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
 	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// hashPassword creates a low-cost bcrypt hash for test fixtures
+func hashPassword(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return string(hash)
+}
+
 // TestAuthService_Login
-// Summary: Tests the Login method with different user scenarios
-// Purpose: Validate JWT token generation and user role assignment in stub mode
+// Summary: Tests the Login method against stored credentials and roles
+// Purpose: Validate password checking, stable user IDs and role loading into JWT claims
 func TestAuthService_Login(t *testing.T) {
 	tests := []struct {
 		name        string
 		email       string
 		password    string
-		expectedErr bool
+		storedUser  *models.User
+		findErr     error
+		roles       []*models.Role
+		expectedErr error
 		checkRole   string
 	}{
 		{
-			name:        "normal user login",
-			email:       "user@example.com",
-			password:    "password123",
-			expectedErr: false,
-			checkRole:   models.RoleUser,
+			name:     "normal user login",
+			email:    "user@example.com",
+			password: "password123",
+			storedUser: &models.User{
+				ID:           "user-001",
+				Email:        "user@example.com",
+				PasswordHash: hashPassword(t, "password123"),
+				IsActive:     true,
+			},
+			roles:     []*models.Role{{Name: models.RoleUser}},
+			checkRole: models.RoleUser,
+		},
+		{
+			name:     "admin role loaded from database",
+			email:    "Ops@Example.com",
+			password: "admin123",
+			storedUser: &models.User{
+				ID:           "user-002",
+				Email:        "ops@example.com",
+				PasswordHash: hashPassword(t, "admin123"),
+				IsActive:     true,
+			},
+			roles:     []*models.Role{{Name: models.RoleAdmin}, {Name: models.RoleUser}},
+			checkRole: models.RoleAdmin,
+		},
+		{
+			name:     "wrong password",
+			email:    "user@example.com",
+			password: "not-the-password",
+			storedUser: &models.User{
+				ID:           "user-001",
+				Email:        "user@example.com",
+				PasswordHash: hashPassword(t, "password123"),
+				IsActive:     true,
+			},
+			expectedErr: ErrInvalidCredentials,
 		},
 		{
-			name:        "admin user login",
-			email:       "admin@example.com",
-			password:    "admin123",
-			expectedErr: false,
-			checkRole:   models.RoleAdmin,
+			name:        "unknown email",
+			email:       "nobody@example.com",
+			password:    "password123",
+			findErr:     repositories.ErrNotFound,
+			expectedErr: ErrInvalidCredentials,
 		},
 		{
-			name:        "another regular user",
-			email:       "test@example.com",
-			password:    "test123",
-			expectedErr: false,
-			checkRole:   models.RoleUser,
+			name:     "inactive account",
+			email:    "disabled@example.com",
+			password: "password123",
+			storedUser: &models.User{
+				ID:           "user-003",
+				Email:        "disabled@example.com",
+				PasswordHash: hashPassword(t, "password123"),
+				IsActive:     false,
+			},
+			expectedErr: ErrAccountDisabled,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUserRepo := mocks.NewUserRepository(t)
+			mockRefreshRepo := mocks.NewRefreshTokenRepository(t)
+			uow := mocks.NewUnitOfWork(t)
 			logger := zerolog.Nop()
 			jwtConfig := config.JWTConfig{
 				Secret: "test-secret-key",
 				Expiry: "24h",
 			}
 
-			service := NewAuthService(mockUserRepo, jwtConfig, logger)
+			mockUserRepo.On("FindByEmail", mock.Anything, strings.ToLower(tt.email)).
+				Return(tt.storedUser, tt.findErr).
+				Once()
+			if tt.roles != nil {
+				mockRefreshRepo.On("Create", mock.Anything, mock.MatchedBy(func(rt *models.RefreshToken) bool {
+					return rt.UserID == tt.storedUser.ID && rt.FamilyID != "" && len(rt.TokenHash) == 64
+				})).Return(nil).Once()
+				mockUserRepo.On("FindRolesByUserID", mock.Anything, tt.storedUser.ID).
+					Return(tt.roles, nil).
+					Once()
+			}
+
+			service := NewAuthService(mockUserRepo, mockRefreshRepo, uow, cache.NewMemoryRevocationStore(), jwtutil.NewHMACKeySet(jwtConfig.Secret), jwtConfig, logger)
 
 			req := &dto.LoginRequest{
 				Email:    tt.email,
@@ -66,8 +134,8 @@ func TestAuthService_Login(t *testing.T) {
 
 			resp, err := service.Login(context.Background(), req)
 
-			if tt.expectedErr {
-				assert.Error(t, err)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
 				assert.Nil(t, resp)
 				return
 			}
@@ -75,30 +143,35 @@ func TestAuthService_Login(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, resp)
 			assert.NotEmpty(t, resp.Token)
+			assert.NotEmpty(t, resp.RefreshToken)
 			assert.NotNil(t, resp.User)
-			assert.Equal(t, tt.email, resp.User.Email)
+			assert.Equal(t, tt.storedUser.ID, resp.User.ID)
 			assert.True(t, resp.User.IsActive)
 
-			// Verify token is valid
+			// Verify token carries the stored user ID and roles
 			claims, err := jwtutil.ValidateToken(resp.Token, jwtConfig.Secret)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.email, claims.Email)
+			assert.Equal(t, tt.storedUser.ID, claims.UserID)
+			assert.Equal(t, tt.storedUser.Email, claims.Email)
 			assert.Contains(t, claims.Roles, tt.checkRole)
 		})
 	}
 }
 
 // TestAuthService_Register
-// Summary: Tests user registration with different role assignments
-// Purpose: Ensure proper user creation and token generation in stub mode
+// Summary: Tests user registration against the user repository
+// Purpose: Ensure email uniqueness, password hashing, and that the user and its role are stored together
 func TestAuthService_Register(t *testing.T) {
 	tests := []struct {
 		name         string
 		email        string
 		password     string
 		role         string
+		existingUser *models.User
+		createErr    error
+		assignErr    error
 		expectedRole string
-		expectedErr  bool
+		expectedErr  error
 	}{
 		{
 			name:         "register default user",
@@ -106,36 +179,86 @@ func TestAuthService_Register(t *testing.T) {
 			password:     "password123",
 			role:         "",
 			expectedRole: models.RoleUser,
-			expectedErr:  false,
 		},
 		{
-			name:         "register with admin role",
-			email:        "newadmin@example.com",
-			password:     "admin123",
-			role:         models.RoleAdmin,
-			expectedRole: models.RoleAdmin,
-			expectedErr:  false,
+			name:         "register with explicit user role",
+			email:        "explicit@example.com",
+			password:     "password123",
+			role:         models.RoleUser,
+			expectedRole: models.RoleUser,
 		},
 		{
-			name:         "register organizer",
-			email:        "organizer@example.com",
-			password:     "org123",
-			role:         models.RoleOrganizer,
-			expectedRole: models.RoleOrganizer,
-			expectedErr:  false,
+			name:         "email already registered",
+			email:        "Taken@Example.com",
+			password:     "password123",
+			existingUser: &models.User{ID: "user-existing", Email: "taken@example.com"},
+			expectedErr:  ErrEmailAlreadyRegistered,
+		},
+		{
+			name:        "concurrent registration hits unique constraint",
+			email:       "race@example.com",
+			password:    "password123",
+			createErr:   fmt.Errorf("%w: users_email_key", repositories.ErrDuplicate),
+			expectedErr: ErrEmailAlreadyRegistered,
+		},
+		{
+			name:      "role assignment fails",
+			email:     "norole@example.com",
+			password:  "password123",
+			assignErr: errors.New("connection reset"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUserRepo := mocks.NewUserRepository(t)
+			mockRefreshRepo := mocks.NewRefreshTokenRepository(t)
+			uow := mocks.NewUnitOfWork(t)
 			logger := zerolog.Nop()
 			jwtConfig := config.JWTConfig{
 				Secret: "test-secret",
 				Expiry: "24h",
 			}
 
-			service := NewAuthService(mockUserRepo, jwtConfig, logger)
+			email := strings.ToLower(tt.email)
+			if tt.existingUser == nil {
+				// The user and its role are written in one transaction
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Users: mockUserRepo})
+					},
+				).Once()
+			}
+			if tt.existingUser != nil {
+				mockUserRepo.On("FindByEmail", mock.Anything, email).Return(tt.existingUser, nil).Once()
+			} else if tt.createErr != nil {
+				mockUserRepo.On("FindByEmail", mock.Anything, email).Return(nil, repositories.ErrNotFound).Once()
+				mockUserRepo.On("Create", mock.Anything, mock.Anything).Return(tt.createErr).Once()
+			} else if tt.assignErr != nil {
+				mockUserRepo.On("FindByEmail", mock.Anything, email).Return(nil, repositories.ErrNotFound).Once()
+				mockUserRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.User).ID = "user-new"
+				}).Return(nil).Once()
+				mockUserRepo.On("AssignRole", mock.Anything, "user-new", models.RoleUser, (*string)(nil)).Return(tt.assignErr).Once()
+			} else {
+				mockUserRepo.On("FindByEmail", mock.Anything, email).Return(nil, repositories.ErrNotFound).Once()
+				mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+					return u.Email == email &&
+						bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(tt.password)) == nil
+				})).
+					Run(func(args mock.Arguments) {
+						args.Get(1).(*models.User).ID = "user-new"
+					}).
+					Return(nil).
+					Once()
+				mockUserRepo.On("AssignRole", mock.Anything, "user-new", tt.expectedRole, (*string)(nil)).Return(nil).Once()
+				mockRefreshRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				mockUserRepo.On("FindRolesByUserID", mock.Anything, "user-new").
+					Return([]*models.Role{{Name: tt.expectedRole}}, nil).
+					Once()
+			}
+
+			service := NewAuthService(mockUserRepo, mockRefreshRepo, uow, cache.NewMemoryRevocationStore(), jwtutil.NewHMACKeySet(jwtConfig.Secret), jwtConfig, logger)
 
 			req := &dto.RegisterRequest{
 				Email:    tt.email,
@@ -145,29 +268,168 @@ func TestAuthService_Register(t *testing.T) {
 
 			resp, err := service.Register(context.Background(), req)
 
-			if tt.expectedErr {
+			if tt.assignErr != nil {
+				// No tokens are issued for a registration that was rolled back
 				assert.Error(t, err)
+				assert.Nil(t, resp)
+				return
+			}
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
 				return
 			}
 
 			assert.NoError(t, err)
 			assert.NotNil(t, resp)
 			assert.NotEmpty(t, resp.Token)
-			assert.Equal(t, tt.email, resp.User.Email)
+			assert.Equal(t, "user-new", resp.User.ID)
+			assert.Equal(t, email, resp.User.Email)
 			assert.True(t, resp.User.IsActive)
 
 			// Check JWT claims
 			claims, err := jwtutil.ValidateToken(resp.Token, jwtConfig.Secret)
 			assert.NoError(t, err)
-			assert.Equal(t, tt.email, claims.Email)
+			assert.Equal(t, "user-new", claims.UserID)
 			assert.Contains(t, claims.Roles, tt.expectedRole)
 		})
 	}
 }
 
+// TestAuthService_Register_RefusesElevatedRoles
+// Summary: Registration requests asking for an elevated role
+// Purpose: Ensure privilege escalation is refused before any user is created
+func TestAuthService_Register_RefusesElevatedRoles(t *testing.T) {
+	for _, role := range []string{models.RoleAdmin, models.RoleOrganizer, models.RoleValidator, "superuser"} {
+		t.Run(role, func(t *testing.T) {
+			// No expectations: any repository call fails the test
+			mockUserRepo := mocks.NewUserRepository(t)
+			mockRefreshRepo := mocks.NewRefreshTokenRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			service := NewAuthService(mockUserRepo, mockRefreshRepo, uow, cache.NewMemoryRevocationStore(), jwtutil.NewHMACKeySet("test-secret"), config.JWTConfig{Secret: "test-secret", Expiry: "24h"}, zerolog.Nop())
+
+			resp, err := service.Register(context.Background(), &dto.RegisterRequest{
+				Email:    "escalate@example.com",
+				Password: "password123",
+				Role:     role,
+			})
+
+			assert.ErrorIs(t, err, ErrRoleNotSelfAssignable)
+			assert.Nil(t, resp)
+		})
+	}
+}
+
+// TestAuthService_Refresh
+// Summary: Exchanges refresh tokens in different states
+// Purpose: Verify rotation within the same family and family revocation on reuse
+func TestAuthService_Refresh(t *testing.T) {
+	jwtConfig := config.JWTConfig{
+		Secret:        "test-refresh-secret",
+		Expiry:        "15m",
+		RefreshExpiry: "720h",
+	}
+	user := &models.User{ID: "user-001", Email: "user@example.com", IsActive: true}
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name         string
+		stored       *models.RefreshToken
+		findErr      error
+		rotateErr    error
+		expectRevoke bool
+		expectedErr  error
+	}{
+		{
+			name: "valid token is rotated",
+			stored: &models.RefreshToken{
+				ID: "rt-1", UserID: user.ID, FamilyID: "family-1",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+		},
+		{
+			name: "reused rotated token revokes family",
+			stored: &models.RefreshToken{
+				ID: "rt-1", UserID: user.ID, FamilyID: "family-1",
+				ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt,
+			},
+			expectRevoke: true,
+			expectedErr:  ErrRefreshTokenReused,
+		},
+		{
+			name: "concurrent rotation revokes family",
+			stored: &models.RefreshToken{
+				ID: "rt-1", UserID: user.ID, FamilyID: "family-1",
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+			rotateErr:    repositories.ErrConflict,
+			expectRevoke: true,
+			expectedErr:  ErrRefreshTokenReused,
+		},
+		{
+			name: "expired token",
+			stored: &models.RefreshToken{
+				ID: "rt-1", UserID: user.ID, FamilyID: "family-1",
+				ExpiresAt: time.Now().Add(-time.Hour),
+			},
+			expectedErr: ErrInvalidRefreshToken,
+		},
+		{
+			name:        "unknown token",
+			findErr:     repositories.ErrNotFound,
+			expectedErr: ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewUserRepository(t)
+			mockRefreshRepo := mocks.NewRefreshTokenRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+
+			plain := "opaque-refresh-token"
+			mockRefreshRepo.On("FindByHash", mock.Anything, hashRefreshToken(plain)).
+				Return(tt.stored, tt.findErr).
+				Once()
+
+			if tt.stored != nil && tt.stored.RevokedAt == nil && tt.stored.ExpiresAt.After(time.Now()) {
+				mockUserRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+				mockRefreshRepo.On("Rotate", mock.Anything, "rt-1", mock.MatchedBy(func(next *models.RefreshToken) bool {
+					// The replacement stays in the same family
+					return next.FamilyID == "family-1" && next.UserID == user.ID && next.TokenHash != hashRefreshToken(plain)
+				})).Return(tt.rotateErr).Once()
+				if tt.rotateErr == nil {
+					mockUserRepo.On("FindRolesByUserID", mock.Anything, user.ID).
+						Return([]*models.Role{{Name: models.RoleUser}}, nil).
+						Once()
+				}
+			}
+			if tt.expectRevoke {
+				mockRefreshRepo.On("RevokeFamily", mock.Anything, "family-1").Return(nil).Once()
+			}
+
+			service := NewAuthService(mockUserRepo, mockRefreshRepo, uow, cache.NewMemoryRevocationStore(), jwtutil.NewHMACKeySet(jwtConfig.Secret), jwtConfig, zerolog.Nop())
+			resp, err := service.Refresh(context.Background(), &dto.RefreshRequest{RefreshToken: plain})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, resp.Token)
+			assert.NotEmpty(t, resp.RefreshToken)
+			assert.NotEqual(t, plain, resp.RefreshToken)
+			assert.Equal(t, int64(900), resp.ExpiresIn)
+		})
+	}
+}
+
 // TestAuthService_ValidateToken
 // Summary: Tests token validation with various token states
-// Purpose: Verify JWT validation logic and user reconstruction from claims
+// Purpose: Verify JWT validation, the active-user check, and that roles are read from the database,
+// so a role revoked after the token was issued no longer counts
 func TestAuthService_ValidateToken(t *testing.T) {
 	logger := zerolog.Nop()
 	jwtConfig := config.JWTConfig{
@@ -178,8 +440,11 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	tests := []struct {
 		name        string
 		setupToken  func() string
+		storedUser  *models.User
+		storedRoles []string // the user's roles in the database
 		expectError bool
 		checkEmail  string
+		checkRoles  []string
 	}{
 		{
 			name: "valid token",
@@ -194,8 +459,11 @@ func TestAuthService_ValidateToken(t *testing.T) {
 				)
 				return token
 			},
+			storedUser:  &models.User{ID: "user-123", Email: "valid@example.com", IsActive: true},
+			storedRoles: []string{models.RoleUser},
 			expectError: false,
 			checkEmail:  "valid@example.com",
+			checkRoles:  []string{models.RoleUser},
 		},
 		{
 			name: "valid admin token",
@@ -210,8 +478,46 @@ func TestAuthService_ValidateToken(t *testing.T) {
 				)
 				return token
 			},
+			storedUser:  &models.User{ID: "admin-456", Email: "admin@example.com", IsActive: true},
+			storedRoles: []string{models.RoleAdmin},
 			expectError: false,
 			checkEmail:  "admin@example.com",
+			checkRoles:  []string{models.RoleAdmin},
+		},
+		{
+			name: "admin role revoked after the token was issued",
+			setupToken: func() string {
+				expiry, _ := time.ParseDuration(jwtConfig.Expiry)
+				token, _ := jwtutil.GenerateToken(
+					"admin-789",
+					"former-admin@example.com",
+					[]string{models.RoleAdmin, models.RoleOrganizer},
+					jwtConfig.Secret,
+					expiry,
+				)
+				return token
+			},
+			storedUser:  &models.User{ID: "admin-789", Email: "former-admin@example.com", IsActive: true},
+			storedRoles: []string{models.RoleOrganizer},
+			expectError: false,
+			checkEmail:  "former-admin@example.com",
+			checkRoles:  []string{models.RoleOrganizer},
+		},
+		{
+			name: "deactivated user",
+			setupToken: func() string {
+				expiry, _ := time.ParseDuration(jwtConfig.Expiry)
+				token, _ := jwtutil.GenerateToken(
+					"user-disabled",
+					"disabled@example.com",
+					[]string{models.RoleUser},
+					jwtConfig.Secret,
+					expiry,
+				)
+				return token
+			},
+			storedUser:  &models.User{ID: "user-disabled", Email: "disabled@example.com", IsActive: false},
+			expectError: true,
 		},
 		{
 			name: "invalid token - wrong secret",
@@ -240,7 +546,19 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUserRepo := mocks.NewUserRepository(t)
-			service := NewAuthService(mockUserRepo, jwtConfig, logger)
+			mockRefreshRepo := mocks.NewRefreshTokenRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			if tt.storedUser != nil {
+				mockUserRepo.On("FindByID", mock.Anything, tt.storedUser.ID).Return(tt.storedUser, nil).Once()
+			}
+			if tt.storedRoles != nil {
+				roles := make([]*models.Role, 0, len(tt.storedRoles))
+				for _, name := range tt.storedRoles {
+					roles = append(roles, &models.Role{Name: name})
+				}
+				mockUserRepo.On("FindRolesByUserID", mock.Anything, tt.storedUser.ID).Return(roles, nil).Once()
+			}
+			service := NewAuthService(mockUserRepo, mockRefreshRepo, uow, cache.NewMemoryRevocationStore(), jwtutil.NewHMACKeySet(jwtConfig.Secret), jwtConfig, logger)
 
 			token := tt.setupToken()
 			user, err := service.ValidateToken(context.Background(), token)
@@ -255,6 +573,77 @@ func TestAuthService_ValidateToken(t *testing.T) {
 			assert.NotNil(t, user)
 			assert.Equal(t, tt.checkEmail, user.Email)
 			assert.True(t, user.IsActive)
+			roles := make([]string, 0, len(user.Roles))
+			for _, role := range user.Roles {
+				roles = append(roles, role.Name)
+			}
+			assert.Equal(t, tt.checkRoles, roles)
+		})
+	}
+}
+
+// TestAuthService_Logout
+// Summary: Logs out with and without a refresh token
+// Purpose: Ensure the access token is rejected afterwards and the refresh family is revoked
+func TestAuthService_Logout(t *testing.T) {
+	jwtConfig := config.JWTConfig{
+		Secret: "test-logout-secret",
+		Expiry: "15m",
+	}
+	user := &models.User{ID: "user-001", Email: "user@example.com", IsActive: true}
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		stored       *models.RefreshToken
+		expectRevoke bool
+	}{
+		{
+			name: "access token only",
+		},
+		{
+			name:         "with own refresh token",
+			refreshToken: "own-refresh-token",
+			stored:       &models.RefreshToken{ID: "rt-1", UserID: user.ID, FamilyID: "family-1"},
+			expectRevoke: true,
+		},
+		{
+			name:         "with someone else's refresh token",
+			refreshToken: "foreign-refresh-token",
+			stored:       &models.RefreshToken{ID: "rt-2", UserID: "user-other", FamilyID: "family-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewUserRepository(t)
+			mockRefreshRepo := mocks.NewRefreshTokenRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			revocations := cache.NewMemoryRevocationStore()
+			service := NewAuthService(mockUserRepo, mockRefreshRepo, uow, revocations, jwtutil.NewHMACKeySet(jwtConfig.Secret), jwtConfig, zerolog.Nop())
+
+			token, err := jwtutil.GenerateToken(user.ID, user.Email, []string{models.RoleUser}, jwtConfig.Secret, 15*time.Minute)
+			assert.NoError(t, err)
+
+			// Token works before logout
+			mockUserRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil).Once()
+			mockUserRepo.On("FindRolesByUserID", mock.Anything, user.ID).Return([]*models.Role{{Name: models.RoleUser}}, nil).Once()
+			_, err = service.ValidateToken(context.Background(), token)
+			assert.NoError(t, err)
+
+			if tt.stored != nil {
+				mockRefreshRepo.On("FindByHash", mock.Anything, hashRefreshToken(tt.refreshToken)).Return(tt.stored, nil).Once()
+			}
+			if tt.expectRevoke {
+				mockRefreshRepo.On("RevokeFamily", mock.Anything, tt.stored.FamilyID).Return(nil).Once()
+			}
+
+			err = service.Logout(context.Background(), token, &dto.LogoutRequest{RefreshToken: tt.refreshToken})
+			assert.NoError(t, err)
+
+			// Same token is rejected afterwards, before any user lookup
+			_, err = service.ValidateToken(context.Background(), token)
+			assert.ErrorIs(t, err, ErrTokenRevoked)
 		})
 	}
 }
@@ -264,13 +653,15 @@ func TestAuthService_ValidateToken(t *testing.T) {
 // Purpose: Test JWT expiration handling
 func TestAuthService_TokenExpiry(t *testing.T) {
 	mockUserRepo := mocks.NewUserRepository(t)
+	mockRefreshRepo := mocks.NewRefreshTokenRepository(t)
+	uow := mocks.NewUnitOfWork(t)
 	logger := zerolog.Nop()
 	jwtConfig := config.JWTConfig{
 		Secret: "test-expiry-secret",
 		Expiry: "1ns", // very short expiry
 	}
 
-	service := NewAuthService(mockUserRepo, jwtConfig, logger)
+	service := NewAuthService(mockUserRepo, mockRefreshRepo, uow, cache.NewMemoryRevocationStore(), jwtutil.NewHMACKeySet(jwtConfig.Secret), jwtConfig, logger)
 
 	// Generate token that will expire immediately
 	expiry, _ := time.ParseDuration(jwtConfig.Expiry)
@@ -289,4 +680,4 @@ func TestAuthService_TokenExpiry(t *testing.T) {
 	user, err := service.ValidateToken(context.Background(), token)
 	assert.Error(t, err)
 	assert.Nil(t, user)
-}
\ No newline at end of file
+}
@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/pkg/ticketpass"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// passPrefix marks a scanned code as a signed pass rather than a ticket code
+	passPrefix = "TP1."
+	// maxScanClockSkew is how far in the future an offline scan's timestamp may be
+	maxScanClockSkew = 5 * time.Minute
+)
+
+// CheckinService admits ticket holders at the gate
+type CheckinService interface {
+	// CheckIn marks the ticket behind a code or signed pass as used. A ticket
+	// that cannot be admitted is not an error: the response says why, with
+	// the holder and order attached.
+	CheckIn(ctx context.Context, actor Actor, req *dto.CheckinRequest) (*dto.CheckinResponse, error)
+	// Snapshot returns the event's admissible tickets, signed, for scanners
+	// to work from while offline
+	Snapshot(ctx context.Context, eventID string) (*ticketpass.SignedDocument, error)
+	// Sync records scans made offline. The earliest scan of a ticket admits
+	// it; every scan that was not simply admitted is reported as a conflict.
+	Sync(ctx context.Context, actor Actor, req *dto.CheckinSyncRequest) (*dto.CheckinSyncResponse, error)
+}
+
+type checkinService struct {
+	uow        repositories.UnitOfWork
+	ticketRepo repositories.TicketRepository
+	eventRepo  repositories.EventRepository
+	userRepo   repositories.UserRepository
+	scanRepo   repositories.CheckinScanRepository
+	signer     *ticketpass.Signer
+	cfg        config.TicketPassConfig
+	log        zerolog.Logger
+}
+
+func NewCheckinService(
+	uow repositories.UnitOfWork,
+	ticketRepo repositories.TicketRepository,
+	eventRepo repositories.EventRepository,
+	userRepo repositories.UserRepository,
+	scanRepo repositories.CheckinScanRepository,
+	signer *ticketpass.Signer,
+	cfg config.TicketPassConfig,
+	log zerolog.Logger,
+) CheckinService {
+	return &checkinService{
+		uow:        uow,
+		ticketRepo: ticketRepo,
+		eventRepo:  eventRepo,
+		userRepo:   userRepo,
+		scanRepo:   scanRepo,
+		signer:     signer,
+		cfg:        cfg,
+		log:        log,
+	}
+}
+
+func (s *checkinService) CheckIn(ctx context.Context, actor Actor, req *dto.CheckinRequest) (*dto.CheckinResponse, error) {
+	ticket, err := s.resolveTicket(ctx, strings.TrimSpace(req.Code), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &models.CheckinScan{
+		TicketID:    &ticket.ID,
+		EventID:     req.EventID,
+		ValidatorID: &actor.UserID,
+		Source:      string(models.ScanSourceOnline),
+	}
+
+	// The update re-checks every condition, so of two gates scanning the same
+	// ticket only one admits it
+	var used *models.Ticket
+	err = s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		var err error
+		if used, err = tx.Tickets.CheckInTicket(ctx, ticket.ID, req.EventID); err != nil {
+			return err
+		}
+		scan.Result = string(models.ScanResultAdmitted)
+		scan.ScannedAt = *used.UsedAt
+		return tx.CheckinScans.Create(ctx, scan)
+	})
+	switch {
+	case err == nil:
+		ticket = used
+	case errors.Is(err, repositories.ErrConflict):
+		// Reload to explain the refusal with the state that caused it
+		if ticket, err = s.ticketRepo.FindTicketByID(ctx, ticket.ID); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	order, err := s.ticketRepo.FindOrderByID(ctx, ticket.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	holder, err := s.userRepo.FindByID(ctx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.CheckinResponse{
+		Admitted: used != nil,
+		Ticket:   ticket,
+		Order:    order,
+		Holder:   &dto.CheckinHolder{UserID: holder.ID, Email: holder.Email},
+	}
+	if resp.Admitted {
+		resp.Message = "ticket admitted"
+		s.log.Info().Str("ticket_id", ticket.ID).Str("event_id", req.EventID).Str("validator_id", actor.UserID).Msg("ticket checked in")
+		return resp, nil
+	}
+
+	resp.Reason = rejectionReason(ticket, order, req.EventID)
+	resp.Message = checkinMessages[resp.Reason]
+	if resp.Reason == dto.CheckinAlreadyUsed && ticket.UsedAt != nil {
+		resp.Message += " at " + ticket.UsedAt.Format(time.RFC3339)
+	}
+	scan.Result = string(models.ScanResultRejected)
+	if resp.Reason == dto.CheckinAlreadyUsed {
+		scan.Result = string(models.ScanResultDuplicate)
+	}
+	scan.Reason = &resp.Reason
+	scan.ScannedAt = time.Now().UTC()
+	if err := s.scanRepo.Create(ctx, scan); err != nil {
+		// The refusal stands without the audit row
+		s.log.Error().Err(err).Str("ticket_id", ticket.ID).Msg("failed to record rejected scan")
+	}
+	s.log.Info().Str("ticket_id", ticket.ID).Str("event_id", req.EventID).Str("validator_id", actor.UserID).
+		Str("reason", resp.Reason).Msg("ticket check-in rejected")
+	return resp, nil
+}
+
+func (s *checkinService) Snapshot(ctx context.Context, eventID string) (*ticketpass.SignedDocument, error) {
+	if uuid.Validate(eventID) != nil {
+		return nil, ErrEventNotFound
+	}
+	event, err := s.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	tickets, err := s.ticketRepo.ListCheckinTickets(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := dto.CheckinSnapshot{
+		EventID:     event.ID,
+		GeneratedAt: time.Now().UTC(),
+		ExpiresAt:   event.EventDate.Add(s.cfg.Validity).UTC(),
+		Tickets:     make([]dto.SnapshotTicket, len(tickets)),
+	}
+	for i, ticket := range tickets {
+		snapshot.Tickets[i] = dto.SnapshotTicket{
+			TicketID: ticket.ID,
+			CodeHash: ticketpass.HashCode(ticket.TicketCode),
+			Status:   ticket.Status,
+			UsedAt:   ticket.UsedAt,
+		}
+	}
+	return s.signer.SignDocument(snapshot)
+}
+
+func (s *checkinService) Sync(ctx context.Context, actor Actor, req *dto.CheckinSyncRequest) (*dto.CheckinSyncResponse, error) {
+	resp := &dto.CheckinSyncResponse{
+		Results:   make([]dto.ScanOutcome, 0, len(req.Scans)),
+		Conflicts: []dto.ScanConflict{},
+	}
+	// Scans are stored one by one; after a failure the device uploads the
+	// batch again and the scans already stored are replayed
+	for _, scan := range req.Scans {
+		outcome, conflict, err := s.syncScan(ctx, actor, req, scan)
+		if err != nil {
+			return nil, err
+		}
+		resp.Results = append(resp.Results, *outcome)
+		if conflict != nil {
+			resp.Conflicts = append(resp.Conflicts, *conflict)
+		}
+	}
+
+	s.log.Info().Str("event_id", req.EventID).Str("device_id", req.DeviceID).Str("validator_id", actor.UserID).
+		Int("scans", len(req.Scans)).Int("conflicts", len(resp.Conflicts)).Msg("offline scans synced")
+	return resp, nil
+}
+
+// syncScan decides and records one offline scan
+func (s *checkinService) syncScan(ctx context.Context, actor Actor, req *dto.CheckinSyncRequest, scan dto.OfflineScan) (*dto.ScanOutcome, *dto.ScanConflict, error) {
+	existing, err := s.scanRepo.FindByID(ctx, scan.ScanID)
+	if err == nil {
+		return s.replay(ctx, existing)
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil, err
+	}
+
+	record := &models.CheckinScan{
+		ID:          scan.ScanID,
+		EventID:     req.EventID,
+		ValidatorID: &actor.UserID,
+		DeviceID:    &req.DeviceID,
+		Source:      string(models.ScanSourceOffline),
+		ScannedAt:   scan.ScannedAt.UTC(),
+	}
+	if scan.Gate != "" {
+		record.Gate = &scan.Gate
+	}
+
+	if scan.ScannedAt.After(time.Now().Add(maxScanClockSkew)) {
+		// A device clock this far ahead would win every timestamp comparison
+		return s.reject(ctx, record, dto.CheckinInvalidScanTime)
+	}
+	// Passes are checked at scan time: one that was valid at the gate counts
+	ticket, err := s.resolveTicket(ctx, strings.TrimSpace(scan.Code), scan.ScannedAt)
+	switch {
+	case errors.Is(err, ErrInvalidTicketPass):
+		return s.reject(ctx, record, dto.CheckinInvalidPass)
+	case errors.Is(err, ErrTicketNotFound):
+		return s.reject(ctx, record, dto.CheckinUnknownTicket)
+	case err != nil:
+		return nil, nil, err
+	}
+	record.TicketID = &ticket.ID
+
+	var other *models.CheckinScan
+	err = s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		other = nil
+		locked, err := tx.Tickets.FindTicketByIDForUpdate(ctx, ticket.ID)
+		if err != nil {
+			return err
+		}
+		order, err := tx.Tickets.FindOrderByID(ctx, locked.OrderID)
+		if err != nil {
+			return err
+		}
+
+		reason := ""
+		switch {
+		case locked.Status == string(models.TicketStatusValid) &&
+			order.EventID == req.EventID && order.Status == string(models.OrderStatusConfirmed):
+			record.Result = string(models.ScanResultAdmitted)
+			if err := tx.Tickets.MarkTicketUsed(ctx, locked.ID, record.ScannedAt); err != nil {
+				return err
+			}
+
+		case locked.Status == string(models.TicketStatusUsed) && order.EventID == req.EventID:
+			admission, err := tx.CheckinScans.FindAdmission(ctx, locked.ID)
+			if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+				return err
+			}
+			other = admission
+			if locked.UsedAt != nil && !record.ScannedAt.Before(*locked.UsedAt) {
+				record.Result = string(models.ScanResultDuplicate)
+				reason = dto.CheckinAlreadyUsed
+				break
+			}
+			// This scan came first: it becomes the admission and the one
+			// recorded so far becomes the duplicate
+			if admission != nil {
+				if err := tx.CheckinScans.UpdateResult(ctx, admission.ID, models.ScanResultDuplicate, dto.CheckinAlreadyUsed); err != nil {
+					return err
+				}
+				admission.Result = string(models.ScanResultDuplicate)
+			}
+			if err := tx.Tickets.MarkTicketUsed(ctx, locked.ID, record.ScannedAt); err != nil {
+				return err
+			}
+			record.Result = string(models.ScanResultAdmitted)
+			reason = dto.CheckinReplacedLaterScan
+
+		default:
+			record.Result = string(models.ScanResultRejected)
+			reason = rejectionReason(locked, order, req.EventID)
+		}
+
+		if reason != "" {
+			record.Reason = &reason
+		}
+		return tx.CheckinScans.Create(ctx, record)
+	})
+	if errors.Is(err, repositories.ErrDuplicate) {
+		// The same scan arrived twice at once; the other upload stored it
+		if existing, err = s.scanRepo.FindByID(ctx, scan.ScanID); err != nil {
+			return nil, nil, err
+		}
+		return s.replay(ctx, existing)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return scanOutcome(record, false), scanConflict(record, other), nil
+}
+
+// reject records an offline scan the server could not match to an admissible ticket
+func (s *checkinService) reject(ctx context.Context, record *models.CheckinScan, reason string) (*dto.ScanOutcome, *dto.ScanConflict, error) {
+	record.Result = string(models.ScanResultRejected)
+	record.Reason = &reason
+	if err := s.scanRepo.Create(ctx, record); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			existing, err := s.scanRepo.FindByID(ctx, record.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			return s.replay(ctx, existing)
+		}
+		return nil, nil, err
+	}
+	return scanOutcome(record, false), scanConflict(record, nil), nil
+}
+
+// replay reports a scan that was uploaded before as it stands now
+func (s *checkinService) replay(ctx context.Context, scan *models.CheckinScan) (*dto.ScanOutcome, *dto.ScanConflict, error) {
+	var other *models.CheckinScan
+	if scan.Result == string(models.ScanResultDuplicate) && scan.TicketID != nil {
+		admission, err := s.scanRepo.FindAdmission(ctx, *scan.TicketID)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return nil, nil, err
+		}
+		other = admission
+	}
+	return scanOutcome(scan, true), scanConflict(scan, other), nil
+}
+
+func scanOutcome(scan *models.CheckinScan, replayed bool) *dto.ScanOutcome {
+	outcome := &dto.ScanOutcome{ScanID: scan.ID, Result: scan.Result, Replayed: replayed}
+	if scan.TicketID != nil {
+		outcome.TicketID = *scan.TicketID
+	}
+	if scan.Reason != nil {
+		outcome.Reason = *scan.Reason
+	}
+	return outcome
+}
+
+// scanConflict describes why an offline scan needs a look, or returns nil
+// for a scan that simply admitted its ticket
+func scanConflict(scan *models.CheckinScan, other *models.CheckinScan) *dto.ScanConflict {
+	if scan.Reason == nil {
+		return nil
+	}
+	conflict := &dto.ScanConflict{
+		ScanID:    scan.ID,
+		Reason:    *scan.Reason,
+		Message:   checkinMessages[*scan.Reason],
+		OtherScan: other,
+	}
+	if scan.TicketID != nil {
+		conflict.TicketID = *scan.TicketID
+	}
+	return conflict
+}
+
+// resolveTicket finds the ticket behind a signed pass or a plain ticket code.
+// Passes are checked for expiry at the time given.
+func (s *checkinService) resolveTicket(ctx context.Context, code string, at time.Time) (*models.Ticket, error) {
+	var ticket *models.Ticket
+	var err error
+	if strings.HasPrefix(code, passPrefix) {
+		claims, verifyErr := s.signer.Verifier().Verify(code, at)
+		if verifyErr != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidTicketPass, verifyErr)
+		}
+		ticket, err = s.ticketRepo.FindTicketByID(ctx, claims.TicketID)
+	} else {
+		ticket, err = s.ticketRepo.FindTicketByCode(ctx, code)
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrTicketNotFound
+		}
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// checkinMessages explains each check-in reason to gate staff
+var checkinMessages = map[string]string{
+	dto.CheckinAlreadyUsed:       "ticket has already been used",
+	dto.CheckinTicketCancelled:   "ticket has been cancelled",
+	dto.CheckinTicketTransferred: "ticket was transferred to someone else",
+	dto.CheckinWrongEvent:        "ticket is for a different event",
+	dto.CheckinOrderNotConfirmed: "ticket's order is not paid in full",
+	dto.CheckinUnknownTicket:     "code does not match any ticket",
+	dto.CheckinInvalidPass:       "ticket pass signature is invalid or the pass had expired",
+	dto.CheckinInvalidScanTime:   "scan time is in the future; check the device clock",
+	dto.CheckinReplacedLaterScan: "ticket was also admitted by a later scan, now recorded as the duplicate",
+}
+
+// rejectionReason tells why a ticket cannot be admitted to eventID
+func rejectionReason(ticket *models.Ticket, order *models.TicketOrder, eventID string) string {
+	switch {
+	case order.EventID != eventID:
+		return dto.CheckinWrongEvent
+	case ticket.Status == string(models.TicketStatusUsed):
+		return dto.CheckinAlreadyUsed
+	case ticket.Status == string(models.TicketStatusCancelled):
+		return dto.CheckinTicketCancelled
+	case ticket.Status == string(models.TicketStatusTransferred):
+		return dto.CheckinTicketTransferred
+	default:
+		return dto.CheckinOrderNotConfirmed
+	}
+}
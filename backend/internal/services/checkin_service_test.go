@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
+	"github.com/baramulti/ticketing-system/backend/pkg/ticketpass"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckinService_CheckIn
+// Summary: Tests admitting tickets at the gate
+// Purpose: Verify ticket codes and signed passes resolve to the ticket, admitted tickets come back used,
+// refusals explain themselves with the holder attached, and bad passes or unknown codes are errors
+func TestCheckinService_CheckIn(t *testing.T) {
+	const eventID = "event-001"
+	usedAt := time.Date(2026, 10, 18, 19, 5, 0, 0, time.UTC)
+
+	key, err := jwtutil.GenerateEd25519Key()
+	require.NoError(t, err)
+	signer, err := ticketpass.NewSigner(key)
+	require.NoError(t, err)
+	sign := func(claims ticketpass.Claims) string {
+		pass, err := signer.Sign(claims)
+		require.NoError(t, err)
+		return pass
+	}
+	validPass := sign(ticketpass.Claims{TicketID: "ticket-001", EventID: eventID, ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	expiredPass := sign(ticketpass.Claims{TicketID: "ticket-001", EventID: eventID, ExpiresAt: time.Now().Add(-time.Hour).Unix()})
+
+	tests := []struct {
+		name         string
+		code         string
+		byCodeErr    error
+		checkInErr   error
+		ticketAfter  *models.Ticket // reloaded after a refused check-in
+		orderEventID string
+		orderStatus  models.TicketOrderStatus
+		wantAdmitted bool
+		wantReason   string
+		expectedErr  error
+	}{
+		{
+			name:         "ticket code admitted",
+			code:         "TKT-ABC",
+			wantAdmitted: true,
+		},
+		{
+			name:         "signed pass admitted",
+			code:         validPass,
+			wantAdmitted: true,
+		},
+		{
+			name:        "expired pass",
+			code:        expiredPass,
+			expectedErr: ErrInvalidTicketPass,
+		},
+		{
+			name:        "forged pass",
+			code:        validPass[:len(validPass)-4] + "AAAA",
+			expectedErr: ErrInvalidTicketPass,
+		},
+		{
+			name:        "unknown code",
+			code:        "TKT-NOPE",
+			byCodeErr:   repositories.ErrNotFound,
+			expectedErr: ErrTicketNotFound,
+		},
+		{
+			name:        "already used",
+			code:        "TKT-ABC",
+			checkInErr:  repositories.ErrConflict,
+			ticketAfter: &models.Ticket{ID: "ticket-001", OrderID: "order-001", Status: string(models.TicketStatusUsed), UsedAt: &usedAt},
+			wantReason:  dto.CheckinAlreadyUsed,
+		},
+		{
+			name:        "cancelled",
+			code:        "TKT-ABC",
+			checkInErr:  repositories.ErrConflict,
+			ticketAfter: &models.Ticket{ID: "ticket-001", OrderID: "order-001", Status: string(models.TicketStatusCancelled)},
+			wantReason:  dto.CheckinTicketCancelled,
+		},
+		{
+			name:         "wrong event",
+			code:         "TKT-ABC",
+			checkInErr:   repositories.ErrConflict,
+			ticketAfter:  &models.Ticket{ID: "ticket-001", OrderID: "order-001", Status: string(models.TicketStatusValid)},
+			orderEventID: "event-002",
+			wantReason:   dto.CheckinWrongEvent,
+		},
+		{
+			name:        "order still pending",
+			code:        "TKT-ABC",
+			checkInErr:  repositories.ErrConflict,
+			ticketAfter: &models.Ticket{ID: "ticket-001", OrderID: "order-001", Status: string(models.TicketStatusValid)},
+			orderStatus: models.OrderStatusPending,
+			wantReason:  dto.CheckinOrderNotConfirmed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketRepo := mocks.NewTicketRepository(t)
+			txTickets := mocks.NewTicketRepository(t)
+			txScans := mocks.NewCheckinScanRepository(t)
+			scanRepo := mocks.NewCheckinScanRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			ctx := context.Background()
+			ticket := &models.Ticket{ID: "ticket-001", OrderID: "order-001", TicketCode: "TKT-ABC", Status: string(models.TicketStatusValid)}
+
+			if tt.expectedErr != ErrInvalidTicketPass {
+				if tt.code == validPass {
+					ticketRepo.On("FindTicketByID", ctx, "ticket-001").Return(ticket, nil).Once()
+				} else if tt.byCodeErr != nil {
+					ticketRepo.On("FindTicketByCode", ctx, tt.code).Return(nil, tt.byCodeErr)
+				} else {
+					ticketRepo.On("FindTicketByCode", ctx, tt.code).Return(ticket, nil)
+				}
+			}
+			if tt.expectedErr == nil {
+				uow.On("Do", ctx, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Tickets: txTickets, CheckinScans: txScans})
+					})
+				if tt.checkInErr != nil {
+					txTickets.On("CheckInTicket", ctx, "ticket-001", eventID).Return(nil, tt.checkInErr)
+					ticketRepo.On("FindTicketByID", ctx, "ticket-001").Return(tt.ticketAfter, nil)
+					scanRepo.On("Create", ctx, mock.MatchedBy(func(scan *models.CheckinScan) bool {
+						return scan.Result != string(models.ScanResultAdmitted) && *scan.Reason == tt.wantReason
+					})).Return(nil)
+				} else {
+					used := *ticket
+					used.Status = string(models.TicketStatusUsed)
+					used.UsedAt = &usedAt
+					txTickets.On("CheckInTicket", ctx, "ticket-001", eventID).Return(&used, nil)
+					txScans.On("Create", ctx, mock.MatchedBy(func(scan *models.CheckinScan) bool {
+						return scan.Result == string(models.ScanResultAdmitted) && scan.ScannedAt.Equal(usedAt) &&
+							scan.Source == string(models.ScanSourceOnline)
+					})).Return(nil)
+				}
+
+				orderEventID, orderStatus := eventID, models.OrderStatusConfirmed
+				if tt.orderEventID != "" {
+					orderEventID = tt.orderEventID
+				}
+				if tt.orderStatus != "" {
+					orderStatus = tt.orderStatus
+				}
+				ticketRepo.On("FindOrderByID", ctx, "order-001").
+					Return(&models.TicketOrder{ID: "order-001", EventID: orderEventID, UserID: "user-001", Status: string(orderStatus)}, nil)
+				userRepo.On("FindByID", ctx, "user-001").Return(&models.User{ID: "user-001", Email: "budi@example.com"}, nil)
+			}
+
+			svc := NewCheckinService(uow, ticketRepo, mocks.NewEventRepository(t), userRepo, scanRepo, signer, config.TicketPassConfig{Validity: time.Hour}, zerolog.Nop())
+			resp, err := svc.CheckIn(ctx, Actor{UserID: "validator-001"}, &dto.CheckinRequest{EventID: eventID, Code: tt.code})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAdmitted, resp.Admitted)
+			assert.Equal(t, tt.wantReason, resp.Reason)
+			assert.NotEmpty(t, resp.Message)
+			assert.Equal(t, "budi@example.com", resp.Holder.Email)
+			assert.Equal(t, "order-001", resp.Order.ID)
+			if tt.wantAdmitted {
+				assert.Equal(t, string(models.TicketStatusUsed), resp.Ticket.Status)
+				assert.NotNil(t, resp.Ticket.UsedAt)
+			}
+		})
+	}
+}
+
+// TestCheckinService_Sync
+// Summary: Tests uploading scans recorded offline
+// Purpose: Verify the earliest scan of a ticket admits it, later or refused scans are reported as conflicts,
+// an earlier scan displaces the recorded admission, and re-uploaded scans are replayed
+func TestCheckinService_Sync(t *testing.T) {
+	const (
+		eventID = "event-001"
+		scanID  = "1b7d2c0e-5a8f-4f3e-9b6a-2d4c8e1f0a01"
+	)
+	scannedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	later := scannedAt.Add(5 * time.Minute)
+	earlier := scannedAt.Add(-5 * time.Minute)
+	confirmed := &models.TicketOrder{ID: "order-001", EventID: eventID, UserID: "user-001", Status: string(models.OrderStatusConfirmed)}
+	admission := &models.CheckinScan{ID: "scan-online", Result: string(models.ScanResultAdmitted), Source: string(models.ScanSourceOnline)}
+
+	type repos struct {
+		tickets   *mocks.TicketRepository
+		txTickets *mocks.TicketRepository
+		txScans   *mocks.CheckinScanRepository
+		scans     *mocks.CheckinScanRepository
+	}
+	lockTicket := func(r repos, ticket *models.Ticket) {
+		r.tickets.On("FindTicketByCode", mock.Anything, "TKT-ABC").Return(&models.Ticket{ID: "ticket-001", OrderID: "order-001"}, nil)
+		r.txTickets.On("FindTicketByIDForUpdate", mock.Anything, "ticket-001").Return(ticket, nil)
+		r.txTickets.On("FindOrderByID", mock.Anything, "order-001").Return(confirmed, nil)
+	}
+	storesScan := func(store *mocks.CheckinScanRepository, result models.ScanResult, reason string) {
+		store.On("Create", mock.Anything, mock.MatchedBy(func(scan *models.CheckinScan) bool {
+			gotReason := ""
+			if scan.Reason != nil {
+				gotReason = *scan.Reason
+			}
+			return scan.ID == scanID && scan.Result == string(result) && gotReason == reason &&
+				scan.Source == string(models.ScanSourceOffline) && *scan.DeviceID == "gate-a-01"
+		})).Return(nil)
+	}
+
+	tests := []struct {
+		name         string
+		code         string
+		scannedAt    time.Time
+		stored       *models.CheckinScan // already uploaded
+		setup        func(r repos)
+		wantResult   models.ScanResult
+		wantReason   string
+		wantReplayed bool
+		wantOther    bool
+	}{
+		{
+			name: "first scan admits",
+			setup: func(r repos) {
+				lockTicket(r, &models.Ticket{ID: "ticket-001", OrderID: "order-001", Status: string(models.TicketStatusValid)})
+				r.txTickets.On("MarkTicketUsed", mock.Anything, "ticket-001", scannedAt).Return(nil)
+				storesScan(r.txScans, models.ScanResultAdmitted, "")
+			},
+			wantResult: models.ScanResultAdmitted,
+		},
+		{
+			name: "ticket admitted earlier at another gate",
+			setup: func(r repos) {
+				lockTicket(r, &models.Ticket{ID: "ticket-001", OrderID: "order-001", Status: string(models.TicketStatusUsed), UsedAt: &earlier})
+				r.txScans.On("FindAdmission", mock.Anything, "ticket-001").Return(admission, nil)
+				storesScan(r.txScans, models.ScanResultDuplicate, dto.CheckinAlreadyUsed)
+			},
+			wantResult: models.ScanResultDuplicate,
+			wantReason: dto.CheckinAlreadyUsed,
+			wantOther:  true,
+		},
+		{
+			name: "offline scan came before the recorded admission",
+			setup: func(r repos) {
+				lockTicket(r, &models.Ticket{ID: "ticket-001", OrderID: "order-001", Status: string(models.TicketStatusUsed), UsedAt: &later})
+				r.txScans.On("FindAdmission", mock.Anything, "ticket-001").Return(admission, nil)
+				r.txScans.On("UpdateResult", mock.Anything, "scan-online", models.ScanResultDuplicate, dto.CheckinAlreadyUsed).Return(nil)
+				r.txTickets.On("MarkTicketUsed", mock.Anything, "ticket-001", scannedAt).Return(nil)
+				storesScan(r.txScans, models.ScanResultAdmitted, dto.CheckinReplacedLaterScan)
+			},
+			wantResult: models.ScanResultAdmitted,
+			wantReason: dto.CheckinReplacedLaterScan,
+			wantOther:  true,
+		},
+		{
+			name: "ticket cancelled before the upload",
+			setup: func(r repos) {
+				lockTicket(r, &models.Ticket{ID: "ticket-001", OrderID: "order-001", Status: string(models.TicketStatusCancelled)})
+				storesScan(r.txScans, models.ScanResultRejected, dto.CheckinTicketCancelled)
+			},
+			wantResult: models.ScanResultRejected,
+			wantReason: dto.CheckinTicketCancelled,
+		},
+		{
+			name: "unknown code",
+			code: "TKT-NOPE",
+			setup: func(r repos) {
+				r.tickets.On("FindTicketByCode", mock.Anything, "TKT-NOPE").Return(nil, repositories.ErrNotFound)
+				storesScan(r.scans, models.ScanResultRejected, dto.CheckinUnknownTicket)
+			},
+			wantResult: models.ScanResultRejected,
+			wantReason: dto.CheckinUnknownTicket,
+		},
+		{
+			name:      "device clock far ahead",
+			scannedAt: time.Now().Add(time.Hour),
+			setup: func(r repos) {
+				storesScan(r.scans, models.ScanResultRejected, dto.CheckinInvalidScanTime)
+			},
+			wantResult: models.ScanResultRejected,
+			wantReason: dto.CheckinInvalidScanTime,
+		},
+		{
+			name:         "scan uploaded again",
+			stored:       &models.CheckinScan{ID: scanID, Result: string(models.ScanResultAdmitted)},
+			setup:        func(r repos) {},
+			wantResult:   models.ScanResultAdmitted,
+			wantReplayed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := repos{
+				tickets:   mocks.NewTicketRepository(t),
+				txTickets: mocks.NewTicketRepository(t),
+				txScans:   mocks.NewCheckinScanRepository(t),
+				scans:     mocks.NewCheckinScanRepository(t),
+			}
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Tickets: r.txTickets, CheckinScans: r.txScans})
+				}).Maybe()
+			if tt.stored != nil {
+				r.scans.On("FindByID", mock.Anything, scanID).Return(tt.stored, nil)
+			} else {
+				r.scans.On("FindByID", mock.Anything, scanID).Return(nil, repositories.ErrNotFound)
+			}
+			tt.setup(r)
+
+			code, at := tt.code, tt.scannedAt
+			if code == "" {
+				code = "TKT-ABC"
+			}
+			if at.IsZero() {
+				at = scannedAt
+			}
+			key, err := jwtutil.GenerateEd25519Key()
+			require.NoError(t, err)
+			signer, err := ticketpass.NewSigner(key)
+			require.NoError(t, err)
+
+			svc := NewCheckinService(uow, r.tickets, mocks.NewEventRepository(t), mocks.NewUserRepository(t), r.scans, signer,
+				config.TicketPassConfig{Validity: time.Hour}, zerolog.Nop())
+			resp, err := svc.Sync(context.Background(), Actor{UserID: "validator-001"}, &dto.CheckinSyncRequest{
+				EventID:  eventID,
+				DeviceID: "gate-a-01",
+				Scans:    []dto.OfflineScan{{ScanID: scanID, Code: code, ScannedAt: at}},
+			})
+
+			require.NoError(t, err)
+			require.Len(t, resp.Results, 1)
+			assert.Equal(t, string(tt.wantResult), resp.Results[0].Result)
+			assert.Equal(t, tt.wantReason, resp.Results[0].Reason)
+			assert.Equal(t, tt.wantReplayed, resp.Results[0].Replayed)
+			if tt.wantReason == "" {
+				assert.Empty(t, resp.Conflicts)
+				return
+			}
+			require.Len(t, resp.Conflicts, 1)
+			assert.Equal(t, tt.wantReason, resp.Conflicts[0].Reason)
+			assert.NotEmpty(t, resp.Conflicts[0].Message)
+			if tt.wantOther {
+				require.NotNil(t, resp.Conflicts[0].OtherScan)
+				assert.Equal(t, "scan-online", resp.Conflicts[0].OtherScan.ID)
+			}
+		})
+	}
+}
+
+// TestCheckinService_Snapshot
+// Summary: Tests the signed ticket snapshot for offline scanners
+// Purpose: Verify the snapshot verifies with the pass keys, carries code hashes rather than codes,
+// expires with the event's passes, and unknown events are not found
+func TestCheckinService_Snapshot(t *testing.T) {
+	const eventID = "2f6b7c1d-3e4a-4b5c-8d9e-0f1a2b3c4d5e"
+	eventDate := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+	key, err := jwtutil.GenerateEd25519Key()
+	require.NoError(t, err)
+	signer, err := ticketpass.NewSigner(key)
+	require.NoError(t, err)
+
+	eventRepo := mocks.NewEventRepository(t)
+	ticketRepo := mocks.NewTicketRepository(t)
+	eventRepo.On("FindByID", mock.Anything, eventID).Return(&models.Event{ID: eventID, EventDate: eventDate}, nil)
+	eventRepo.On("FindByID", mock.Anything, "6a0c9a55-1111-4c2b-9e0f-000000000000").Return(nil, repositories.ErrNotFound)
+	ticketRepo.On("ListCheckinTickets", mock.Anything, eventID).Return([]*models.Ticket{
+		{ID: "ticket-001", TicketCode: "TKT-ABC", Status: string(models.TicketStatusValid)},
+	}, nil)
+
+	svc := NewCheckinService(mocks.NewUnitOfWork(t), ticketRepo, eventRepo, mocks.NewUserRepository(t), mocks.NewCheckinScanRepository(t),
+		signer, config.TicketPassConfig{Validity: 24 * time.Hour}, zerolog.Nop())
+
+	doc, err := svc.Snapshot(context.Background(), eventID)
+	require.NoError(t, err)
+
+	var snapshot dto.CheckinSnapshot
+	require.NoError(t, signer.Verifier().VerifyDocument(doc, &snapshot))
+	assert.Equal(t, eventID, snapshot.EventID)
+	assert.True(t, snapshot.ExpiresAt.Equal(eventDate.Add(24*time.Hour)))
+	require.Len(t, snapshot.Tickets, 1)
+	assert.Equal(t, ticketpass.HashCode("TKT-ABC"), snapshot.Tickets[0].CodeHash)
+	assert.NotContains(t, doc.Payload, "TKT-ABC")
+
+	_, err = svc.Snapshot(context.Background(), "6a0c9a55-1111-4c2b-9e0f-000000000000")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+	_, err = svc.Snapshot(context.Background(), "not-a-uuid")
+	assert.ErrorIs(t, err, ErrEventNotFound)
+}
@@ -0,0 +1,77 @@
+package services
+
+import "errors"
+
+// Domain errors returned by services. Handlers map these to HTTP status codes.
+var (
+	ErrInvalidCredentials     = errors.New("invalid email or password")
+	ErrEmailAlreadyRegistered = errors.New("email already registered")
+	ErrAccountDisabled        = errors.New("account is disabled")
+	ErrRoleNotSelfAssignable  = errors.New("role cannot be self-assigned at registration")
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrInvalidRefreshToken    = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused     = errors.New("refresh token reuse detected")
+	ErrInvalidToken           = errors.New("invalid or expired token")
+	ErrTokenRevoked           = errors.New("token has been revoked")
+	ErrRoleAlreadyExists      = errors.New("role already exists")
+	ErrRoleNotAssigned        = errors.New("user does not have this role")
+	ErrCannotRevokeOwnAdmin   = errors.New("admins cannot revoke their own admin role")
+	ErrPermissionNotFound     = errors.New("permission not found")
+	ErrPermissionExists       = errors.New("permission already exists")
+	ErrPermissionNotAttached  = errors.New("role does not have this permission")
+	ErrEventNotFound          = errors.New("event not found")
+	ErrNotEventOrganizer      = errors.New("event belongs to another organizer")
+	ErrInvalidEventDate       = errors.New("event_date must be an RFC 3339 timestamp")
+	ErrEventEnded             = errors.New("event has already taken place")
+	ErrEventDateInPast        = errors.New("event_date must be in the future")
+	ErrInvalidDateRange       = errors.New("from must not be after to")
+	ErrInvalidPriceRange      = errors.New("min_price must not be above max_price")
+	ErrInvalidCursor          = errors.New("invalid cursor")
+	ErrAvailableAboveTotal    = errors.New("available_tickets cannot exceed total_tickets")
+	ErrTotalBelowSold         = errors.New("total_tickets cannot be lower than the tickets already sold")
+	ErrEventHasOrders         = errors.New("event has paid, confirmed, refunded or in-progress orders")
+	ErrTierNotFound           = errors.New("ticket tier not found")
+	ErrTierRequired           = errors.New("event sells tickets by tier; tier_id is required")
+	ErrTierNotOnSale          = errors.New("ticket tier is not on sale")
+	ErrTierOrderLimit         = errors.New("quantity exceeds the tier's per-order limit")
+	ErrTierCapacityExceeded   = errors.New("tier capacities exceed the event's total tickets")
+	ErrTierExists             = errors.New("event already has a tier with this name")
+	ErrTierHasOrders          = errors.New("ticket tier has orders")
+	ErrInvalidSalesWindow     = errors.New("sales_end must be after sales_start")
+	ErrSeatMapNotFound        = errors.New("seat map not found")
+	ErrSeatMapTooLarge        = errors.New("seat map has too many seats")
+	ErrDuplicateSeat          = errors.New("seat map lists the same seat twice")
+	ErrSeatMapLocked          = errors.New("seat map cannot change once tickets are taken")
+	ErrSeatedCapacity         = errors.New("ticket counts of a seated event follow its seat map")
+	ErrSeatMapVenueMismatch   = errors.New("seat map belongs to another venue")
+	ErrNotSeated              = errors.New("event has no reserved seating")
+	ErrSeatsRequired          = errors.New("event has reserved seating; seat_ids is required")
+	ErrSeatCountMismatch      = errors.New("quantity must match the number of seats")
+	ErrSeatsUnavailable       = errors.New("one or more seats are not available")
+	ErrVenueNotFound          = errors.New("venue not found")
+	ErrNotVenueOwner          = errors.New("venue belongs to another organizer")
+	ErrInvalidTimeZone        = errors.New("time_zone must be an IANA time zone name")
+	ErrVenueCapacityExceeded  = errors.New("total_tickets exceed the venue's capacity")
+	ErrCapacityBelowEvents    = errors.New("capacity cannot be lower than the total tickets of an event at the venue")
+	ErrVenueHasEvents         = errors.New("venue has events")
+	ErrInsufficientTickets    = errors.New("not enough tickets available")
+	ErrPaymentDeclined        = errors.New("payment was declined")
+	ErrPaymentFailed          = errors.New("payment could not be processed")
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderNotCancellable    = errors.New("order can no longer be cancelled")
+	ErrOrderNotRefundable     = errors.New("order cannot be refunded in its current status")
+	ErrRefundFailed           = errors.New("refund could not be processed")
+	ErrTicketNotFound         = errors.New("ticket not found")
+	ErrTicketNotValid         = errors.New("ticket is not valid for entry")
+	ErrInvalidTicketPass      = errors.New("invalid ticket pass")
+	ErrTicketNotTransferable  = errors.New("ticket cannot be transferred in its current status")
+	ErrTransfersDisabled      = errors.New("ticket transfers are disabled for this event")
+	ErrTransferCutoffPassed   = errors.New("ticket transfers have closed for this event")
+	ErrRecipientNotFound      = errors.New("recipient has no active account")
+	ErrTransferToSelf         = errors.New("ticket cannot be transferred to its own holder")
+	ErrOrderHasTransfers      = errors.New("order has transferred tickets")
+	ErrWebhookNotConfigured   = errors.New("payment webhooks are not configured")
+	ErrInvalidWebhook         = errors.New("invalid webhook payload")
+	ErrBadWebhookSignature    = errors.New("invalid webhook signature")
+)
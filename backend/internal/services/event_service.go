@@ -2,69 +2,771 @@ package services
 
 import (
 	"context"
-	"fmt"
-	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
 	"github.com/baramulti/ticketing-system/backend/internal/models"
 	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
 type EventService interface {
 	GetByID(ctx context.Context, id string) (*models.Event, error)
-	List(ctx context.Context, page, pageSize int) (eventResponse *dto.EventListResponse, err error)
-	Create(ctx context.Context, event *models.Event) error
-	Update(ctx context.Context, event *models.Event) error
-	Delete(ctx context.Context, id string) error
+	// List returns one page of the events matching q, by page number or
+	// after q.Cursor
+	List(ctx context.Context, q *dto.EventListQuery) (*dto.EventListResponse, error)
+	// ListByVenue is List restricted to the events held at a venue
+	ListByVenue(ctx context.Context, venueID string, q *dto.EventListQuery) (*dto.EventListResponse, error)
+	Create(ctx context.Context, actor Actor, req *dto.CreateEventRequest) (*models.Event, error)
+	Update(ctx context.Context, actor Actor, id string, req *dto.UpdateEventRequest) (*models.Event, error)
+	Delete(ctx context.Context, actor Actor, id string) error
+	GetSales(ctx context.Context, actor Actor, id string) (*dto.EventSalesResponse, error)
+	CreateTier(ctx context.Context, actor Actor, eventID string, req *dto.CreateTierRequest) (*models.TicketTier, error)
+	UpdateTier(ctx context.Context, actor Actor, eventID, tierID string, req *dto.UpdateTierRequest) (*models.TicketTier, error)
+	DeleteTier(ctx context.Context, actor Actor, eventID, tierID string) error
+	AssignSeatMap(ctx context.Context, actor Actor, eventID string, req *dto.AssignSeatMapRequest) (*models.Event, error)
 }
 
 type eventService struct {
-	repo repositories.EventRepository
-	log  zerolog.Logger
+	repo       repositories.EventRepository
+	ticketRepo repositories.TicketRepository
+	tierRepo   repositories.TicketTierRepository
+	venueRepo  repositories.VenueRepository
+	uow        repositories.UnitOfWork
+	log        zerolog.Logger
 }
 
-func NewEventService(repo repositories.EventRepository, log zerolog.Logger) EventService {
+func NewEventService(repo repositories.EventRepository, ticketRepo repositories.TicketRepository, tierRepo repositories.TicketTierRepository, venueRepo repositories.VenueRepository, uow repositories.UnitOfWork, log zerolog.Logger) EventService {
 	return &eventService{
-		repo: repo,
-		log:  log,
+		repo:       repo,
+		ticketRepo: ticketRepo,
+		tierRepo:   tierRepo,
+		venueRepo:  venueRepo,
+		uow:        uow,
+		log:        log,
 	}
 }
 
+// GetByID returns the event with its venue and ticket tiers
 func (s *eventService) GetByID(ctx context.Context, id string) (*models.Event, error) {
-	// TODO: fetch event with validation
-	return s.repo.FindByID(ctx, id)
+	if uuid.Validate(id) != nil {
+		return nil, ErrEventNotFound
+	}
+	event, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		s.log.Error().Err(err).Str("event_id", id).Msg("failed to find event")
+		return nil, err
+	}
+	if err := s.attachDetails(ctx, []*models.Event{event}); err != nil {
+		s.log.Error().Err(err).Str("event_id", id).Msg("failed to load event details")
+		return nil, err
+	}
+	return event, nil
 }
 
-func (s *eventService) List(ctx context.Context, page, pageSize int) (eventResponse *dto.EventListResponse, err error) {
-	offset := (page - 1) * pageSize
+func (s *eventService) List(ctx context.Context, q *dto.EventListQuery) (*dto.EventListResponse, error) {
 	// TODO: add caching layer here (Redis)
+	query, err := eventQuery(q)
+	if err != nil {
+		return nil, err
+	}
 
-	data, err := s.repo.List(ctx, pageSize, offset)
+	total, err := s.repo.Count(ctx, query.Filter)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to count events")
+		return nil, err
+	}
+
+	// One extra row tells whether another page follows
+	pageSize := query.Limit
+	query.Limit++
+	events, err := s.repo.List(ctx, query)
 	if err != nil {
 		s.log.Error().Err(err).Msg("failed get list of events")
 		return nil, err
 	}
-	eventResponse = &dto.EventListResponse{
-		Events: data,
-		Total:  len(data),
-		Page:   page,
+
+	resp := &dto.EventListResponse{Total: total, PageSize: pageSize}
+	if query.After == nil {
+		resp.Page = query.Offset/pageSize + 1
+	}
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		resp.NextCursor = encodeEventCursor(query.SortBy, query.SortDesc, events[pageSize-1])
+	}
+	if err := s.attachDetails(ctx, events); err != nil {
+		s.log.Error().Err(err).Msg("failed to load event details")
+		return nil, err
+	}
+	resp.Events = events
+	return resp, nil
+}
+
+func (s *eventService) ListByVenue(ctx context.Context, venueID string, q *dto.EventListQuery) (*dto.EventListResponse, error) {
+	if uuid.Validate(venueID) != nil {
+		return nil, ErrVenueNotFound
+	}
+	if _, err := s.venueRepo.FindByID(ctx, venueID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrVenueNotFound
+		}
+		return nil, err
+	}
+
+	venueQuery := *q
+	venueQuery.VenueID = venueID
+	return s.List(ctx, &venueQuery)
+}
+
+// Create stores a new event owned by the acting organizer (or admin). Its
+// total tickets must fit in the venue.
+func (s *eventService) Create(ctx context.Context, actor Actor, req *dto.CreateEventRequest) (*models.Event, error) {
+	eventDate, err := parseEventDate(req.EventDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	organizerID := actor.UserID
+	event := &models.Event{
+		Title:            req.Title,
+		Description:      req.Description,
+		EventDate:        eventDate,
+		VenueID:          req.VenueID,
+		TicketPrice:      *req.TicketPrice,
+		TotalTickets:     req.TotalTickets,
+		AvailableTickets: *req.AvailableTickets,
+		OrganizerID:      &organizerID,
+		TransfersEnabled: true,
+	}
+	if req.TransfersEnabled != nil {
+		event.TransfersEnabled = *req.TransfersEnabled
+	}
+	if event.AvailableTickets > event.TotalTickets {
+		return nil, ErrAvailableAboveTotal
+	}
+
+	err = s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		if err := checkVenueCapacity(ctx, tx, event.VenueID, event.TotalTickets); err != nil {
+			return err
+		}
+		return tx.Events.Create(ctx, event)
+	})
+	if err != nil {
+		if !isEventClientError(err) {
+			s.log.Error().Err(err).Str("organizer_id", actor.UserID).Msg("failed to create event")
+		}
+		return nil, err
+	}
+
+	s.log.Info().Str("event_id", event.ID).Str("organizer_id", actor.UserID).Msg("event created")
+	return event, nil
+}
+
+// Update applies the fields present in req to an event the actor manages.
+// The event row stays locked meanwhile, so purchases cannot change
+// available_tickets underneath it.
+func (s *eventService) Update(ctx context.Context, actor Actor, id string, req *dto.UpdateEventRequest) (*models.Event, error) {
+	var event *models.Event
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		var err error
+		event, err = s.lockManagedEvent(ctx, tx, actor, id)
+		if err != nil {
+			return err
+		}
+		if event.SeatMapID != nil && req.TotalTickets != nil {
+			return ErrSeatedCapacity
+		}
+		// A seated event's layout is its venue's, so it cannot move
+		if event.SeatMapID != nil && req.VenueID != nil && *req.VenueID != event.VenueID {
+			return ErrSeatMapVenueMismatch
+		}
+		if err := applyEventUpdate(event, req, time.Now()); err != nil {
+			return err
+		}
+		if req.VenueID != nil || req.TotalTickets != nil {
+			if err := checkVenueCapacity(ctx, tx, event.VenueID, event.TotalTickets); err != nil {
+				return err
+			}
+		}
+		if req.TotalTickets != nil {
+			tiers, err := tx.Tiers.ListByEventID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if tierCapacity(tiers, "") > event.TotalTickets {
+				return ErrTierCapacityExceeded
+			}
+		}
+		return tx.Events.Update(ctx, event)
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		if !isEventClientError(err) {
+			s.log.Error().Err(err).Str("event_id", id).Msg("failed to update event")
+		}
+		return nil, err
+	}
+
+	s.log.Info().Str("event_id", id).Str("actor_id", actor.UserID).Msg("event updated")
+	return event, nil
+}
+
+// Delete removes an event the actor manages, with its cancelled and expired
+// orders. Events with paid, confirmed or refunded orders are kept, so no
+// payment or refund history is lost, and so are events with live holds,
+// whose purchases may still be charged.
+func (s *eventService) Delete(ctx context.Context, actor Actor, id string) error {
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		if _, err := s.lockManagedEvent(ctx, tx, actor, id); err != nil {
+			return err
+		}
+		sold, err := tx.Tickets.HasSoldOrders(ctx, id)
+		if err != nil {
+			return err
+		}
+		if sold {
+			return ErrEventHasOrders
+		}
+		held, err := tx.Tickets.HasActiveHolds(ctx, id)
+		if err != nil {
+			return err
+		}
+		if held {
+			return ErrEventHasOrders
+		}
+		if err := tx.Tickets.DeleteUnsoldOrdersByEvent(ctx, id); err != nil {
+			return err
+		}
+		return tx.Events.Delete(ctx, id)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotFound):
+			return ErrEventNotFound
+		case errors.Is(err, repositories.ErrForeignKeyViolation):
+			// An order was paid after the check above
+			return ErrEventHasOrders
+		case !isEventClientError(err):
+			s.log.Error().Err(err).Str("event_id", id).Msg("failed to delete event")
+		}
+		return err
+	}
+
+	s.log.Info().Str("event_id", id).Str("actor_id", actor.UserID).Msg("event deleted")
+	return nil
+}
+
+// GetSales reports order totals for an event the actor manages
+func (s *eventService) GetSales(ctx context.Context, actor Actor, id string) (*dto.EventSalesResponse, error) {
+	if _, err := s.findManagedEvent(ctx, actor, id); err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.ticketRepo.SummarizeOrdersByEvent(ctx, id)
+	if err != nil {
+		s.log.Error().Err(err).Str("event_id", id).Msg("failed to summarize event sales")
+		return nil, err
+	}
+
+	sales := &dto.EventSalesResponse{EventID: id, ByStatus: summaries}
+	for _, summary := range summaries {
+		switch models.TicketOrderStatus(summary.Status) {
+		case models.OrderStatusPaid, models.OrderStatusConfirmed:
+			sales.TicketsSold += summary.Tickets
+			sales.Revenue += summary.Revenue
+		}
+	}
+	return sales, nil
+}
+
+// CreateTier adds a ticket tier to an event the actor manages. The tiers'
+// capacities together cannot exceed the event's total tickets.
+func (s *eventService) CreateTier(ctx context.Context, actor Actor, eventID string, req *dto.CreateTierRequest) (*models.TicketTier, error) {
+	tier := &models.TicketTier{
+		EventID:          eventID,
+		Name:             req.Name,
+		Price:            *req.Price,
+		TotalTickets:     req.TotalTickets,
+		AvailableTickets: req.TotalTickets,
+		SalesStart:       utcTime(req.SalesStart),
+		SalesEnd:         utcTime(req.SalesEnd),
+		MaxPerOrder:      req.MaxPerOrder,
+	}
+	if !validSalesWindow(tier) {
+		return nil, ErrInvalidSalesWindow
+	}
+
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		event, err := s.lockManagedEvent(ctx, tx, actor, eventID)
+		if err != nil {
+			return err
+		}
+		tiers, err := tx.Tiers.ListByEventID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if tierCapacity(tiers, "")+tier.TotalTickets > event.TotalTickets {
+			return ErrTierCapacityExceeded
+		}
+		return tx.Tiers.Create(ctx, tier)
+	})
+	if err != nil {
+		return nil, s.tierError(err, eventID, "failed to create ticket tier")
+	}
+
+	s.log.Info().Str("event_id", eventID).Str("tier_id", tier.ID).Str("actor_id", actor.UserID).Msg("ticket tier created")
+	return tier, nil
+}
+
+// UpdateTier applies the fields present in req to one of the event's tiers.
+// Changing the total keeps the tickets already sold or held, like events do.
+func (s *eventService) UpdateTier(ctx context.Context, actor Actor, eventID, tierID string, req *dto.UpdateTierRequest) (*models.TicketTier, error) {
+	var tier *models.TicketTier
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		event, err := s.lockManagedEvent(ctx, tx, actor, eventID)
+		if err != nil {
+			return err
+		}
+		tiers, err := tx.Tiers.ListByEventID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if tier = findTier(tiers, tierID); tier == nil {
+			return ErrTierNotFound
+		}
+		if err := applyTierUpdate(tier, req); err != nil {
+			return err
+		}
+		if tierCapacity(tiers, tierID)+tier.TotalTickets > event.TotalTickets {
+			return ErrTierCapacityExceeded
+		}
+		return tx.Tiers.Update(ctx, tier)
+	})
+	if err != nil {
+		return nil, s.tierError(err, eventID, "failed to update ticket tier")
+	}
+
+	s.log.Info().Str("event_id", eventID).Str("tier_id", tierID).Str("actor_id", actor.UserID).Msg("ticket tier updated")
+	return tier, nil
+}
+
+// DeleteTier removes a tier nobody has ordered from
+func (s *eventService) DeleteTier(ctx context.Context, actor Actor, eventID, tierID string) error {
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		if _, err := s.lockManagedEvent(ctx, tx, actor, eventID); err != nil {
+			return err
+		}
+		tiers, err := tx.Tiers.ListByEventID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if findTier(tiers, tierID) == nil {
+			return ErrTierNotFound
+		}
+		return tx.Tiers.Delete(ctx, tierID)
+	})
+	if err != nil {
+		return s.tierError(err, eventID, "failed to delete ticket tier")
+	}
+
+	s.log.Info().Str("event_id", eventID).Str("tier_id", tierID).Str("actor_id", actor.UserID).Msg("ticket tier deleted")
+	return nil
+}
+
+// AssignSeatMap switches an event to reserved seating with the given map.
+// Its ticket counts become the number of seats, so this is only allowed
+// before any ticket is taken.
+func (s *eventService) AssignSeatMap(ctx context.Context, actor Actor, eventID string, req *dto.AssignSeatMapRequest) (*models.Event, error) {
+	var event *models.Event
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		var err error
+		event, err = s.lockManagedEvent(ctx, tx, actor, eventID)
+		if err != nil {
+			return err
+		}
+		if event.AvailableTickets != event.TotalTickets {
+			return ErrSeatMapLocked
+		}
+		seatMap, err := tx.Seats.FindSeatMapByID(ctx, req.SeatMapID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return ErrSeatMapNotFound
+			}
+			return err
+		}
+		if seatMap.VenueID != event.VenueID {
+			return ErrSeatMapVenueMismatch
+		}
+
+		seats, err := tx.Seats.ReplaceEventSeats(ctx, eventID, req.SeatMapID)
+		if err != nil {
+			return err
+		}
+		tiers, err := tx.Tiers.ListByEventID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if tierCapacity(tiers, "") > seats {
+			return ErrTierCapacityExceeded
+		}
+		if err := checkVenueCapacity(ctx, tx, event.VenueID, seats); err != nil {
+			return err
+		}
+
+		event.SeatMapID = &req.SeatMapID
+		event.TotalTickets = seats
+		event.AvailableTickets = seats
+		return tx.Events.Update(ctx, event)
+	})
+	if err != nil {
+		if !isEventClientError(err) {
+			s.log.Error().Err(err).Str("event_id", eventID).Msg("failed to assign seat map")
+		}
+		return nil, err
+	}
+
+	s.log.Info().Str("event_id", eventID).Str("seat_map_id", req.SeatMapID).Int("seats", event.TotalTickets).
+		Str("actor_id", actor.UserID).Msg("seat map assigned")
+	return event, nil
+}
+
+// tierError maps repository errors from the tier writes to service errors
+// and logs the ones that are not the caller's mistake
+func (s *eventService) tierError(err error, eventID, msg string) error {
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		return ErrTierNotFound
+	case errors.Is(err, repositories.ErrDuplicate):
+		return ErrTierExists
+	case errors.Is(err, repositories.ErrForeignKeyViolation):
+		return ErrTierHasOrders
+	case !isEventClientError(err):
+		s.log.Error().Err(err).Str("event_id", eventID).Msg(msg)
+	}
+	return err
+}
+
+// attachDetails loads the venues and tiers of the given events onto them
+func (s *eventService) attachDetails(ctx context.Context, events []*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	byID := make(map[string]*models.Event, len(events))
+	ids := make([]string, 0, len(events))
+	byVenueID := make(map[string]*models.Venue)
+	venueIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		byID[event.ID] = event
+		ids = append(ids, event.ID)
+		if _, ok := byVenueID[event.VenueID]; !ok {
+			byVenueID[event.VenueID] = nil
+			venueIDs = append(venueIDs, event.VenueID)
+		}
+	}
+
+	venues, err := s.venueRepo.ListByIDs(ctx, venueIDs)
+	if err != nil {
+		return err
+	}
+	for _, venue := range venues {
+		byVenueID[venue.ID] = venue
+	}
+	for _, event := range events {
+		event.Venue = byVenueID[event.VenueID]
+	}
+
+	tiers, err := s.tierRepo.ListByEventIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, tier := range tiers {
+		if event, ok := byID[tier.EventID]; ok {
+			event.Tiers = append(event.Tiers, tier)
+		}
+	}
+	return nil
+}
+
+// checkVenueCapacity checks total tickets fit in the venue, which stays
+// locked until the transaction ends so its capacity cannot shrink meanwhile
+func checkVenueCapacity(ctx context.Context, tx repositories.TxRepositories, venueID string, total int) error {
+	venue, err := tx.Venues.FindByIDForUpdate(ctx, venueID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrVenueNotFound
+		}
+		return err
+	}
+	if total > venue.Capacity {
+		return ErrVenueCapacityExceeded
+	}
+	return nil
+}
+
+// findManagedEvent loads an event and checks the actor may manage it
+func (s *eventService) findManagedEvent(ctx context.Context, actor Actor, id string) (*models.Event, error) {
+	if uuid.Validate(id) != nil {
+		return nil, ErrEventNotFound
+	}
+	event, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	if err := s.authorizeOrganizer(actor, event); err != nil {
+		return nil, err
 	}
+	return event, nil
+}
 
-	return eventResponse, err
+// lockManagedEvent is findManagedEvent inside a transaction, with the event
+// row locked until it ends
+func (s *eventService) lockManagedEvent(ctx context.Context, tx repositories.TxRepositories, actor Actor, id string) (*models.Event, error) {
+	if uuid.Validate(id) != nil {
+		return nil, ErrEventNotFound
+	}
+	event, err := tx.Events.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	if err := s.authorizeOrganizer(actor, event); err != nil {
+		return nil, err
+	}
+	return event, nil
 }
 
-func (s *eventService) Create(ctx context.Context, event *models.Event) error {
-	// TODO: validate and create event
-	// set timestamps, generate ID, etc.
-	return fmt.Errorf("not implemented")
+// authorizeOrganizer checks the actor may manage the event: admins manage
+// every event, organizers only the ones they created. Events without an
+// organizer are admin-managed.
+func (s *eventService) authorizeOrganizer(actor Actor, event *models.Event) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	if event.OrganizerID == nil || *event.OrganizerID != actor.UserID {
+		s.log.Warn().Str("event_id", event.ID).Str("actor_id", actor.UserID).Msg("event access denied: not the organizer")
+		return ErrNotEventOrganizer
+	}
+	return nil
 }
 
-func (s *eventService) Update(ctx context.Context, event *models.Event) error {
-	// TODO: validate event exists before update
-	return fmt.Errorf("not implemented")
+// applyEventUpdate copies the fields present in req onto event. Changing the
+// total keeps the number of tickets already sold or held, moving
+// available_tickets by the same amount; it is never set directly.
+func applyEventUpdate(event *models.Event, req *dto.UpdateEventRequest, now time.Time) error {
+	if req.Title != nil {
+		event.Title = *req.Title
+	}
+	if req.Description != nil {
+		event.Description = *req.Description
+	}
+	if req.EventDate != nil {
+		eventDate, err := parseEventDate(*req.EventDate, now)
+		if err != nil {
+			return err
+		}
+		event.EventDate = eventDate
+	}
+	if req.VenueID != nil {
+		event.VenueID = *req.VenueID
+	}
+	if req.TicketPrice != nil {
+		event.TicketPrice = *req.TicketPrice
+	}
+	if req.TransfersEnabled != nil {
+		event.TransfersEnabled = *req.TransfersEnabled
+	}
+
+	if req.TotalTickets != nil {
+		taken := event.TotalTickets - event.AvailableTickets
+		event.TotalTickets = *req.TotalTickets
+		event.AvailableTickets = event.TotalTickets - taken
+		if event.AvailableTickets < 0 {
+			return ErrTotalBelowSold
+		}
+	}
+	return nil
 }
 
-func (s *eventService) Delete(ctx context.Context, id string) error {
-	// TODO: check for active tickets before deletion
-	return fmt.Errorf("not implemented")
+// applyTierUpdate copies the fields present in req onto tier
+func applyTierUpdate(tier *models.TicketTier, req *dto.UpdateTierRequest) error {
+	if req.Name != nil {
+		tier.Name = *req.Name
+	}
+	if req.Price != nil {
+		tier.Price = *req.Price
+	}
+	if req.SalesStart != nil {
+		tier.SalesStart = utcTime(req.SalesStart)
+	}
+	if req.SalesEnd != nil {
+		tier.SalesEnd = utcTime(req.SalesEnd)
+	}
+	if req.MaxPerOrder != nil {
+		tier.MaxPerOrder = req.MaxPerOrder
+	}
+	if req.TotalTickets != nil {
+		taken := tier.TotalTickets - tier.AvailableTickets
+		tier.TotalTickets = *req.TotalTickets
+		tier.AvailableTickets = tier.TotalTickets - taken
+		if tier.AvailableTickets < 0 {
+			return ErrTotalBelowSold
+		}
+	}
+	if !validSalesWindow(tier) {
+		return ErrInvalidSalesWindow
+	}
+	return nil
+}
+
+// validSalesWindow reports whether the tier's sale window, if bounded on
+// both sides, ends after it starts
+func validSalesWindow(tier *models.TicketTier) bool {
+	return tier.SalesStart == nil || tier.SalesEnd == nil || tier.SalesEnd.After(*tier.SalesStart)
+}
+
+// tierCapacity sums the total tickets of tiers, leaving out the tier with
+// id skipID
+func tierCapacity(tiers []*models.TicketTier, skipID string) int {
+	total := 0
+	for _, tier := range tiers {
+		if tier.ID != skipID {
+			total += tier.TotalTickets
+		}
+	}
+	return total
+}
+
+func findTier(tiers []*models.TicketTier, id string) *models.TicketTier {
+	for _, tier := range tiers {
+		if tier.ID == id {
+			return tier
+		}
+	}
+	return nil
+}
+
+// utcTime returns t in UTC; timestamps are stored without a time zone
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// eventQuery turns the listing parameters into a repository query. A cursor
+// must come from a listing with the same sort.
+func eventQuery(q *dto.EventListQuery) (models.EventQuery, error) {
+	page, pageSize := pageBounds(q.Page, q.PageSize)
+	query := models.EventQuery{
+		Filter: models.EventFilter{
+			From:      utcTime(q.From),
+			To:        utcTime(q.To),
+			VenueID:   q.VenueID,
+			MinPrice:  q.MinPrice,
+			MaxPrice:  q.MaxPrice,
+			Available: q.Available,
+		},
+		SortBy:   strings.TrimPrefix(q.Sort, "-"),
+		SortDesc: strings.HasPrefix(q.Sort, "-"),
+		Limit:    pageSize,
+		Offset:   (page - 1) * pageSize,
+	}
+	if query.SortBy == "" {
+		query.SortBy = models.EventSortDate
+	}
+
+	filter := query.Filter
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return query, ErrInvalidDateRange
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return query, ErrInvalidPriceRange
+	}
+
+	if q.Cursor != "" {
+		after, err := decodeEventCursor(q.Cursor, query.SortBy, query.SortDesc)
+		if err != nil {
+			return query, err
+		}
+		query.After = after
+	}
+	return query, nil
+}
+
+// eventCursor is the JSON behind an opaque listing cursor. It records the
+// sort it was issued for, so it cannot be replayed against another order.
+type eventCursor struct {
+	Sort  string    `json:"s"`
+	Date  time.Time `json:"d"`
+	Price float64   `json:"p"`
+	ID    string    `json:"id"`
+}
+
+func encodeEventCursor(sortBy string, desc bool, last *models.Event) string {
+	if desc {
+		sortBy = "-" + sortBy
+	}
+	data, _ := json.Marshal(eventCursor{Sort: sortBy, Date: last.EventDate, Price: last.TicketPrice, ID: last.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeEventCursor(value, sortBy string, desc bool) (*models.EventCursor, error) {
+	if desc {
+		sortBy = "-" + sortBy
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor eventCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sortBy || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &models.EventCursor{EventDate: cursor.Date, TicketPrice: cursor.Price, ID: cursor.ID}, nil
+}
+
+// parseEventDate parses an RFC 3339 timestamp, which always carries a UTC
+// offset, and returns it in UTC: event_date is stored without a time zone.
+func parseEventDate(value string, now time.Time) (time.Time, error) {
+	eventDate, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidEventDate
+	}
+	if !eventDate.After(now) {
+		return time.Time{}, ErrEventDateInPast
+	}
+	return eventDate.UTC(), nil
+}
+
+// isEventClientError reports whether err is the caller's mistake rather than a failure
+func isEventClientError(err error) bool {
+	for _, target := range []error{
+		ErrEventNotFound, ErrNotEventOrganizer, ErrInvalidEventDate, ErrEventDateInPast,
+		ErrAvailableAboveTotal, ErrTotalBelowSold, ErrEventHasOrders,
+		ErrTierNotFound, ErrTierCapacityExceeded, ErrTierExists, ErrTierHasOrders, ErrInvalidSalesWindow,
+		ErrSeatMapNotFound, ErrSeatMapLocked, ErrSeatedCapacity, ErrSeatMapVenueMismatch,
+		ErrVenueNotFound, ErrVenueCapacityExceeded,
+		ErrInvalidDateRange, ErrInvalidPriceRange, ErrInvalidCursor,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
 }
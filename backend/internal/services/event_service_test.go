@@ -0,0 +1,660 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestEventService_Create
+// Summary: Tests creating events from organizer requests
+// Purpose: Verify event dates are stored in UTC, past dates and malformed timestamps are refused,
+// available tickets cannot exceed the total, the total must fit in the venue, and transfers are on
+// unless turned off
+func TestEventService_Create(t *testing.T) {
+	nextYear := time.Now().Year() + 1
+	noTransfers := false
+
+	tests := []struct {
+		name          string
+		eventDate     string
+		available     int
+		total         int
+		venueErr      error
+		transfers     *bool
+		expectedDate  time.Time
+		expectedErr   error
+		wantTransfers bool
+	}{
+		{
+			name:          "offset is converted to UTC",
+			eventDate:     time.Date(nextYear, 3, 14, 9, 0, 0, 0, time.FixedZone("WIB", 7*3600)).Format(time.RFC3339),
+			available:     500,
+			expectedDate:  time.Date(nextYear, 3, 14, 2, 0, 0, 0, time.UTC),
+			wantTransfers: true,
+		},
+		{
+			name:         "transfers turned off",
+			eventDate:    time.Date(nextYear, 3, 14, 2, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			available:    500,
+			transfers:    &noTransfers,
+			expectedDate: time.Date(nextYear, 3, 14, 2, 0, 0, 0, time.UTC),
+		},
+		{name: "timestamp without offset", eventDate: "2030-03-14T09:00:00", available: 500, expectedErr: ErrInvalidEventDate},
+		{name: "date in the past", eventDate: "2020-03-14T09:00:00+07:00", available: 500, expectedErr: ErrEventDateInPast},
+		{
+			name:        "available above total",
+			eventDate:   time.Date(nextYear, 3, 14, 2, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			available:   501,
+			expectedErr: ErrAvailableAboveTotal,
+		},
+		{
+			name:        "total above the venue's capacity",
+			eventDate:   time.Date(nextYear, 3, 14, 2, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			available:   500,
+			total:       1001,
+			expectedErr: ErrVenueCapacityExceeded,
+		},
+		{
+			name:        "unknown venue",
+			eventDate:   time.Date(nextYear, 3, 14, 2, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			available:   500,
+			venueErr:    repositories.ErrNotFound,
+			expectedErr: ErrVenueNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.total == 0 {
+				tt.total = 500
+			}
+			price := 250000.0
+			eventRepo := mocks.NewEventRepository(t)
+			venueRepo := mocks.NewVenueRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+
+			// Dates and counts are checked before the venue is looked up
+			if tt.venueErr != nil || tt.expectedErr == nil || tt.expectedErr == ErrVenueCapacityExceeded {
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Events: eventRepo, Venues: venueRepo})
+					},
+				).Once()
+				if tt.venueErr != nil {
+					venueRepo.On("FindByIDForUpdate", mock.Anything, "venue-001").Return(nil, tt.venueErr).Once()
+				} else {
+					venueRepo.On("FindByIDForUpdate", mock.Anything, "venue-001").Return(&models.Venue{ID: "venue-001", Capacity: 1000}, nil).Once()
+				}
+			}
+			if tt.expectedErr == nil {
+				eventRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Event")).Return(nil).Once()
+			}
+
+			svc := NewEventService(mocks.NewEventRepository(t), mocks.NewTicketRepository(t), mocks.NewTicketTierRepository(t), mocks.NewVenueRepository(t), uow, zerolog.Nop())
+			got, err := svc.Create(context.Background(), Actor{UserID: "organizer-001"}, &dto.CreateEventRequest{
+				Title:            "Jakarta Tech Conference",
+				EventDate:        tt.eventDate,
+				VenueID:          "venue-001",
+				TicketPrice:      &price,
+				TotalTickets:     tt.total,
+				AvailableTickets: &tt.available,
+				TransfersEnabled: tt.transfers,
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDate, got.EventDate)
+			assert.Equal(t, time.UTC, got.EventDate.Location())
+			assert.Equal(t, tt.wantTransfers, got.TransfersEnabled)
+			assert.Equal(t, "organizer-001", *got.OrganizerID)
+			assert.Equal(t, "venue-001", got.VenueID)
+		})
+	}
+}
+
+// TestEventService_Update
+// Summary: Tests partial event updates
+// Purpose: Verify only the fields present change, a new total keeps the tickets already taken,
+// and totals below them, below the tiers' capacity, above the venue's capacity or availability above
+// the total are refused, as are ticket count changes on seated events
+func TestEventService_Update(t *testing.T) {
+	organizerID := "organizer-001"
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+
+	tests := []struct {
+		name              string
+		req               dto.UpdateEventRequest
+		tierCapacity      int
+		seated            bool
+		expectedTotal     int
+		expectedAvailable int
+		expectedErr       error
+	}{
+		{name: "title only", req: dto.UpdateEventRequest{Title: strPtr("Renamed")}, expectedTotal: 500, expectedAvailable: 380},
+		{name: "description cleared", req: dto.UpdateEventRequest{Description: strPtr("")}, expectedTotal: 500, expectedAvailable: 380},
+		{name: "more tickets", req: dto.UpdateEventRequest{TotalTickets: intPtr(600)}, expectedTotal: 600, expectedAvailable: 480},
+		{name: "fewer tickets", req: dto.UpdateEventRequest{TotalTickets: intPtr(150)}, expectedTotal: 150, expectedAvailable: 30},
+		{name: "total below taken", req: dto.UpdateEventRequest{TotalTickets: intPtr(100)}, expectedErr: ErrTotalBelowSold},
+		{name: "total below the tiers", req: dto.UpdateEventRequest{TotalTickets: intPtr(300)}, tierCapacity: 400, expectedErr: ErrTierCapacityExceeded},
+		{name: "date in the past", req: dto.UpdateEventRequest{EventDate: strPtr("2020-03-14T09:00:00+07:00")}, expectedErr: ErrEventDateInPast},
+		{name: "total above the venue", req: dto.UpdateEventRequest{TotalTickets: intPtr(1001)}, expectedErr: ErrVenueCapacityExceeded},
+		{name: "moved to another venue", req: dto.UpdateEventRequest{VenueID: strPtr("venue-002")}, expectedTotal: 500, expectedAvailable: 380},
+		{name: "seated event total", req: dto.UpdateEventRequest{TotalTickets: intPtr(600)}, seated: true, expectedErr: ErrSeatedCapacity},
+		{name: "seated event title", req: dto.UpdateEventRequest{Title: strPtr("Renamed")}, seated: true, expectedTotal: 500, expectedAvailable: 380},
+		{name: "seated event moved to another venue", req: dto.UpdateEventRequest{VenueID: strPtr("venue-002")}, seated: true, expectedErr: ErrSeatMapVenueMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &models.Event{
+				ID:               "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e",
+				Title:            "Jakarta Tech Conference",
+				Description:      "Talks and workshops",
+				EventDate:        time.Now().Add(30 * 24 * time.Hour).UTC(),
+				TotalTickets:     500,
+				AvailableTickets: 380,
+				VenueID:          "venue-001",
+				OrganizerID:      &organizerID,
+			}
+			if tt.seated {
+				seatMapID := "seat-map-001"
+				event.SeatMapID = &seatMapID
+			}
+
+			eventRepo := mocks.NewEventRepository(t)
+			tierRepo := mocks.NewTicketTierRepository(t)
+			venueRepo := mocks.NewVenueRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: eventRepo, Tiers: tierRepo, Venues: venueRepo})
+				},
+			).Once()
+			eventRepo.On("FindByIDForUpdate", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(event, nil).Once()
+			checksVenue := (tt.req.TotalTickets != nil || tt.req.VenueID != nil) && tt.expectedErr != ErrTotalBelowSold && !tt.seated
+			if checksVenue {
+				venueID := "venue-001"
+				if tt.req.VenueID != nil {
+					venueID = *tt.req.VenueID
+				}
+				venueRepo.On("FindByIDForUpdate", mock.Anything, venueID).Return(&models.Venue{ID: venueID, Capacity: 1000}, nil).Once()
+			}
+			if checksVenue && tt.req.TotalTickets != nil && tt.expectedErr != ErrVenueCapacityExceeded {
+				tierRepo.On("ListByEventID", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").
+					Return([]*models.TicketTier{{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", TotalTickets: tt.tierCapacity}}, nil).Once()
+			}
+			if tt.expectedErr == nil {
+				eventRepo.On("Update", mock.Anything, event).Return(nil).Once()
+			}
+
+			svc := NewEventService(mocks.NewEventRepository(t), mocks.NewTicketRepository(t), mocks.NewTicketTierRepository(t), mocks.NewVenueRepository(t), uow, zerolog.Nop())
+			got, err := svc.Update(context.Background(), Actor{UserID: organizerID}, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", &tt.req)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTotal, got.TotalTickets)
+			assert.Equal(t, tt.expectedAvailable, got.AvailableTickets)
+			if tt.req.Title == nil {
+				assert.Equal(t, "Jakarta Tech Conference", got.Title)
+			}
+			if tt.req.Description != nil {
+				assert.Empty(t, got.Description)
+			}
+		})
+	}
+}
+
+// TestEventService_Delete
+// Summary: Tests deleting events
+// Purpose: Verify cancelled and expired orders are removed with the event, and events with paid, confirmed or
+// refunded orders, or with pending orders still on hold, are kept, including when an order is paid between the
+// check and the delete
+func TestEventService_Delete(t *testing.T) {
+	organizerID := "organizer-001"
+
+	tests := []struct {
+		name        string
+		sold        bool
+		held        bool
+		deleteErr   error
+		expectedErr error
+	}{
+		{name: "no sold orders"},
+		{name: "sold orders", sold: true, expectedErr: ErrEventHasOrders},
+		{name: "purchase still on hold", held: true, expectedErr: ErrEventHasOrders},
+		{name: "order paid meanwhile", deleteErr: repositories.ErrForeignKeyViolation, expectedErr: ErrEventHasOrders},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := mocks.NewEventRepository(t)
+			ticketRepo := mocks.NewTicketRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: eventRepo, Tickets: ticketRepo})
+				},
+			).Once()
+			eventRepo.On("FindByIDForUpdate", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").
+				Return(&models.Event{ID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", OrganizerID: &organizerID}, nil).Once()
+			ticketRepo.On("HasSoldOrders", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(tt.sold, nil).Once()
+			if !tt.sold {
+				ticketRepo.On("HasActiveHolds", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(tt.held, nil).Once()
+			}
+			if !tt.sold && !tt.held {
+				ticketRepo.On("DeleteUnsoldOrdersByEvent", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(nil).Once()
+				eventRepo.On("Delete", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(tt.deleteErr).Once()
+			}
+
+			svc := NewEventService(mocks.NewEventRepository(t), mocks.NewTicketRepository(t), mocks.NewTicketTierRepository(t), mocks.NewVenueRepository(t), uow, zerolog.Nop())
+			err := svc.Delete(context.Background(), Actor{UserID: organizerID}, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestEventService_CreateTier
+// Summary: Tests adding ticket tiers to an event
+// Purpose: Verify a new tier starts with all its tickets available and a UTC sale window, and that
+// windows ending before they start, capacity beyond the event's total and duplicate names are refused
+func TestEventService_CreateTier(t *testing.T) {
+	organizerID := "organizer-001"
+	price := 750000.0
+	wib := time.FixedZone("WIB", 7*3600)
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, wib)
+	end := time.Date(2030, 2, 1, 9, 0, 0, 0, wib)
+
+	tests := []struct {
+		name        string
+		total       int
+		start, end  *time.Time
+		createErr   error
+		expectedErr error
+	}{
+		{name: "tier within capacity", total: 100, start: &start, end: &end},
+		{name: "fills the event", total: 200},
+		{name: "beyond the event's capacity", total: 201, expectedErr: ErrTierCapacityExceeded},
+		{name: "window ending before it starts", total: 100, start: &end, end: &start, expectedErr: ErrInvalidSalesWindow},
+		{name: "duplicate name", total: 100, createErr: repositories.ErrDuplicate, expectedErr: ErrTierExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := mocks.NewEventRepository(t)
+			tierRepo := mocks.NewTicketTierRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			if tt.expectedErr != ErrInvalidSalesWindow {
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Events: eventRepo, Tiers: tierRepo})
+					},
+				).Once()
+				eventRepo.On("FindByIDForUpdate", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").
+					Return(&models.Event{ID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", TotalTickets: 500, OrganizerID: &organizerID}, nil).Once()
+				tierRepo.On("ListByEventID", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").
+					Return([]*models.TicketTier{{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", Name: "Regular", TotalTickets: 300}}, nil).Once()
+			}
+			if tt.expectedErr == nil || tt.createErr != nil {
+				tierRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.TicketTier")).Return(tt.createErr).Once()
+			}
+
+			svc := NewEventService(mocks.NewEventRepository(t), mocks.NewTicketRepository(t), mocks.NewTicketTierRepository(t), mocks.NewVenueRepository(t), uow, zerolog.Nop())
+			got, err := svc.CreateTier(context.Background(), Actor{UserID: organizerID}, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", &dto.CreateTierRequest{
+				Name:         "VIP",
+				Price:        &price,
+				TotalTickets: tt.total,
+				SalesStart:   tt.start,
+				SalesEnd:     tt.end,
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.total, got.AvailableTickets)
+			if tt.start != nil {
+				assert.Equal(t, time.UTC, got.SalesStart.Location())
+				assert.True(t, got.SalesStart.Equal(start))
+			}
+		})
+	}
+}
+
+// TestEventService_UpdateTier
+// Summary: Tests partial tier updates
+// Purpose: Verify a new total keeps the tier's tickets already taken, the other tiers' capacity counts
+// against the event's total, and tiers of other events are not found
+func TestEventService_UpdateTier(t *testing.T) {
+	organizerID := "organizer-001"
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name              string
+		tierID            string
+		req               dto.UpdateTierRequest
+		expectedAvailable int
+		expectedErr       error
+	}{
+		{name: "more tickets", tierID: "tier-002", req: dto.UpdateTierRequest{TotalTickets: intPtr(150)}, expectedAvailable: 130},
+		{name: "total below taken", tierID: "tier-002", req: dto.UpdateTierRequest{TotalTickets: intPtr(10)}, expectedErr: ErrTotalBelowSold},
+		{name: "beyond the event's capacity", tierID: "tier-002", req: dto.UpdateTierRequest{TotalTickets: intPtr(201)}, expectedErr: ErrTierCapacityExceeded},
+		{name: "per-order limit", tierID: "tier-002", req: dto.UpdateTierRequest{MaxPerOrder: intPtr(2)}, expectedAvailable: 80},
+		{name: "tier of another event", tierID: "tier-009", req: dto.UpdateTierRequest{MaxPerOrder: intPtr(2)}, expectedErr: ErrTierNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := mocks.NewEventRepository(t)
+			tierRepo := mocks.NewTicketTierRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: eventRepo, Tiers: tierRepo})
+				},
+			).Once()
+			eventRepo.On("FindByIDForUpdate", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").
+				Return(&models.Event{ID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", TotalTickets: 500, OrganizerID: &organizerID}, nil).Once()
+			tierRepo.On("ListByEventID", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return([]*models.TicketTier{
+				{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", Name: "Regular", TotalTickets: 300, AvailableTickets: 300},
+				{ID: "tier-002", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", Name: "VIP", TotalTickets: 100, AvailableTickets: 80},
+			}, nil).Once()
+			if tt.expectedErr == nil {
+				tierRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.TicketTier")).Return(nil).Once()
+			}
+
+			svc := NewEventService(mocks.NewEventRepository(t), mocks.NewTicketRepository(t), mocks.NewTicketTierRepository(t), mocks.NewVenueRepository(t), uow, zerolog.Nop())
+			got, err := svc.UpdateTier(context.Background(), Actor{UserID: organizerID}, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", tt.tierID, &tt.req)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAvailable, got.AvailableTickets)
+		})
+	}
+}
+
+// TestEventService_AssignSeatMap
+// Summary: Tests switching an event to reserved seating
+// Purpose: Verify the event's ticket counts become the map's seat count, and that events with tickets
+// taken, unknown maps, maps of another venue, tiers larger than the map and maps larger than the venue
+// are refused
+func TestEventService_AssignSeatMap(t *testing.T) {
+	organizerID := "organizer-001"
+	seatMapID := "5b1e1c1a-0000-4000-8000-0000000000aa"
+
+	tests := []struct {
+		name         string
+		available    int
+		mapErr       error
+		mapVenueID   string
+		tierCapacity int
+		venueSeats   int
+		expectedErr  error
+	}{
+		{name: "fresh event", available: 500},
+		{name: "tickets already taken", available: 499, expectedErr: ErrSeatMapLocked},
+		{name: "unknown seat map", available: 500, mapErr: repositories.ErrNotFound, expectedErr: ErrSeatMapNotFound},
+		{name: "map of another venue", available: 500, mapVenueID: "venue-002", expectedErr: ErrSeatMapVenueMismatch},
+		{name: "tiers larger than the map", available: 500, tierCapacity: 301, expectedErr: ErrTierCapacityExceeded},
+		{name: "map larger than the venue", available: 500, venueSeats: 250, expectedErr: ErrVenueCapacityExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := mocks.NewEventRepository(t)
+			tierRepo := mocks.NewTicketTierRepository(t)
+			seatRepo := mocks.NewSeatRepository(t)
+			venueRepo := mocks.NewVenueRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: eventRepo, Tiers: tierRepo, Seats: seatRepo, Venues: venueRepo})
+				},
+			).Once()
+			eventRepo.On("FindByIDForUpdate", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").Return(&models.Event{
+				ID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", VenueID: "venue-001", TotalTickets: 500, AvailableTickets: tt.available, OrganizerID: &organizerID,
+			}, nil).Once()
+
+			if tt.expectedErr != ErrSeatMapLocked {
+				if tt.mapErr != nil {
+					seatRepo.On("FindSeatMapByID", mock.Anything, seatMapID).Return(nil, tt.mapErr).Once()
+				} else {
+					if tt.mapVenueID == "" {
+						tt.mapVenueID = "venue-001"
+					}
+					seatRepo.On("FindSeatMapByID", mock.Anything, seatMapID).Return(&models.SeatMap{ID: seatMapID, VenueID: tt.mapVenueID}, nil).Once()
+				}
+			}
+			if tt.mapErr == nil && tt.expectedErr != ErrSeatMapLocked && tt.expectedErr != ErrSeatMapVenueMismatch {
+				seatRepo.On("ReplaceEventSeats", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", seatMapID).Return(300, nil).Once()
+				tierRepo.On("ListByEventID", mock.Anything, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e").
+					Return([]*models.TicketTier{{ID: "tier-001", EventID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", TotalTickets: tt.tierCapacity}}, nil).Once()
+			}
+			if tt.expectedErr == nil || tt.expectedErr == ErrVenueCapacityExceeded {
+				if tt.venueSeats == 0 {
+					tt.venueSeats = 1000
+				}
+				venueRepo.On("FindByIDForUpdate", mock.Anything, "venue-001").Return(&models.Venue{ID: "venue-001", Capacity: tt.venueSeats}, nil).Once()
+			}
+			if tt.expectedErr == nil {
+				eventRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Event")).Return(nil).Once()
+			}
+
+			svc := NewEventService(mocks.NewEventRepository(t), mocks.NewTicketRepository(t), mocks.NewTicketTierRepository(t), mocks.NewVenueRepository(t), uow, zerolog.Nop())
+			got, err := svc.AssignSeatMap(context.Background(), Actor{UserID: organizerID}, "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", &dto.AssignSeatMapRequest{SeatMapID: seatMapID})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, seatMapID, *got.SeatMapID)
+			assert.Equal(t, 300, got.TotalTickets)
+			assert.Equal(t, 300, got.AvailableTickets)
+		})
+	}
+}
+
+// TestEventService_List
+// Summary: Tests filtering, sorting and paging the event listing
+// Purpose: Verify totals come from a count, page sizes are capped, a cursor is issued while more events
+// follow and continues after the last event of its sort, and bad ranges or cursors are refused
+func TestEventService_List(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*3600)
+	from := time.Date(2030, 3, 1, 0, 0, 0, 0, wib)
+	to := time.Date(2030, 3, 31, 0, 0, 0, 0, wib)
+	floatPtr := func(v float64) *float64 { return &v }
+	last := &models.Event{ID: "evt-002", EventDate: time.Date(2030, 3, 2, 2, 0, 0, 0, time.UTC), TicketPrice: 350000}
+	priceCursor := encodeEventCursor(models.EventSortPrice, true, last)
+
+	tests := []struct {
+		name           string
+		query          dto.EventListQuery
+		rows           int // events the repository returns
+		expectedQuery  func(q models.EventQuery) bool
+		expectedPage   int
+		expectedSize   int
+		expectedEvents int
+		wantCursor     bool
+		expectedErr    error
+	}{
+		{
+			name:  "defaults",
+			query: dto.EventListQuery{},
+			rows:  3,
+			expectedQuery: func(q models.EventQuery) bool {
+				return q.SortBy == models.EventSortDate && !q.SortDesc && q.Limit == 11 && q.Offset == 0 && q.After == nil
+			},
+			expectedPage: 1, expectedSize: 10, expectedEvents: 3,
+		},
+		{
+			name:  "filters in UTC",
+			query: dto.EventListQuery{From: &from, To: &to, MinPrice: floatPtr(100000), MaxPrice: floatPtr(500000), Sort: "-price"},
+			rows:  1,
+			expectedQuery: func(q models.EventQuery) bool {
+				return q.Filter.From.Equal(from) && q.Filter.From.Location() == time.UTC && *q.Filter.MaxPrice == 500000 &&
+					q.SortBy == models.EventSortPrice && q.SortDesc
+			},
+			expectedPage: 1, expectedSize: 10, expectedEvents: 1,
+		},
+		{
+			name:  "page size capped",
+			query: dto.EventListQuery{PageQuery: dto.PageQuery{Page: 3, PageSize: 1000}},
+			rows:  101,
+			expectedQuery: func(q models.EventQuery) bool {
+				return q.Limit == maxPageSize+1 && q.Offset == 2*maxPageSize
+			},
+			expectedPage: 3, expectedSize: maxPageSize, expectedEvents: maxPageSize, wantCursor: true,
+		},
+		{
+			name:  "cursor continues after its event",
+			query: dto.EventListQuery{Cursor: priceCursor, Sort: "-price", PageQuery: dto.PageQuery{Page: 4, PageSize: 2}},
+			rows:  3,
+			expectedQuery: func(q models.EventQuery) bool {
+				return q.After != nil && q.After.ID == "evt-002" && q.After.TicketPrice == 350000 && q.After.EventDate.Equal(last.EventDate)
+			},
+			expectedSize: 2, expectedEvents: 2, wantCursor: true,
+		},
+		{name: "cursor of another sort", query: dto.EventListQuery{Cursor: priceCursor, Sort: "price"}, expectedErr: ErrInvalidCursor},
+		{name: "garbled cursor", query: dto.EventListQuery{Cursor: "not-a-cursor!"}, expectedErr: ErrInvalidCursor},
+		{name: "from after to", query: dto.EventListQuery{From: &to, To: &from}, expectedErr: ErrInvalidDateRange},
+		{name: "min above max price", query: dto.EventListQuery{MinPrice: floatPtr(2), MaxPrice: floatPtr(1)}, expectedErr: ErrInvalidPriceRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventRepo := mocks.NewEventRepository(t)
+			tierRepo := mocks.NewTicketTierRepository(t)
+			venueRepo := mocks.NewVenueRepository(t)
+			if tt.expectedErr == nil {
+				events := make([]*models.Event, tt.rows)
+				for i := range events {
+					events[i] = &models.Event{ID: "evt-" + string(rune('a'+i%26)), VenueID: "venue-001", EventDate: last.EventDate, TicketPrice: 250000}
+				}
+				eventRepo.On("Count", mock.Anything, mock.AnythingOfType("models.EventFilter")).Return(250, nil).Once()
+				eventRepo.On("List", mock.Anything, mock.MatchedBy(tt.expectedQuery)).Return(events, nil).Once()
+				venueRepo.On("ListByIDs", mock.Anything, []string{"venue-001"}).Return([]*models.Venue{{ID: "venue-001"}}, nil).Once()
+				tierRepo.On("ListByEventIDs", mock.Anything, mock.Anything).Return([]*models.TicketTier{}, nil).Once()
+			}
+
+			svc := NewEventService(eventRepo, mocks.NewTicketRepository(t), tierRepo, venueRepo, mocks.NewUnitOfWork(t), zerolog.Nop())
+			got, err := svc.List(context.Background(), &tt.query)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 250, got.Total)
+			assert.Equal(t, tt.expectedPage, got.Page)
+			assert.Equal(t, tt.expectedSize, got.PageSize)
+			assert.Len(t, got.Events, tt.expectedEvents)
+			assert.Equal(t, tt.wantCursor, got.NextCursor != "")
+			if tt.wantCursor {
+				after, err := decodeEventCursor(got.NextCursor, models.EventSortDate, false)
+				if tt.query.Sort != "" {
+					after, err = decodeEventCursor(got.NextCursor, models.EventSortPrice, true)
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, got.Events[len(got.Events)-1].ID, after.ID)
+			}
+		})
+	}
+}
+
+// TestEventService_ListByVenue
+// Summary: Tests listing the events held at a venue
+// Purpose: Verify the listing is restricted to the venue, each event comes with its venue and tiers,
+// and unknown or malformed venues are reported
+func TestEventService_ListByVenue(t *testing.T) {
+	const venueID = "3f8e2a1b-7c6d-4e5f-9a0b-1c2d3e4f5a6b"
+
+	tests := []struct {
+		name        string
+		venueID     string // defaults to venueID
+		venueErr    error
+		expectedErr error
+	}{
+		{name: "venue with events"},
+		{name: "unknown venue", venueErr: repositories.ErrNotFound, expectedErr: ErrVenueNotFound},
+		{name: "malformed venue id", venueID: "not-a-uuid", expectedErr: ErrVenueNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venue := &models.Venue{ID: venueID, Name: "Jakarta Convention Center", Capacity: 5000}
+			eventRepo := mocks.NewEventRepository(t)
+			tierRepo := mocks.NewTicketTierRepository(t)
+			venueRepo := mocks.NewVenueRepository(t)
+			if tt.venueID == "" {
+				tt.venueID = venueID
+			}
+			switch {
+			case tt.venueID != venueID:
+				// Malformed ids are answered without a query
+			case tt.venueErr != nil:
+				venueRepo.On("FindByID", mock.Anything, venueID).Return(nil, tt.venueErr).Once()
+			default:
+				venueRepo.On("FindByID", mock.Anything, venueID).Return(venue, nil).Once()
+				atVenue := mock.MatchedBy(func(f models.EventFilter) bool { return f.VenueID == venueID })
+				eventRepo.On("Count", mock.Anything, atVenue).Return(2, nil).Once()
+				eventRepo.On("List", mock.Anything, mock.MatchedBy(func(q models.EventQuery) bool {
+					return q.Filter.VenueID == venueID && q.Offset == 10
+				})).Return([]*models.Event{
+					{ID: "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", VenueID: venueID},
+					{ID: "evt-002", VenueID: venueID},
+				}, nil).Once()
+				venueRepo.On("ListByIDs", mock.Anything, []string{venueID}).Return([]*models.Venue{venue}, nil).Once()
+				tierRepo.On("ListByEventIDs", mock.Anything, []string{"9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e", "evt-002"}).
+					Return([]*models.TicketTier{{ID: "tier-001", EventID: "evt-002"}}, nil).Once()
+			}
+
+			svc := NewEventService(eventRepo, mocks.NewTicketRepository(t), tierRepo, venueRepo, mocks.NewUnitOfWork(t), zerolog.Nop())
+			got, err := svc.ListByVenue(context.Background(), tt.venueID, &dto.EventListQuery{PageQuery: dto.PageQuery{Page: 2}})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Events, 2)
+			assert.Equal(t, 2, got.Page)
+			assert.Equal(t, 2, got.Total)
+			for _, event := range got.Events {
+				assert.Same(t, venue, event.Venue)
+			}
+			assert.Len(t, got.Events[1].Tiers, 1)
+		})
+	}
+}
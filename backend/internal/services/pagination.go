@@ -0,0 +1,22 @@
+package services
+
+const (
+	defaultPageSize = 10
+	// maxPageSize caps page_size so one request cannot fetch a whole table
+	maxPageSize = 100
+)
+
+// pageBounds fills in the defaults of an unset page or page size and caps
+// the page size
+func pageBounds(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/payment"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// WebhookOutcome tells the gateway what happened to a delivery. Every outcome
+// is acknowledged with 200 so the gateway stops retrying.
+type WebhookOutcome string
+
+const (
+	WebhookProcessed WebhookOutcome = "processed"
+	// WebhookDuplicate: the event ID was already handled
+	WebhookDuplicate WebhookOutcome = "duplicate"
+	// WebhookIgnored: unknown order, another transaction, or a transition the
+	// order has already moved past (out-of-order delivery)
+	WebhookIgnored WebhookOutcome = "ignored"
+)
+
+// webhookTargets maps each gateway event to the order status it leads to
+var webhookTargets = map[payment.EventType]models.TicketOrderStatus{
+	payment.EventAuthorized: models.OrderStatusPaid,
+	payment.EventCaptured:   models.OrderStatusConfirmed,
+	payment.EventDeclined:   models.OrderStatusCancelled,
+	payment.EventRefunded:   models.OrderStatusRefunded,
+}
+
+type PaymentService interface {
+	// HandleWebhook verifies and applies a gateway webhook. payload must be
+	// the raw request body the signature was computed over.
+	HandleWebhook(ctx context.Context, payload []byte, signature string) (WebhookOutcome, error)
+}
+
+type paymentService struct {
+	uow           repositories.UnitOfWork
+	webhookSecret string
+	log           zerolog.Logger
+}
+
+func NewPaymentService(uow repositories.UnitOfWork, webhookSecret string, log zerolog.Logger) PaymentService {
+	return &paymentService{
+		uow:           uow,
+		webhookSecret: webhookSecret,
+		log:           log,
+	}
+}
+
+func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) (WebhookOutcome, error) {
+	if s.webhookSecret == "" {
+		return "", ErrWebhookNotConfigured
+	}
+	if err := payment.VerifyWebhookSignature(s.webhookSecret, signature, payload, time.Now()); err != nil {
+		s.log.Warn().Err(err).Msg("payment webhook rejected")
+		return "", ErrBadWebhookSignature
+	}
+
+	var event payment.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return "", ErrInvalidWebhook
+	}
+	target, known := webhookTargets[event.Type]
+	if event.ID == "" || !known || uuid.Validate(event.Reference) != nil {
+		return "", ErrInvalidWebhook
+	}
+
+	// Recording the event and moving the order commit together, so a failed
+	// attempt leaves nothing behind and the gateway's retry is processed.
+	outcome := WebhookProcessed
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		err := tx.PaymentWebhooks.Create(ctx, &models.PaymentWebhookEvent{
+			ID:            event.ID,
+			EventType:     string(event.Type),
+			TransactionID: event.TransactionID,
+			Reference:     event.Reference,
+			Payload:       payload,
+		})
+		if errors.Is(err, repositories.ErrDuplicate) {
+			outcome = WebhookDuplicate
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		order, err := tx.Tickets.FindOrderByIDForUpdate(ctx, event.Reference)
+		if errors.Is(err, repositories.ErrNotFound) {
+			outcome = WebhookIgnored
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		from := models.TicketOrderStatus(order.Status)
+		otherTransaction := order.PaymentID != nil && *order.PaymentID != event.TransactionID
+		if otherTransaction || !from.CanTransitionTo(target) {
+			if from == models.OrderStatusCancelled && (target == models.OrderStatusPaid || target == models.OrderStatusConfirmed) {
+				// The hold expired before the money arrived
+				s.log.Warn().Str("order_id", order.ID).Str("transaction_id", event.TransactionID).
+					Msg("payment received for a cancelled order, refund required")
+			}
+			outcome = WebhookIgnored
+			return nil
+		}
+
+		if target == models.OrderStatusCancelled || target == models.OrderStatusRefunded {
+			err := checkNoTransfers(ctx, tx, order)
+			if errors.Is(err, ErrOrderHasTransfers) {
+				// The recipients' tickets stay valid, so the seats cannot be sold again
+				s.log.Warn().Str("order_id", order.ID).Str("transaction_id", event.TransactionID).
+					Msg("payment reversed for an order with transferred tickets, manual review required")
+				outcome = WebhookIgnored
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		change := models.OrderStatusChange{
+			OrderID: order.ID,
+			From:    from,
+			To:      target,
+			Reason:  fmt.Sprintf("payment webhook %s (%s)", event.Type, event.ID),
+		}
+		if err := tx.Tickets.UpdateOrderPayment(ctx, change, event.TransactionID); err != nil {
+			return err
+		}
+		if target == models.OrderStatusCancelled || target == models.OrderStatusRefunded {
+			return releaseTickets(ctx, tx, order)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error().Err(err).Str("event_id", event.ID).Msg("failed to apply payment webhook")
+		return "", err
+	}
+
+	s.log.Info().
+		Str("event_id", event.ID).
+		Str("type", string(event.Type)).
+		Str("order_id", event.Reference).
+		Str("outcome", string(outcome)).
+		Msg("payment webhook handled")
+	return outcome, nil
+}
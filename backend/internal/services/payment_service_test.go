@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/payment"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testWebhookSecret = "whsec_test"
+
+// TestPaymentService_HandleWebhook
+// Summary: Applying signed gateway webhooks to orders
+// Purpose: Verify orders follow the state machine, duplicate, replayed or out-of-order events have no effect,
+// and a refund of an order whose tickets were transferred leaves the order and the inventory alone
+func TestPaymentService_HandleWebhook(t *testing.T) {
+	orderID := "6f1c2a9e-3d4b-4c5a-9e8f-7a6b5c4d3e2f"
+	txnID := "sim_txn_001"
+	otherTxn := "sim_txn_002"
+
+	tests := []struct {
+		name            string
+		eventType       payment.EventType
+		orderStatus     models.TicketOrderStatus
+		orderPaymentID  *string
+		tickets         []*models.Ticket // the order's tickets, read before a confirmed order is reversed
+		duplicate       bool
+		orderMissing    bool
+		expectedOutcome WebhookOutcome
+		expectedStatus  models.TicketOrderStatus // set when the order should move
+		released        bool
+	}{
+		{
+			name:            "authorized moves pending to paid",
+			eventType:       payment.EventAuthorized,
+			orderStatus:     models.OrderStatusPending,
+			expectedOutcome: WebhookProcessed,
+			expectedStatus:  models.OrderStatusPaid,
+		},
+		{
+			name:            "captured confirms a paid order",
+			eventType:       payment.EventCaptured,
+			orderStatus:     models.OrderStatusPaid,
+			orderPaymentID:  &txnID,
+			expectedOutcome: WebhookProcessed,
+			expectedStatus:  models.OrderStatusConfirmed,
+		},
+		{
+			name:            "declined cancels and releases tickets",
+			eventType:       payment.EventDeclined,
+			orderStatus:     models.OrderStatusPending,
+			expectedOutcome: WebhookProcessed,
+			expectedStatus:  models.OrderStatusCancelled,
+			released:        true,
+		},
+		{
+			name:            "refunded releases tickets",
+			eventType:       payment.EventRefunded,
+			orderStatus:     models.OrderStatusConfirmed,
+			orderPaymentID:  &txnID,
+			tickets:         []*models.Ticket{{ID: "ticket-001", Status: string(models.TicketStatusValid)}},
+			expectedOutcome: WebhookProcessed,
+			expectedStatus:  models.OrderStatusRefunded,
+			released:        true,
+		},
+		{
+			name:           "refunded after a ticket was transferred",
+			eventType:      payment.EventRefunded,
+			orderStatus:    models.OrderStatusConfirmed,
+			orderPaymentID: &txnID,
+			tickets: []*models.Ticket{
+				{ID: "ticket-001", Status: string(models.TicketStatusValid)},
+				{ID: "ticket-002", Status: string(models.TicketStatusTransferred)},
+			},
+			expectedOutcome: WebhookIgnored,
+		},
+		{
+			name:            "replayed event id",
+			eventType:       payment.EventCaptured,
+			duplicate:       true,
+			expectedOutcome: WebhookDuplicate,
+		},
+		{
+			name:            "authorized arriving after captured",
+			eventType:       payment.EventAuthorized,
+			orderStatus:     models.OrderStatusConfirmed,
+			orderPaymentID:  &txnID,
+			expectedOutcome: WebhookIgnored,
+		},
+		{
+			name:            "captured for a cancelled order",
+			eventType:       payment.EventCaptured,
+			orderStatus:     models.OrderStatusCancelled,
+			expectedOutcome: WebhookIgnored,
+		},
+		{
+			name:            "event for another transaction",
+			eventType:       payment.EventCaptured,
+			orderStatus:     models.OrderStatusPaid,
+			orderPaymentID:  &otherTxn,
+			expectedOutcome: WebhookIgnored,
+		},
+		{
+			name:            "unknown order",
+			eventType:       payment.EventCaptured,
+			orderMissing:    true,
+			expectedOutcome: WebhookIgnored,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txEvents := mocks.NewEventRepository(t)
+			txTickets := mocks.NewTicketRepository(t)
+			txWebhooks := mocks.NewPaymentWebhookRepository(t)
+			txSeats := mocks.NewSeatRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: txEvents, Tickets: txTickets, PaymentWebhooks: txWebhooks, Seats: txSeats})
+				},
+			).Once()
+
+			if tt.duplicate {
+				txWebhooks.On("Create", mock.Anything, mock.Anything).Return(repositories.ErrDuplicate).Once()
+			} else {
+				txWebhooks.On("Create", mock.Anything, mock.MatchedBy(func(e *models.PaymentWebhookEvent) bool {
+					return e.ID == "evt_001" && e.Reference == orderID && e.TransactionID == txnID
+				})).Return(nil).Once()
+
+				if tt.orderMissing {
+					txTickets.On("FindOrderByIDForUpdate", mock.Anything, orderID).Return(nil, repositories.ErrNotFound).Once()
+				} else {
+					order := &models.TicketOrder{ID: orderID, EventID: "event-001", Quantity: 2, Status: string(tt.orderStatus), PaymentID: tt.orderPaymentID}
+					txTickets.On("FindOrderByIDForUpdate", mock.Anything, orderID).Return(order, nil).Once()
+				}
+				if tt.tickets != nil {
+					txTickets.On("FindTicketsByOrderID", mock.Anything, orderID).Return(tt.tickets, nil).Once()
+				}
+			}
+			if tt.expectedStatus != "" {
+				txTickets.On("UpdateOrderPayment", mock.Anything, orderChange(orderID, tt.orderStatus, tt.expectedStatus), txnID).Return(nil).Once()
+			}
+			if tt.released {
+				txTickets.On("UpdateTicketsStatusByOrderID", mock.Anything, orderID, models.TicketStatusCancelled).Return(2, nil).Once()
+				txSeats.On("ReleaseSeatsByOrder", mock.Anything, orderID).Return(nil).Once()
+				txEvents.On("IncrementAvailableTickets", mock.Anything, "event-001", 2).Return(nil).Once()
+			}
+
+			payload := webhookPayload(t, tt.eventType, orderID, txnID)
+			service := NewPaymentService(uow, testWebhookSecret, zerolog.Nop())
+			outcome, err := service.HandleWebhook(context.Background(), payload, payment.SignWebhook(testWebhookSecret, payload, time.Now()))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOutcome, outcome)
+		})
+	}
+}
+
+// TestPaymentService_HandleWebhook_Rejected
+// Summary: Webhooks that never reach the database
+// Purpose: Verify unsigned, forged and malformed deliveries are rejected before any order is touched
+func TestPaymentService_HandleWebhook_Rejected(t *testing.T) {
+	orderID := "6f1c2a9e-3d4b-4c5a-9e8f-7a6b5c4d3e2f"
+	valid := webhookPayload(t, payment.EventCaptured, orderID, "sim_txn_001")
+
+	tests := []struct {
+		name        string
+		secret      string
+		payload     []byte
+		signature   string
+		expectedErr error
+	}{
+		{
+			name:        "webhook secret not configured",
+			payload:     valid,
+			signature:   payment.SignWebhook(testWebhookSecret, valid, time.Now()),
+			expectedErr: ErrWebhookNotConfigured,
+		},
+		{
+			name:        "missing signature",
+			secret:      testWebhookSecret,
+			payload:     valid,
+			expectedErr: ErrBadWebhookSignature,
+		},
+		{
+			name:        "signed with another secret",
+			secret:      testWebhookSecret,
+			payload:     valid,
+			signature:   payment.SignWebhook("attacker", valid, time.Now()),
+			expectedErr: ErrBadWebhookSignature,
+		},
+		{
+			name:        "replayed after the tolerance window",
+			secret:      testWebhookSecret,
+			payload:     valid,
+			signature:   payment.SignWebhook(testWebhookSecret, valid, time.Now().Add(-time.Hour)),
+			expectedErr: ErrBadWebhookSignature,
+		},
+		{
+			name:        "unknown event type",
+			secret:      testWebhookSecret,
+			payload:     webhookPayload(t, "payment.exploded", orderID, "sim_txn_001"),
+			expectedErr: ErrInvalidWebhook,
+		},
+		{
+			name:        "reference is not an order id",
+			secret:      testWebhookSecret,
+			payload:     webhookPayload(t, payment.EventCaptured, "order-001", "sim_txn_001"),
+			expectedErr: ErrInvalidWebhook,
+		},
+		{
+			name:        "not json",
+			secret:      testWebhookSecret,
+			payload:     []byte("captured"),
+			expectedErr: ErrInvalidWebhook,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := tt.signature
+			if signature == "" && tt.expectedErr == ErrInvalidWebhook {
+				signature = payment.SignWebhook(testWebhookSecret, tt.payload, time.Now())
+			}
+
+			service := NewPaymentService(mocks.NewUnitOfWork(t), tt.secret, zerolog.Nop())
+			outcome, err := service.HandleWebhook(context.Background(), tt.payload, signature)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Empty(t, outcome)
+		})
+	}
+}
+
+func webhookPayload(t *testing.T, eventType payment.EventType, reference, transactionID string) []byte {
+	t.Helper()
+	payload, err := json.Marshal(payment.WebhookEvent{
+		ID:            "evt_001",
+		Type:          eventType,
+		TransactionID: transactionID,
+		Reference:     reference,
+		CreatedAt:     time.Now(),
+	})
+	assert.NoError(t, err)
+	return payload
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/rs/zerolog"
+)
+
+// permissionCacheTTL bounds how stale a user's permissions can be if an
+// invalidation is missed (e.g. a role edited directly in the database)
+const permissionCacheTTL = 15 * time.Minute
+
+type PermissionService interface {
+	HasPermission(ctx context.Context, userID, permission string) (bool, error)
+	GetUserPermissions(ctx context.Context, userID string) ([]string, error)
+	InvalidateUser(ctx context.Context, userID string) error
+	InvalidateAll(ctx context.Context) error
+}
+
+type permissionService struct {
+	userRepo repositories.UserRepository
+	cache    cache.PermissionCache
+	log      zerolog.Logger
+}
+
+func NewPermissionService(userRepo repositories.UserRepository, permCache cache.PermissionCache, log zerolog.Logger) PermissionService {
+	return &permissionService{
+		userRepo: userRepo,
+		cache:    permCache,
+		log:      log,
+	}
+}
+
+func (s *permissionService) HasPermission(ctx context.Context, userID, permission string) (bool, error) {
+	permissions, err := s.GetUserPermissions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetUserPermissions reads through the cache. A cache outage degrades to a
+// database lookup instead of failing authorization.
+func (s *permissionService) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
+	permissions, ok, err := s.cache.Get(ctx, userID)
+	if err != nil {
+		s.log.Warn().Err(err).Str("user_id", userID).Msg("permission cache read failed")
+	} else if ok {
+		return permissions, nil
+	}
+
+	permissions, err = s.userRepo.FindPermissionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, userID, permissions, permissionCacheTTL); err != nil {
+		s.log.Warn().Err(err).Str("user_id", userID).Msg("permission cache write failed")
+	}
+	return permissions, nil
+}
+
+func (s *permissionService) InvalidateUser(ctx context.Context, userID string) error {
+	s.log.Debug().Str("user_id", userID).Msg("invalidating user permissions")
+	return s.cache.Delete(ctx, userID)
+}
+
+func (s *permissionService) InvalidateAll(ctx context.Context) error {
+	s.log.Info().Msg("invalidating all cached permissions")
+	return s.cache.Clear(ctx)
+}
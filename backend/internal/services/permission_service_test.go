@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestPermissionService_HasPermission
+// Summary: Permission checks resolved through the user's roles
+// Purpose: Verify granted/missing permissions and that repository failures are not treated as a grant
+func TestPermissionService_HasPermission(t *testing.T) {
+	organizerPerms := []string{models.PermEventCreate, models.PermEventRead, models.PermEventUpdate, models.PermTicketRead}
+
+	tests := []struct {
+		name        string
+		permission  string
+		repoPerms   []string
+		repoErr     error
+		expected    bool
+		expectError bool
+	}{
+		{
+			name:       "organizer can create events",
+			permission: models.PermEventCreate,
+			repoPerms:  organizerPerms,
+			expected:   true,
+		},
+		{
+			name:       "organizer cannot delete users",
+			permission: models.PermUserDelete,
+			repoPerms:  organizerPerms,
+			expected:   false,
+		},
+		{
+			name:       "user without roles",
+			permission: models.PermEventRead,
+			repoPerms:  []string{},
+			expected:   false,
+		},
+		{
+			name:        "repository failure",
+			permission:  models.PermEventCreate,
+			repoErr:     errors.New("connection refused"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewUserRepository(t)
+			mockUserRepo.On("FindPermissionsByUserID", mock.Anything, "user-001").Return(tt.repoPerms, tt.repoErr).Once()
+
+			service := NewPermissionService(mockUserRepo, cache.NewMemoryPermissionCache(), zerolog.Nop())
+			allowed, err := service.HasPermission(context.Background(), "user-001", tt.permission)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.False(t, allowed)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
+// TestPermissionService_Cache
+// Summary: Cached permission lookups and invalidation
+// Purpose: Verify repeated checks hit the cache and invalidation forces a fresh lookup
+func TestPermissionService_Cache(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := mocks.NewUserRepository(t)
+	service := NewPermissionService(mockUserRepo, cache.NewMemoryPermissionCache(), zerolog.Nop())
+
+	// First lookup loads from the database, the second is served from cache
+	mockUserRepo.On("FindPermissionsByUserID", mock.Anything, "user-001").Return([]string{models.PermEventRead}, nil).Once()
+	allowed, err := service.HasPermission(ctx, "user-001", models.PermEventCreate)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	allowed, err = service.HasPermission(ctx, "user-001", models.PermEventRead)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	// Role granted: per-user invalidation picks up the new permission
+	assert.NoError(t, service.InvalidateUser(ctx, "user-001"))
+	mockUserRepo.On("FindPermissionsByUserID", mock.Anything, "user-001").Return([]string{models.PermEventRead, models.PermEventCreate}, nil).Once()
+	allowed, err = service.HasPermission(ctx, "user-001", models.PermEventCreate)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	// Role permissions changed: everyone reloads
+	assert.NoError(t, service.InvalidateAll(ctx))
+	mockUserRepo.On("FindPermissionsByUserID", mock.Anything, "user-001").Return([]string{models.PermEventRead}, nil).Once()
+	allowed, err = service.HasPermission(ctx, "user-001", models.PermEventCreate)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type RoleService interface {
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (*models.Role, error)
+	ListPermissions(ctx context.Context) ([]*models.Permission, error)
+	CreatePermission(ctx context.Context, req *dto.CreatePermissionRequest) (*models.Permission, error)
+	AttachPermission(ctx context.Context, roleID, permission string) error
+	DetachPermission(ctx context.Context, roleID, permission string) error
+}
+
+type roleService struct {
+	roleRepo repositories.RoleRepository
+	permSvc  PermissionService
+	log      zerolog.Logger
+}
+
+func NewRoleService(roleRepo repositories.RoleRepository, permSvc PermissionService, log zerolog.Logger) RoleService {
+	return &roleService{
+		roleRepo: roleRepo,
+		permSvc:  permSvc,
+		log:      log,
+	}
+}
+
+func (s *roleService) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	return s.roleRepo.List(ctx)
+}
+
+// CreateRole adds a custom role. It starts without permissions.
+func (s *roleService) CreateRole(ctx context.Context, req *dto.CreateRoleRequest) (*models.Role, error) {
+	role := &models.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		IsActive:    true,
+		Permissions: []*models.Permission{},
+	}
+
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, ErrRoleAlreadyExists
+		}
+		s.log.Error().Err(err).Str("role", req.Name).Msg("failed to create role")
+		return nil, err
+	}
+
+	s.log.Info().Str("role_id", role.ID).Str("role", role.Name).Msg("role created")
+	return role, nil
+}
+
+func (s *roleService) ListPermissions(ctx context.Context) ([]*models.Permission, error) {
+	return s.roleRepo.ListPermissions(ctx)
+}
+
+// CreatePermission registers a new resource.action permission. The name is
+// validated at the request boundary.
+func (s *roleService) CreatePermission(ctx context.Context, req *dto.CreatePermissionRequest) (*models.Permission, error) {
+	resource, action, _ := strings.Cut(req.Name, ".")
+	permission := &models.Permission{
+		Name:        req.Name,
+		Resource:    resource,
+		Action:      action,
+		Description: req.Description,
+	}
+
+	if err := s.roleRepo.CreatePermission(ctx, permission); err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, ErrPermissionExists
+		}
+		s.log.Error().Err(err).Str("permission", req.Name).Msg("failed to create permission")
+		return nil, err
+	}
+
+	s.log.Info().Str("permission", permission.Name).Msg("permission created")
+	return permission, nil
+}
+
+func (s *roleService) AttachPermission(ctx context.Context, roleID, permission string) error {
+	if err := s.ensureRole(ctx, roleID); err != nil {
+		return err
+	}
+
+	if err := s.roleRepo.AttachPermission(ctx, roleID, permission); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrPermissionNotFound
+		}
+		s.log.Error().Err(err).Str("role_id", roleID).Str("permission", permission).Msg("failed to attach permission")
+		return err
+	}
+
+	s.log.Info().Str("role_id", roleID).Str("permission", permission).Msg("permission attached to role")
+	s.invalidateAll(ctx)
+	return nil
+}
+
+func (s *roleService) DetachPermission(ctx context.Context, roleID, permission string) error {
+	if err := s.ensureRole(ctx, roleID); err != nil {
+		return err
+	}
+
+	if err := s.roleRepo.DetachPermission(ctx, roleID, permission); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrPermissionNotAttached
+		}
+		s.log.Error().Err(err).Str("role_id", roleID).Str("permission", permission).Msg("failed to detach permission")
+		return err
+	}
+
+	s.log.Info().Str("role_id", roleID).Str("permission", permission).Msg("permission detached from role")
+	s.invalidateAll(ctx)
+	return nil
+}
+
+func (s *roleService) ensureRole(ctx context.Context, roleID string) error {
+	if uuid.Validate(roleID) != nil {
+		return ErrRoleNotFound
+	}
+	if _, err := s.roleRepo.FindByID(ctx, roleID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrRoleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// invalidateAll drops every cached permission set, since any number of users
+// may hold the changed role. The change is already stored; a failed
+// invalidation only delays it until the cache TTL.
+func (s *roleService) invalidateAll(ctx context.Context) {
+	if err := s.permSvc.InvalidateAll(ctx); err != nil {
+		s.log.Error().Err(err).Msg("failed to invalidate permission cache")
+	}
+}
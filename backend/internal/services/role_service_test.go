@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestRoleService_Create
+// Summary: Creating custom roles and permissions
+// Purpose: Verify resource/action are derived from the permission name and duplicates map to domain errors
+func TestRoleService_Create(t *testing.T) {
+	t.Run("create role", func(t *testing.T) {
+		mockRoleRepo := mocks.NewRoleRepository(t)
+		mockRoleRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *models.Role) bool {
+			return r.Name == "gate_staff" && r.IsActive
+		})).Return(nil).Once()
+
+		service := NewRoleService(mockRoleRepo, nil, zerolog.Nop())
+		role, err := service.CreateRole(context.Background(), &dto.CreateRoleRequest{Name: "gate_staff"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gate_staff", role.Name)
+		assert.Empty(t, role.Permissions)
+	})
+
+	t.Run("duplicate role", func(t *testing.T) {
+		mockRoleRepo := mocks.NewRoleRepository(t)
+		mockRoleRepo.On("Create", mock.Anything, mock.Anything).Return(repositories.ErrDuplicate).Once()
+
+		service := NewRoleService(mockRoleRepo, nil, zerolog.Nop())
+		_, err := service.CreateRole(context.Background(), &dto.CreateRoleRequest{Name: models.RoleOrganizer})
+
+		assert.ErrorIs(t, err, ErrRoleAlreadyExists)
+	})
+
+	t.Run("create permission", func(t *testing.T) {
+		mockRoleRepo := mocks.NewRoleRepository(t)
+		mockRoleRepo.On("CreatePermission", mock.Anything, mock.MatchedBy(func(p *models.Permission) bool {
+			return p.Name == "venues.create" && p.Resource == "venues" && p.Action == "create"
+		})).Return(nil).Once()
+
+		service := NewRoleService(mockRoleRepo, nil, zerolog.Nop())
+		_, err := service.CreatePermission(context.Background(), &dto.CreatePermissionRequest{Name: "venues.create"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("duplicate permission", func(t *testing.T) {
+		mockRoleRepo := mocks.NewRoleRepository(t)
+		mockRoleRepo.On("CreatePermission", mock.Anything, mock.Anything).Return(repositories.ErrDuplicate).Once()
+
+		service := NewRoleService(mockRoleRepo, nil, zerolog.Nop())
+		_, err := service.CreatePermission(context.Background(), &dto.CreatePermissionRequest{Name: models.PermEventCreate})
+
+		assert.ErrorIs(t, err, ErrPermissionExists)
+	})
+}
+
+// TestRoleService_RolePermissions
+// Summary: Attaching and detaching permissions on roles
+// Purpose: Verify missing or malformed roles and missing permissions map to domain errors and any change
+// drops all cached permissions
+func TestRoleService_RolePermissions(t *testing.T) {
+	const roleID = "d2c4e6f8-1a3b-4c5d-9e7f-8a6b4c2d0e1f"
+
+	tests := []struct {
+		name        string
+		roleID      string // defaults to roleID
+		detach      bool
+		findErr     error
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "attach", detach: false},
+		{name: "detach", detach: true},
+		{name: "unknown role", findErr: repositories.ErrNotFound, expectedErr: ErrRoleNotFound},
+		{name: "unknown permission", repoErr: repositories.ErrNotFound, expectedErr: ErrPermissionNotFound},
+		{name: "detach permission role lacks", detach: true, repoErr: repositories.ErrNotFound, expectedErr: ErrPermissionNotAttached},
+		{name: "malformed role id", roleID: "not-a-uuid", expectedErr: ErrRoleNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			mockRoleRepo := mocks.NewRoleRepository(t)
+			if tt.roleID == "" {
+				tt.roleID = roleID
+			}
+
+			switch {
+			case tt.roleID != roleID:
+				// Malformed ids are answered without a query
+			case tt.findErr != nil:
+				mockRoleRepo.On("FindByID", mock.Anything, roleID).Return(nil, tt.findErr).Once()
+			default:
+				mockRoleRepo.On("FindByID", mock.Anything, roleID).Return(&models.Role{ID: roleID, Name: models.RoleOrganizer}, nil).Once()
+				method := "AttachPermission"
+				if tt.detach {
+					method = "DetachPermission"
+				}
+				mockRoleRepo.On(method, mock.Anything, roleID, models.PermEventDelete).Return(tt.repoErr).Once()
+			}
+
+			// Any user's cached permissions may include the changed role
+			permCache := cache.NewMemoryPermissionCache()
+			_ = permCache.Set(ctx, "user-001", []string{models.PermEventCreate}, time.Minute)
+			permSvc := NewPermissionService(mocks.NewUserRepository(t), permCache, zerolog.Nop())
+
+			service := NewRoleService(mockRoleRepo, permSvc, zerolog.Nop())
+			var err error
+			if tt.detach {
+				err = service.DetachPermission(ctx, tt.roleID, models.PermEventDelete)
+			} else {
+				err = service.AttachPermission(ctx, tt.roleID, models.PermEventDelete)
+			}
+
+			_, cached, _ := permCache.Get(ctx, "user-001")
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.True(t, cached)
+				return
+			}
+			assert.NoError(t, err)
+			assert.False(t, cached, "permission cache should be cleared")
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// maxSeatsPerMap bounds a seat map, and so the seat inventory copied to
+// every event that uses it
+const maxSeatsPerMap = 100000
+
+// SeatService manages venue seat maps and reports seat availability
+type SeatService interface {
+	// CreateSeatMap stores a layout with all its seats
+	CreateSeatMap(ctx context.Context, actor Actor, req *dto.CreateSeatMapRequest) (*models.SeatMap, error)
+	// GetSeatMap returns a layout with its seats
+	GetSeatMap(ctx context.Context, id string) (*models.SeatMap, error)
+	// GetEventSeats reports which seats of a seated event are still free.
+	// It runs a single query, so clients can poll it.
+	GetEventSeats(ctx context.Context, eventID string) (*dto.EventSeatsResponse, error)
+}
+
+type seatService struct {
+	uow       repositories.UnitOfWork
+	seatRepo  repositories.SeatRepository
+	eventRepo repositories.EventRepository
+	log       zerolog.Logger
+}
+
+func NewSeatService(uow repositories.UnitOfWork, seatRepo repositories.SeatRepository, eventRepo repositories.EventRepository, log zerolog.Logger) SeatService {
+	return &seatService{
+		uow:       uow,
+		seatRepo:  seatRepo,
+		eventRepo: eventRepo,
+		log:       log,
+	}
+}
+
+func (s *seatService) CreateSeatMap(ctx context.Context, actor Actor, req *dto.CreateSeatMapRequest) (*models.SeatMap, error) {
+	count := 0
+	for _, section := range req.Sections {
+		for _, row := range section.Rows {
+			count += row.Seats
+		}
+	}
+	if count > maxSeatsPerMap {
+		return nil, ErrSeatMapTooLarge
+	}
+
+	createdBy := actor.UserID
+	seatMap := &models.SeatMap{
+		Name:      strings.TrimSpace(req.Name),
+		VenueID:   req.VenueID,
+		CreatedBy: &createdBy,
+	}
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		if _, err := tx.Venues.FindByID(ctx, req.VenueID); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return ErrVenueNotFound
+			}
+			return err
+		}
+		if err := tx.Seats.CreateSeatMap(ctx, seatMap); err != nil {
+			return err
+		}
+
+		seats := make([]*models.Seat, 0, count)
+		for _, section := range req.Sections {
+			for _, row := range section.Rows {
+				for number := 1; number <= row.Seats; number++ {
+					seats = append(seats, &models.Seat{
+						SeatMapID: seatMap.ID,
+						Section:   strings.TrimSpace(section.Name),
+						Row:       strings.TrimSpace(row.Label),
+						Number:    number,
+					})
+				}
+			}
+		}
+		if err := tx.Seats.CreateSeats(ctx, seats); err != nil {
+			return err
+		}
+		seatMap.Seats = seats
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, ErrDuplicateSeat
+		}
+		if errors.Is(err, ErrVenueNotFound) {
+			return nil, err
+		}
+		s.log.Error().Err(err).Str("actor_id", actor.UserID).Msg("failed to create seat map")
+		return nil, err
+	}
+
+	s.log.Info().Str("seat_map_id", seatMap.ID).Int("seats", count).Str("actor_id", actor.UserID).Msg("seat map created")
+	return seatMap, nil
+}
+
+func (s *seatService) GetSeatMap(ctx context.Context, id string) (*models.SeatMap, error) {
+	if uuid.Validate(id) != nil {
+		return nil, ErrSeatMapNotFound
+	}
+	seatMap, err := s.seatRepo.FindSeatMapByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrSeatMapNotFound
+		}
+		return nil, err
+	}
+
+	seatMap.Seats, err = s.seatRepo.ListSeats(ctx, id)
+	if err != nil {
+		s.log.Error().Err(err).Str("seat_map_id", id).Msg("failed to list seats")
+		return nil, err
+	}
+	return seatMap, nil
+}
+
+func (s *seatService) GetEventSeats(ctx context.Context, eventID string) (*dto.EventSeatsResponse, error) {
+	if uuid.Validate(eventID) != nil {
+		return nil, ErrEventNotFound
+	}
+	seats, err := s.seatRepo.ListEventSeats(ctx, eventID)
+	if err != nil {
+		s.log.Error().Err(err).Str("event_id", eventID).Msg("failed to list event seats")
+		return nil, err
+	}
+
+	// No seats: tell a missing event from one without reserved seating
+	if len(seats) == 0 {
+		if _, err := s.eventRepo.FindByID(ctx, eventID); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return nil, ErrEventNotFound
+			}
+			return nil, err
+		}
+		return nil, ErrNotSeated
+	}
+
+	resp := &dto.EventSeatsResponse{EventID: eventID, Seats: seats}
+	for _, seat := range seats {
+		if seat.Available {
+			resp.Available++
+		}
+	}
+	return resp, nil
+}
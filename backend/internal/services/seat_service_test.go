@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestSeatService_CreateSeatMap
+// Summary: Tests describing a venue's seats
+// Purpose: Verify every row is numbered from 1 within the map, and maps that are too large, list a
+// seat twice or name an unknown venue are refused
+func TestSeatService_CreateSeatMap(t *testing.T) {
+	tests := []struct {
+		name          string
+		sections      []dto.SeatSectionRequest
+		venueErr      error
+		createErr     error
+		expectedSeats int
+		expectedErr   error
+	}{
+		{
+			name: "two sections",
+			sections: []dto.SeatSectionRequest{
+				{Name: "Floor", Rows: []dto.SeatRowRequest{{Label: "A", Seats: 10}, {Label: "B", Seats: 12}}},
+				{Name: "Balcony", Rows: []dto.SeatRowRequest{{Label: "A", Seats: 8}}},
+			},
+			expectedSeats: 30,
+		},
+		{
+			name: "too many seats",
+			sections: []dto.SeatSectionRequest{
+				{Name: "Floor", Rows: []dto.SeatRowRequest{{Label: "A", Seats: maxSeatsPerMap}, {Label: "B", Seats: 1}}},
+			},
+			expectedErr: ErrSeatMapTooLarge,
+		},
+		{
+			name: "row listed twice",
+			sections: []dto.SeatSectionRequest{
+				{Name: "Floor", Rows: []dto.SeatRowRequest{{Label: "A", Seats: 10}, {Label: "A", Seats: 10}}},
+			},
+			createErr:   repositories.ErrDuplicate,
+			expectedErr: ErrDuplicateSeat,
+		},
+		{
+			name: "unknown venue",
+			sections: []dto.SeatSectionRequest{
+				{Name: "Floor", Rows: []dto.SeatRowRequest{{Label: "A", Seats: 10}}},
+			},
+			venueErr:    repositories.ErrNotFound,
+			expectedErr: ErrVenueNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seatRepo := mocks.NewSeatRepository(t)
+			venueRepo := mocks.NewVenueRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			if tt.expectedErr != ErrSeatMapTooLarge {
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Seats: seatRepo, Venues: venueRepo})
+					},
+				).Once()
+				if tt.venueErr != nil {
+					venueRepo.On("FindByID", mock.Anything, "venue-001").Return(nil, tt.venueErr).Once()
+				} else {
+					venueRepo.On("FindByID", mock.Anything, "venue-001").Return(&models.Venue{ID: "venue-001"}, nil).Once()
+				}
+			}
+			if tt.expectedErr != ErrSeatMapTooLarge && tt.venueErr == nil {
+				seatRepo.On("CreateSeatMap", mock.Anything, mock.MatchedBy(func(m *models.SeatMap) bool {
+					return m.Name == "Plenary Hall" && m.VenueID == "venue-001" && *m.CreatedBy == "organizer-001"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.SeatMap).ID = "seat-map-001"
+				}).Return(nil).Once()
+				seatRepo.On("CreateSeats", mock.Anything, mock.MatchedBy(func(seats []*models.Seat) bool {
+					if tt.expectedSeats > 0 {
+						balcony := seats[len(seats)-1]
+						return len(seats) == tt.expectedSeats && seats[0].Number == 1 && seats[0].SeatMapID == "seat-map-001" &&
+							balcony.Section == "Balcony" && balcony.Number == 8
+					}
+					return true
+				})).Return(tt.createErr).Once()
+			}
+
+			svc := NewSeatService(uow, mocks.NewSeatRepository(t), mocks.NewEventRepository(t), zerolog.Nop())
+			got, err := svc.CreateSeatMap(context.Background(), Actor{UserID: "organizer-001"}, &dto.CreateSeatMapRequest{
+				Name:     " Plenary Hall ",
+				VenueID:  "venue-001",
+				Sections: tt.sections,
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Seats, tt.expectedSeats)
+		})
+	}
+}
+
+// TestSeatService_GetEventSeats
+// Summary: Tests reading an event's seat availability
+// Purpose: Verify free seats are counted, and events without seating or that do not exist are told apart,
+// with malformed ids answered as missing events without a query
+func TestSeatService_GetEventSeats(t *testing.T) {
+	const eventID = "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e"
+
+	tests := []struct {
+		name              string
+		eventID           string // defaults to eventID
+		seats             []*models.EventSeat
+		findErr           error
+		expectedAvailable int
+		expectedErr       error
+	}{
+		{
+			name: "seated event",
+			seats: []*models.EventSeat{
+				{SeatID: "seat-001", Section: "Floor", Row: "A", Number: 1, Available: true},
+				{SeatID: "seat-002", Section: "Floor", Row: "A", Number: 2},
+				{SeatID: "seat-003", Section: "Floor", Row: "A", Number: 3, Available: true},
+			},
+			expectedAvailable: 2,
+		},
+		{name: "event without seating", seats: []*models.EventSeat{}, expectedErr: ErrNotSeated},
+		{name: "missing event", seats: []*models.EventSeat{}, findErr: repositories.ErrNotFound, expectedErr: ErrEventNotFound},
+		{name: "malformed event id", eventID: "not-a-uuid", expectedErr: ErrEventNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.eventID == "" {
+				tt.eventID = eventID
+			}
+			seatRepo := mocks.NewSeatRepository(t)
+			eventRepo := mocks.NewEventRepository(t)
+			if tt.eventID == eventID {
+				seatRepo.On("ListEventSeats", mock.Anything, eventID).Return(tt.seats, nil).Once()
+			}
+			switch {
+			case tt.eventID != eventID:
+				// Malformed ids are answered without a query
+			case tt.findErr != nil:
+				eventRepo.On("FindByID", mock.Anything, eventID).Return(nil, tt.findErr).Once()
+			case len(tt.seats) == 0:
+				eventRepo.On("FindByID", mock.Anything, eventID).Return(&models.Event{ID: eventID}, nil).Once()
+			}
+
+			svc := NewSeatService(mocks.NewUnitOfWork(t), seatRepo, eventRepo, zerolog.Nop())
+			got, err := svc.GetEventSeats(context.Background(), tt.eventID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAvailable, got.Available)
+			assert.Len(t, got.Seats, len(tt.seats))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
+	"github.com/baramulti/ticketing-system/backend/pkg/ticketpass"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTicketPassService_IssuePass
+// Summary: Tests issuing the signed pass behind a ticket's QR code
+// Purpose: Verify owners and admins get a pass that verifies until the configured time after the event,
+// strangers are told the ticket does not exist, and only valid tickets of confirmed orders get one
+func TestTicketPassService_IssuePass(t *testing.T) {
+	const ticketID = "6f1c2f1e-8d4b-4a43-9a8e-0c2b8f0a1d01"
+	eventDate := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name        string
+		actor       Actor
+		ticketID    string
+		ticketErr   error
+		ticket      string
+		order       string
+		expectedErr error
+	}{
+		{
+			name:   "owner gets a pass",
+			actor:  Actor{UserID: "user-001"},
+			ticket: string(models.TicketStatusValid),
+			order:  string(models.OrderStatusConfirmed),
+		},
+		{
+			name:   "admin gets a pass",
+			actor:  Actor{UserID: "admin-001", Roles: []string{models.RoleAdmin}},
+			ticket: string(models.TicketStatusValid),
+			order:  string(models.OrderStatusConfirmed),
+		},
+		{
+			name:        "stranger",
+			actor:       Actor{UserID: "user-002"},
+			ticket:      string(models.TicketStatusValid),
+			order:       string(models.OrderStatusConfirmed),
+			expectedErr: ErrTicketNotFound,
+		},
+		{
+			name:        "invalid ticket id",
+			actor:       Actor{UserID: "user-001"},
+			ticketID:    "not-a-uuid",
+			expectedErr: ErrTicketNotFound,
+		},
+		{
+			name:        "ticket not found",
+			actor:       Actor{UserID: "user-001"},
+			ticketErr:   repositories.ErrNotFound,
+			expectedErr: ErrTicketNotFound,
+		},
+		{
+			name:        "order not confirmed yet",
+			actor:       Actor{UserID: "user-001"},
+			ticket:      string(models.TicketStatusValid),
+			order:       string(models.OrderStatusPaid),
+			expectedErr: ErrTicketNotValid,
+		},
+		{
+			name:        "ticket already used",
+			actor:       Actor{UserID: "user-001"},
+			ticket:      string(models.TicketStatusUsed),
+			order:       string(models.OrderStatusConfirmed),
+			expectedErr: ErrTicketNotValid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketRepo := mocks.NewTicketRepository(t)
+			eventRepo := mocks.NewEventRepository(t)
+
+			key, err := jwtutil.GenerateEd25519Key()
+			require.NoError(t, err)
+			signer, err := ticketpass.NewSigner(key)
+			require.NoError(t, err)
+
+			id := tt.ticketID
+			if id == "" {
+				id = ticketID
+				if tt.ticketErr != nil {
+					ticketRepo.On("FindTicketByID", context.Background(), id).Return(nil, tt.ticketErr)
+				} else {
+					ticketRepo.On("FindTicketByID", context.Background(), id).
+						Return(&models.Ticket{ID: id, OrderID: "order-001", Status: tt.ticket}, nil)
+					ticketRepo.On("FindOrderByID", context.Background(), "order-001").
+						Return(&models.TicketOrder{ID: "order-001", UserID: "user-001", EventID: "event-001", Status: tt.order}, nil)
+				}
+			}
+			if tt.expectedErr == nil {
+				eventRepo.On("FindByID", context.Background(), "event-001").
+					Return(&models.Event{ID: "event-001", EventDate: eventDate}, nil)
+			}
+
+			svc := NewTicketPassService(ticketRepo, eventRepo, signer, config.TicketPassConfig{Validity: 24 * time.Hour}, zerolog.Nop())
+			pass, err := svc.IssuePass(context.Background(), tt.actor, id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, pass)
+				return
+			}
+			assert.NoError(t, err)
+			claims, err := signer.Verifier().Verify(pass, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, ticketpass.Claims{TicketID: id, EventID: "event-001", ExpiresAt: eventDate.Add(24 * time.Hour).Unix()}, *claims)
+		})
+	}
+}
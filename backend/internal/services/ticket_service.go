@@ -2,75 +2,461 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/dto"
 	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/payment"
 	"github.com/baramulti/ticketing-system/backend/internal/repositories"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
+// Ticket prices are stored in rupiah
+const paymentCurrency = "IDR"
+
 type TicketService interface {
 	PurchaseTicket(ctx context.Context, userID string, req *dto.PurchaseRequest) (*dto.PurchaseResponse, error)
 	GetUserOrders(ctx context.Context, userID string) ([]*models.TicketOrder, error)
-	GetOrderByID(ctx context.Context, orderID string) (*models.TicketOrder, error)
+	// GetOrderByID returns the order with its tickets and event. Only the
+	// buyer and admins can see it.
+	GetOrderByID(ctx context.Context, actor Actor, orderID string) (*models.TicketOrder, error)
+	// CancelOrder cancels the buyer's unconfirmed order, voids any payment
+	// authorization and puts the tickets back on sale
+	CancelOrder(ctx context.Context, actor Actor, orderID, reason string) (*models.TicketOrder, error)
+	// RefundOrder returns a paid or confirmed order's payment and puts the
+	// tickets back on sale. Callers are authorized by permission, not ownership.
+	RefundOrder(ctx context.Context, actor Actor, orderID, reason string) (*models.TicketOrder, error)
+	// ReleaseExpiredHolds cancels pending orders whose hold has passed and
+	// returns their tickets to the events. It reports how many were released.
+	ReleaseExpiredHolds(ctx context.Context) (int, error)
 }
 
 type ticketService struct {
-	ticketRepo repositories.TicketRepository
-	eventRepo  repositories.EventRepository
-	log        zerolog.Logger
+	ticketRepo  repositories.TicketRepository
+	eventRepo   repositories.EventRepository
+	uow         repositories.UnitOfWork
+	gateway     payment.Gateway
+	paymentCfg  config.PaymentConfig
+	checkoutCfg config.CheckoutConfig
+	log         zerolog.Logger
 }
 
 func NewTicketService(
 	ticketRepo repositories.TicketRepository,
 	eventRepo repositories.EventRepository,
+	uow repositories.UnitOfWork,
+	gateway payment.Gateway,
+	paymentCfg config.PaymentConfig,
+	checkoutCfg config.CheckoutConfig,
 	log zerolog.Logger,
 ) TicketService {
 	return &ticketService{
-		ticketRepo: ticketRepo,
-		eventRepo:  eventRepo,
-		log:        log,
+		ticketRepo:  ticketRepo,
+		eventRepo:   eventRepo,
+		uow:         uow,
+		gateway:     gateway,
+		paymentCfg:  paymentCfg,
+		checkoutCfg: checkoutCfg,
+		log:         log,
 	}
 }
 
+// PurchaseTicket reserves the tickets, then charges and captures the payment.
+// The order moves pending -> paid -> confirmed as each gateway call succeeds.
+// A decline releases the tickets; a gateway timeout leaves the order where it
+// got to, since the provider may still complete the payment.
 func (s *ticketService) PurchaseTicket(ctx context.Context, userID string, req *dto.PurchaseRequest) (*dto.PurchaseResponse, error) {
-	// TODO: Production flow:
-	// 1. Validate event exists: event, err := s.eventRepo.FindByID(ctx, req.EventID)
-	// 2. Check availability: event.AvailableTickets >= req.Quantity
-	// 3. Start database transaction
-	// 4. Lock event row: SELECT FOR UPDATE
-	// 5. Process payment via gateway (Stripe/Midtrans)
-	// 6. Create order record with status "pending"
-	// 7. Decrement event.AvailableTickets
-	// 8. Generate unique ticket codes
-	// 9. Update order status to "confirmed"
-	// 10. Commit transaction
-	// 11. Send confirmation email (async job)
+	order, err := s.reserve(ctx, userID, req)
+	if err != nil {
+		if !isReservationRefusal(err) {
+			s.log.Error().Err(err).Str("user_id", userID).Str("event_id", req.EventID).Msg("ticket reservation failed")
+		}
+		return nil, err
+	}
+
+	// The buyer disconnecting must not abort a charge halfway
+	ctx = context.WithoutCancel(ctx)
+	txn, err := s.charge(ctx, order)
+	if err != nil {
+		return s.handleChargeError(ctx, order, err)
+	}
+	if err := s.setPayment(ctx, order, txn.ID, models.OrderStatusPaid, "payment authorized"); err != nil {
+		return nil, err
+	}
+	if order.Status == string(models.OrderStatusCancelled) {
+		// Cancelled by the buyer or the hold sweeper while the charge was in flight
+		s.voidAuthorization(ctx, order, txn.ID)
+		return s.purchaseResponse(order, "", "Order was cancelled before payment completed"), nil
+	}
+	if order.Status != string(models.OrderStatusPaid) {
+		// A webhook already settled the order; nothing left to capture
+		return s.purchaseResponse(order, txn.ID, "Payment processed"), nil
+	}
+
+	captured, err := s.capture(ctx, txn.ID)
+	if err != nil {
+		// The charge is authorized and recorded; capture can be retried
+		s.log.Warn().Err(err).Str("order_id", order.ID).Str("transaction_id", txn.ID).Msg("payment capture incomplete")
+		return s.purchaseResponse(order, txn.ID, "Payment authorized, confirmation pending"), nil
+	}
+	if err := s.setPayment(ctx, order, captured.ID, models.OrderStatusConfirmed, "payment captured"); err != nil {
+		return nil, err
+	}
 
 	s.log.Info().
+		Str("order_id", order.ID).
 		Str("user_id", userID).
 		Str("event_id", req.EventID).
+		Str("transaction_id", captured.ID).
 		Int("qty", req.Quantity).
-		Msg("purchase attempt (stub)")
+		Msg("ticket purchase successful")
 
-	// STUB: Return mock successful purchase
-	orderID := uuid.New().String()
-	transactionID := fmt.Sprintf("TXN-%d", time.Now().Unix())
+	return s.purchaseResponse(order, captured.ID, fmt.Sprintf("Successfully purchased %d ticket(s)", req.Quantity)), nil
+}
 
-	s.log.Info().
-		Str("order_id", orderID).
-		Str("transaction_id", transactionID).
-		Msg("ticket purchase successful (mock)")
+// reserve locks the event, takes the tickets from inventory and stores a
+// pending order with its tickets, all in one transaction. Concurrent buyers
+// queue on the row lock instead of overselling. The tickets are held for
+// HoldWindow; if the order is still pending then, the hold sweeper releases it.
+func (s *ticketService) reserve(ctx context.Context, userID string, req *dto.PurchaseRequest) (*models.TicketOrder, error) {
+	holdExpiresAt := time.Now().Add(s.checkoutCfg.HoldWindow)
+	order := &models.TicketOrder{
+		EventID:       req.EventID,
+		UserID:        userID,
+		Quantity:      req.Quantity,
+		Status:        string(models.OrderStatusPending),
+		HoldExpiresAt: &holdExpiresAt,
+	}
+
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		event, err := tx.Events.FindByIDForUpdate(ctx, req.EventID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return ErrEventNotFound
+			}
+			return err
+		}
+		if !event.EventDate.After(time.Now()) {
+			return ErrEventEnded
+		}
+		if event.AvailableTickets < req.Quantity {
+			return ErrInsufficientTickets
+		}
+		if err := checkSeatRequest(event, req); err != nil {
+			return err
+		}
+
+		price, err := takeTierTickets(ctx, tx, event, req.TierID, req.Quantity, time.Now())
+		if err != nil {
+			return err
+		}
+		if req.TierID != "" {
+			order.TierID = &req.TierID
+		}
+		if err := tx.Events.DecrementAvailableTickets(ctx, event.ID, req.Quantity); err != nil {
+			if errors.Is(err, repositories.ErrInsufficientTickets) {
+				return ErrInsufficientTickets
+			}
+			return err
+		}
+
+		order.TotalPrice = price * float64(req.Quantity)
+		if err := tx.Tickets.CreateOrder(ctx, order); err != nil {
+			return err
+		}
+
+		if len(req.SeatIDs) > 0 {
+			if err := tx.Seats.HoldSeats(ctx, event.ID, order.ID, req.SeatIDs); err != nil {
+				if errors.Is(err, repositories.ErrConflict) {
+					return ErrSeatsUnavailable
+				}
+				return err
+			}
+		}
+
+		tickets := make([]*models.Ticket, req.Quantity)
+		for i := range tickets {
+			tickets[i] = &models.Ticket{OrderID: order.ID, TicketCode: newTicketCode()}
+			if len(req.SeatIDs) > 0 {
+				tickets[i].SeatID = &req.SeatIDs[i]
+			}
+		}
+		return tx.Tickets.CreateTickets(ctx, tickets)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// checkSeatRequest checks the purchase names one seat per ticket for events
+// with reserved seating, and no seats for the others
+func checkSeatRequest(event *models.Event, req *dto.PurchaseRequest) error {
+	switch {
+	case event.SeatMapID == nil && len(req.SeatIDs) > 0:
+		return ErrNotSeated
+	case event.SeatMapID != nil && len(req.SeatIDs) == 0:
+		return ErrSeatsRequired
+	case len(req.SeatIDs) > 0 && len(req.SeatIDs) != req.Quantity:
+		return ErrSeatCountMismatch
+	}
+	return nil
+}
+
+// takeTierTickets checks the requested tier and takes the tickets from its
+// inventory, returning the price per ticket. Events sold by tier take no
+// orders without one; other events sell at the event's ticket price.
+func takeTierTickets(ctx context.Context, tx repositories.TxRepositories, event *models.Event, tierID string, quantity int, now time.Time) (float64, error) {
+	if tierID == "" {
+		tiers, err := tx.Tiers.ListByEventID(ctx, event.ID)
+		if err != nil {
+			return 0, err
+		}
+		if len(tiers) > 0 {
+			return 0, ErrTierRequired
+		}
+		return event.TicketPrice, nil
+	}
+
+	tier, err := tx.Tiers.FindByID(ctx, tierID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return 0, ErrTierNotFound
+		}
+		return 0, err
+	}
+	if tier.EventID != event.ID {
+		return 0, ErrTierNotFound
+	}
+	if !tier.OnSale(now) {
+		return 0, ErrTierNotOnSale
+	}
+	if tier.MaxPerOrder != nil && quantity > *tier.MaxPerOrder {
+		return 0, ErrTierOrderLimit
+	}
+	if err := tx.Tiers.DecrementAvailableTickets(ctx, tier.ID, quantity); err != nil {
+		if errors.Is(err, repositories.ErrInsufficientTickets) {
+			return 0, ErrInsufficientTickets
+		}
+		return 0, err
+	}
+	return tier.Price, nil
+}
+
+// isReservationRefusal reports whether a reservation failed because of the
+// request rather than an error worth logging
+func isReservationRefusal(err error) bool {
+	for _, target := range []error{
+		ErrEventNotFound, ErrEventEnded, ErrInsufficientTickets,
+		ErrTierNotFound, ErrTierRequired, ErrTierNotOnSale, ErrTierOrderLimit,
+		ErrNotSeated, ErrSeatsRequired, ErrSeatCountMismatch, ErrSeatsUnavailable,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ticketService) charge(ctx context.Context, order *models.TicketOrder) (*payment.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.paymentCfg.Timeout)
+	defer cancel()
+
+	return s.gateway.Charge(ctx, payment.ChargeRequest{
+		Reference: order.ID,
+		Amount:    order.TotalPrice,
+		Currency:  paymentCurrency,
+	})
+}
+
+func (s *ticketService) capture(ctx context.Context, transactionID string) (*payment.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.paymentCfg.Timeout)
+	defer cancel()
+	return s.gateway.Capture(ctx, transactionID)
+}
+
+// voidAuthorization releases a charge that no longer has an order to pay for.
+// Failures are logged for manual follow-up; the order is already cancelled.
+func (s *ticketService) voidAuthorization(ctx context.Context, order *models.TicketOrder, transactionID string) {
+	ctx, cancel := context.WithTimeout(ctx, s.paymentCfg.Timeout)
+	defer cancel()
+
+	if _, err := s.gateway.Void(ctx, transactionID); err != nil {
+		s.log.Error().Err(err).Str("order_id", order.ID).Str("transaction_id", transactionID).
+			Msg("payment authorized for a cancelled order, void required")
+	}
+}
+
+func (s *ticketService) handleChargeError(ctx context.Context, order *models.TicketOrder, err error) (*dto.PurchaseResponse, error) {
+	switch {
+	case errors.Is(err, payment.ErrTimeout):
+		// Unknown outcome: keep the reservation until the provider reports back
+		s.log.Warn().Err(err).Str("order_id", order.ID).Msg("payment outcome unknown, order left pending")
+		return s.purchaseResponse(order, "", "Payment is being processed"), nil
+	case errors.Is(err, payment.ErrDeclined):
+		s.log.Info().Str("order_id", order.ID).Msg("payment declined")
+		if relErr := s.release(ctx, order, "payment declined"); relErr != nil {
+			return nil, relErr
+		}
+		return nil, ErrPaymentDeclined
+	default:
+		s.log.Error().Err(err).Str("order_id", order.ID).Msg("payment charge failed")
+		if relErr := s.release(ctx, order, "payment failed"); relErr != nil {
+			return nil, relErr
+		}
+		return nil, ErrPaymentFailed
+	}
+}
+
+// release cancels an unpaid order and returns its tickets to the inventory.
+// If a payment webhook already moved the order on, it is left alone.
+func (s *ticketService) release(ctx context.Context, order *models.TicketOrder, reason string) error {
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		err := tx.Tickets.UpdateOrderStatus(ctx, models.OrderStatusChange{
+			OrderID: order.ID,
+			From:    models.OrderStatusPending,
+			To:      models.OrderStatusCancelled,
+			ActorID: &order.UserID,
+			Reason:  reason,
+		})
+		if errors.Is(err, repositories.ErrConflict) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		order.Status = string(models.OrderStatusCancelled)
+		return releaseTickets(ctx, tx, order)
+	})
+	if err != nil {
+		s.log.Error().Err(err).Str("order_id", order.ID).Msg("failed to release reserved tickets")
+	}
+	return err
+}
+
+// releaseTickets voids an order's tickets and puts them, and their seats,
+// back on sale. The caller has already moved the order to cancelled or refunded.
+// Only the tickets that were actually voided are restocked: a ticket already
+// used at the gate keeps its place.
+func releaseTickets(ctx context.Context, tx repositories.TxRepositories, order *models.TicketOrder) error {
+	released, err := tx.Tickets.UpdateTicketsStatusByOrderID(ctx, order.ID, models.TicketStatusCancelled)
+	if err != nil {
+		return err
+	}
+	if err := tx.Seats.ReleaseSeatsByOrder(ctx, order.ID); err != nil {
+		return err
+	}
+	if released == 0 {
+		return nil
+	}
+	if order.TierID != nil {
+		if err := tx.Tiers.IncrementAvailableTickets(ctx, *order.TierID, released); err != nil {
+			return err
+		}
+	}
+	return tx.Events.IncrementAvailableTickets(ctx, order.EventID, released)
+}
+
+// setPayment advances the order one step. A payment webhook may have got
+// there first; the order then keeps whatever status the webhook gave it.
+func (s *ticketService) setPayment(ctx context.Context, order *models.TicketOrder, transactionID string, to models.TicketOrderStatus, reason string) error {
+	change := models.OrderStatusChange{
+		OrderID: order.ID,
+		From:    models.TicketOrderStatus(order.Status),
+		To:      to,
+		ActorID: &order.UserID,
+		Reason:  reason,
+	}
+	err := s.ticketRepo.UpdateOrderPayment(ctx, change, transactionID)
+	if errors.Is(err, repositories.ErrConflict) {
+		current, findErr := s.ticketRepo.FindOrderByID(ctx, order.ID)
+		if findErr != nil {
+			return findErr
+		}
+		order.Status = current.Status
+		order.PaymentID = current.PaymentID
+		return nil
+	}
+	if err != nil {
+		s.log.Error().Err(err).Str("order_id", order.ID).Str("status", string(to)).Msg("failed to record payment")
+		return err
+	}
+	order.PaymentID = &transactionID
+	order.Status = string(to)
+	return nil
+}
 
-	return &dto.PurchaseResponse{
-		OrderID:       orderID,
+func (s *ticketService) purchaseResponse(order *models.TicketOrder, transactionID, message string) *dto.PurchaseResponse {
+	resp := &dto.PurchaseResponse{
+		OrderID:       order.ID,
 		TransactionID: transactionID,
-		Status:        "confirmed",
-		Message:       fmt.Sprintf("Successfully purchased %d ticket(s)", req.Quantity),
-	}, nil
+		Status:        order.Status,
+		TotalPrice:    order.TotalPrice,
+		Message:       message,
+	}
+	// Only a pending order can still lose its tickets
+	if order.Status == string(models.OrderStatusPending) {
+		resp.HeldUntil = order.HoldExpiresAt
+	}
+	return resp
+}
+
+// expiredHoldBatch bounds how many orders one sweep transaction locks
+const expiredHoldBatch = 100
+
+func (s *ticketService) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	released := 0
+	for {
+		var batch int
+		err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+			orders, err := tx.Tickets.FindExpiredHoldsForUpdate(ctx, expiredHoldBatch)
+			if err != nil {
+				return err
+			}
+			batch = len(orders)
+
+			for _, order := range orders {
+				err := tx.Tickets.UpdateOrderStatus(ctx, models.OrderStatusChange{
+					OrderID: order.ID,
+					From:    models.OrderStatusPending,
+					To:      models.OrderStatusCancelled,
+					Reason:  "hold expired",
+				})
+				if err != nil {
+					return err
+				}
+				if err := releaseTickets(ctx, tx, order); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			s.log.Error().Err(err).Int("released", released).Msg("failed to release expired holds")
+			return released, err
+		}
+
+		released += batch
+		if batch < expiredHoldBatch {
+			break
+		}
+	}
+
+	if released > 0 {
+		s.log.Info().Int("released", released).Msg("expired ticket holds released")
+	}
+	return released, nil
+}
+
+// newTicketCode returns an unguessable ticket code: 128 bits from the
+// operating system's CSPRNG, base32-encoded so it can be read out or typed
+func newTicketCode() string {
+	return "TKT-" + rand.Text()
 }
 
 func (s *ticketService) GetUserOrders(ctx context.Context, userID string) ([]*models.TicketOrder, error) {
@@ -78,7 +464,203 @@ func (s *ticketService) GetUserOrders(ctx context.Context, userID string) ([]*mo
 	return s.ticketRepo.ListOrdersByUserID(ctx, userID)
 }
 
-func (s *ticketService) GetOrderByID(ctx context.Context, orderID string) (*models.TicketOrder, error) {
-	// TODO: fetch order with tickets
-	return s.ticketRepo.FindOrderByID(ctx, orderID)
-}
\ No newline at end of file
+func (s *ticketService) GetOrderByID(ctx context.Context, actor Actor, orderID string) (*models.TicketOrder, error) {
+	if uuid.Validate(orderID) != nil {
+		return nil, ErrOrderNotFound
+	}
+	order, err := s.ticketRepo.FindOrderByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if err := authorizeOrderOwner(actor, order); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.ticketRepo.FindTicketsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	order.Tickets = make([]models.Ticket, len(tickets))
+	for i, ticket := range tickets {
+		order.Tickets[i] = *ticket
+	}
+
+	history, err := s.ticketRepo.ListOrderHistory(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	order.History = make([]models.OrderStatusHistory, len(history))
+	for i, entry := range history {
+		order.History[i] = *entry
+	}
+
+	event, err := s.eventRepo.FindByID(ctx, order.EventID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+	order.Event = event
+
+	return order, nil
+}
+
+// authorizeOrderOwner lets the buyer and admins through. Anyone else is told
+// the order does not exist, so order IDs cannot be probed.
+func authorizeOrderOwner(actor Actor, order *models.TicketOrder) error {
+	if order.UserID != actor.UserID && !actor.IsAdmin() {
+		return ErrOrderNotFound
+	}
+	return nil
+}
+
+func (s *ticketService) CancelOrder(ctx context.Context, actor Actor, orderID, reason string) (*models.TicketOrder, error) {
+	if reason == "" {
+		reason = "cancelled on request"
+	}
+	change := models.OrderStatusChange{OrderID: orderID, To: models.OrderStatusCancelled, ActorID: &actor.UserID, Reason: reason}
+	order, err := s.closeOrder(ctx, change, ErrOrderNotCancellable, func(order *models.TicketOrder) error {
+		return authorizeOrderOwner(actor, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Str("order_id", order.ID).Str("by", actor.UserID).Msg("order cancelled")
+	return order, nil
+}
+
+func (s *ticketService) RefundOrder(ctx context.Context, actor Actor, orderID, reason string) (*models.TicketOrder, error) {
+	if reason == "" {
+		reason = "refunded on request"
+	}
+	change := models.OrderStatusChange{OrderID: orderID, To: models.OrderStatusRefunded, ActorID: &actor.UserID, Reason: reason}
+	order, err := s.closeOrder(ctx, change, ErrOrderNotRefundable, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info().Str("order_id", order.ID).Str("by", actor.UserID).Float64("amount", order.TotalPrice).Msg("order refunded")
+	return order, nil
+}
+
+// closeOrder moves an order to change.To (cancelled or refunded), reverses
+// its payment and returns the tickets to the event. change.From is filled in
+// from the locked order. The order row stays locked for the gateway call, so
+// a webhook for the same order waits instead of racing it. notAllowed wraps
+// the *models.TransitionError when the order's status cannot move.
+func (s *ticketService) closeOrder(
+	ctx context.Context,
+	change models.OrderStatusChange,
+	notAllowed error,
+	authorize func(order *models.TicketOrder) error,
+) (*models.TicketOrder, error) {
+	orderID := change.OrderID
+	if uuid.Validate(orderID) != nil {
+		return nil, ErrOrderNotFound
+	}
+	// Once the gateway has returned the money the order must be updated too
+	ctx = context.WithoutCancel(ctx)
+
+	var order *models.TicketOrder
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		var err error
+		order, err = tx.Tickets.FindOrderByIDForUpdate(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
+		}
+		if authorize != nil {
+			if err := authorize(order); err != nil {
+				return err
+			}
+		}
+
+		// A received ticket was never paid for; only the buyer's order holds money
+		if order.Source == string(models.OrderSourceTransfer) {
+			return notAllowed
+		}
+		change.From = models.TicketOrderStatus(order.Status)
+		if err := change.From.ValidateTransition(change.To); err != nil {
+			return fmt.Errorf("%w: %w", notAllowed, err)
+		}
+		if err := checkNoTransfers(ctx, tx, order); err != nil {
+			return err
+		}
+		if err := s.reversePayment(ctx, order, change.From); err != nil {
+			return err
+		}
+
+		if err := tx.Tickets.UpdateOrderStatus(ctx, change); err != nil {
+			return err
+		}
+		order.Status = string(change.To)
+		return releaseTickets(ctx, tx, order)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrOrderNotFound) && !errors.Is(err, notAllowed) &&
+			!errors.Is(err, ErrOrderHasTransfers) && !errors.Is(err, ErrRefundFailed) {
+			s.log.Error().Err(err).Str("order_id", orderID).Str("status", string(change.To)).Msg("failed to close order")
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// checkNoTransfers refuses to close a confirmed order once one of its tickets
+// has been given away; the money would go back while the recipient still holds
+// a ticket.
+func checkNoTransfers(ctx context.Context, tx repositories.TxRepositories, order *models.TicketOrder) error {
+	if order.Status != string(models.OrderStatusConfirmed) {
+		return nil
+	}
+	tickets, err := tx.Tickets.FindTicketsByOrderID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	for _, ticket := range tickets {
+		if ticket.Status == string(models.TicketStatusTransferred) {
+			return ErrOrderHasTransfers
+		}
+	}
+	return nil
+}
+
+// reversePayment voids an authorized (paid) order's charge or refunds a
+// captured (confirmed) one. Pending orders have nothing to reverse. A retry
+// after an earlier attempt went through at the provider is treated as success.
+func (s *ticketService) reversePayment(ctx context.Context, order *models.TicketOrder, from models.TicketOrderStatus) error {
+	if from != models.OrderStatusPaid && from != models.OrderStatusConfirmed {
+		return nil
+	}
+	if order.PaymentID == nil {
+		return fmt.Errorf("order %s is %s without a payment transaction", order.ID, from)
+	}
+	transactionID := *order.PaymentID
+
+	gatewayCtx, cancel := context.WithTimeout(ctx, s.paymentCfg.Timeout)
+	defer cancel()
+
+	var err error
+	reversed := payment.StatusVoided
+	if from == models.OrderStatusPaid {
+		_, err = s.gateway.Void(gatewayCtx, transactionID)
+	} else {
+		reversed = payment.StatusRefunded
+		_, err = s.gateway.Refund(gatewayCtx, transactionID, order.TotalPrice)
+	}
+
+	if errors.Is(err, payment.ErrInvalidState) {
+		if txn, statusErr := s.gateway.Status(gatewayCtx, transactionID); statusErr == nil && txn.Status == reversed {
+			return nil
+		}
+	}
+	if err != nil {
+		s.log.Error().Err(err).Str("order_id", order.ID).Str("transaction_id", transactionID).Msg("payment reversal failed")
+		return ErrRefundFailed
+	}
+	return nil
+}
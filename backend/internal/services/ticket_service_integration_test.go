@@ -0,0 +1,280 @@
+//go:build integration
+
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/payment"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/testutil/pgtest"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	pgtest.Run(m)
+}
+
+// TestTicketService_Integration_ConcurrentPurchase
+// Summary: Hundreds of parallel purchases against one event in Postgres
+// Purpose: Verify the locked purchase transaction never oversells: inventory stops at zero and every sold seat has exactly one ticket
+func TestTicketService_Integration_ConcurrentPurchase(t *testing.T) {
+	db := pgtest.DB(t)
+	pgtest.Reset(t)
+	ctx := context.Background()
+
+	const (
+		capacity = 100
+		buyers   = 300
+	)
+
+	userRepo := repositories.NewUserRepository(db)
+	eventRepo := repositories.NewEventRepository(db)
+	ticketRepo := repositories.NewTicketRepository(db)
+
+	user := &models.User{Email: "buyer@example.com", PasswordHash: "hash", IsActive: true}
+	require.NoError(t, userRepo.Create(ctx, user))
+	venue := &models.Venue{Name: "JIExpo Kemayoran", TimeZone: "Asia/Jakarta", Capacity: capacity}
+	require.NoError(t, repositories.NewVenueRepository(db).Create(ctx, venue))
+	event := &models.Event{
+		Title:            "Java Jazz Festival",
+		EventDate:        time.Now().Add(30 * 24 * time.Hour),
+		VenueID:          venue.ID,
+		TicketPrice:      750000,
+		TotalTickets:     capacity,
+		AvailableTickets: capacity,
+	}
+	require.NoError(t, eventRepo.Create(ctx, event))
+
+	service := NewTicketService(ticketRepo, eventRepo, repositories.NewUnitOfWork(db),
+		payment.NewSimulator(payment.ModeSucceed, 0), config.PaymentConfig{Timeout: time.Second},
+		config.CheckoutConfig{HoldWindow: 10 * time.Minute}, zerolog.Nop())
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		sold     int
+		rejected int
+		failures []error
+	)
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(qty int) {
+			defer wg.Done()
+			_, err := service.PurchaseTicket(ctx, user.ID, &dto.PurchaseRequest{EventID: event.ID, Quantity: qty})
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				sold += qty
+			case errors.Is(err, ErrInsufficientTickets):
+				rejected++
+			default:
+				failures = append(failures, err)
+			}
+		}(i%3 + 1)
+	}
+	wg.Wait()
+
+	require.Empty(t, failures)
+	assert.Positive(t, rejected)
+
+	stored, err := eventRepo.FindByID(ctx, event.ID)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, stored.AvailableTickets, 0)
+	assert.Equal(t, capacity-sold, stored.AvailableTickets)
+
+	var tickets int
+	require.NoError(t, db.Get(&tickets, `
+		SELECT COUNT(*) FROM tickets t
+		JOIN ticket_orders o ON o.id = t.order_id
+		WHERE o.event_id = $1`, event.ID))
+	assert.Equal(t, sold, tickets)
+}
+
+// TestTicketService_Integration_ConcurrentSeatPurchase
+// Summary: Many buyers racing for the same numbered seats in Postgres
+// Purpose: Verify each seat is sold to exactly one buyer and everyone else is told it is taken
+func TestTicketService_Integration_ConcurrentSeatPurchase(t *testing.T) {
+	db := pgtest.DB(t)
+	pgtest.Reset(t)
+	ctx := context.Background()
+
+	const (
+		rowSeats = 20
+		buyers   = 100
+	)
+
+	userRepo := repositories.NewUserRepository(db)
+	eventRepo := repositories.NewEventRepository(db)
+	ticketRepo := repositories.NewTicketRepository(db)
+	seatRepo := repositories.NewSeatRepository(db)
+
+	user := &models.User{Email: "buyer@example.com", PasswordHash: "hash", IsActive: true}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	venue := &models.Venue{Name: "Jakarta Convention Center", TimeZone: "Asia/Jakarta", Capacity: rowSeats}
+	require.NoError(t, repositories.NewVenueRepository(db).Create(ctx, venue))
+
+	seatMap := &models.SeatMap{Name: "Plenary Hall", VenueID: venue.ID}
+	require.NoError(t, seatRepo.CreateSeatMap(ctx, seatMap))
+	seats := make([]*models.Seat, rowSeats)
+	for i := range seats {
+		seats[i] = &models.Seat{SeatMapID: seatMap.ID, Section: "Floor", Row: "A", Number: i + 1}
+	}
+	require.NoError(t, seatRepo.CreateSeats(ctx, seats))
+	event := &models.Event{
+		Title:            "Jakarta Tech Conference",
+		EventDate:        time.Now().Add(30 * 24 * time.Hour),
+		VenueID:          venue.ID,
+		TicketPrice:      250000,
+		TotalTickets:     rowSeats,
+		AvailableTickets: rowSeats,
+	}
+	require.NoError(t, eventRepo.Create(ctx, event))
+	_, err := seatRepo.ReplaceEventSeats(ctx, event.ID, seatMap.ID)
+	require.NoError(t, err)
+	event.SeatMapID = &seatMap.ID
+	require.NoError(t, eventRepo.Update(ctx, event))
+
+	service := NewTicketService(ticketRepo, eventRepo, repositories.NewUnitOfWork(db),
+		payment.NewSimulator(payment.ModeSucceed, 0), config.PaymentConfig{Timeout: time.Second},
+		config.CheckoutConfig{HoldWindow: 10 * time.Minute}, zerolog.Nop())
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		sold     int
+		taken    int
+		failures []error
+	)
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(seatID string) {
+			defer wg.Done()
+			_, err := service.PurchaseTicket(ctx, user.ID, &dto.PurchaseRequest{EventID: event.ID, SeatIDs: []string{seatID}, Quantity: 1})
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				sold++
+			case errors.Is(err, ErrSeatsUnavailable):
+				taken++
+			default:
+				failures = append(failures, err)
+			}
+		}(seats[i%rowSeats].ID)
+	}
+	wg.Wait()
+
+	require.Empty(t, failures)
+	assert.Equal(t, rowSeats, sold)
+	assert.Equal(t, buyers-rowSeats, taken)
+
+	var seatTickets, distinctSeats int
+	require.NoError(t, db.QueryRow(`
+		SELECT COUNT(*), COUNT(DISTINCT t.seat_id) FROM tickets t
+		JOIN ticket_orders o ON o.id = t.order_id
+		WHERE o.event_id = $1`, event.ID).Scan(&seatTickets, &distinctSeats))
+	assert.Equal(t, rowSeats, seatTickets)
+	assert.Equal(t, rowSeats, distinctSeats)
+
+	available, err := seatRepo.ListEventSeats(ctx, event.ID)
+	require.NoError(t, err)
+	for _, seat := range available {
+		assert.False(t, seat.Available)
+	}
+}
+
+// TestTicketService_Integration_RefundRacesTransfer
+// Summary: A refund and a ticket transfer of the same order running at once in Postgres
+// Purpose: Verify exactly one of them wins: a refunded order leaves the recipient without a valid ticket,
+// and a completed transfer makes the refund fail with ErrOrderHasTransfers
+func TestTicketService_Integration_RefundRacesTransfer(t *testing.T) {
+	db := pgtest.DB(t)
+	pgtest.Reset(t)
+	ctx := context.Background()
+
+	userRepo := repositories.NewUserRepository(db)
+	eventRepo := repositories.NewEventRepository(db)
+	ticketRepo := repositories.NewTicketRepository(db)
+	uow := repositories.NewUnitOfWork(db)
+
+	buyer := &models.User{Email: "buyer@example.com", PasswordHash: "hash", IsActive: true}
+	require.NoError(t, userRepo.Create(ctx, buyer))
+	friend := &models.User{Email: "friend@example.com", PasswordHash: "hash", IsActive: true}
+	require.NoError(t, userRepo.Create(ctx, friend))
+	venue := &models.Venue{Name: "Istora Senayan", TimeZone: "Asia/Jakarta", Capacity: 10}
+	require.NoError(t, repositories.NewVenueRepository(db).Create(ctx, venue))
+	event := &models.Event{
+		Title:            "Synchronize Fest",
+		EventDate:        time.Now().Add(30 * 24 * time.Hour),
+		VenueID:          venue.ID,
+		TicketPrice:      350000,
+		TotalTickets:     10,
+		AvailableTickets: 10,
+		TransfersEnabled: true,
+	}
+	require.NoError(t, eventRepo.Create(ctx, event))
+
+	// The refund is slow at the gateway, so the transfer starts while it holds the order
+	gateway := payment.NewSimulator(payment.ModeSucceed, 200*time.Millisecond)
+	tickets := NewTicketService(ticketRepo, eventRepo, uow, gateway, config.PaymentConfig{Timeout: time.Second},
+		config.CheckoutConfig{HoldWindow: 10 * time.Minute}, zerolog.Nop())
+	transfers := NewTicketTransferService(uow, ticketRepo, eventRepo, userRepo,
+		config.TransferConfig{Cutoff: 24 * time.Hour}, zerolog.Nop())
+
+	purchase, err := tickets.PurchaseTicket(ctx, buyer.ID, &dto.PurchaseRequest{EventID: event.ID, Quantity: 2})
+	require.NoError(t, err)
+	require.Equal(t, string(models.OrderStatusConfirmed), purchase.Status)
+	bought, err := ticketRepo.FindTicketsByOrderID(ctx, purchase.OrderID)
+	require.NoError(t, err)
+	gateway.SetMode(payment.ModeDelay)
+
+	var (
+		wg                     sync.WaitGroup
+		refundErr, transferErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, refundErr = tickets.RefundOrder(ctx, Actor{UserID: buyer.ID}, purchase.OrderID, "")
+	}()
+	go func() {
+		defer wg.Done()
+		time.Sleep(50 * time.Millisecond)
+		_, transferErr = transfers.TransferTicket(ctx, Actor{UserID: buyer.ID}, bought[0].ID,
+			&dto.TransferTicketRequest{Email: friend.Email})
+	}()
+	wg.Wait()
+
+	var validReceived int
+	require.NoError(t, db.Get(&validReceived, `
+		SELECT COUNT(*) FROM tickets t
+		JOIN ticket_orders o ON o.id = t.order_id
+		WHERE o.user_id = $1 AND t.status = $2`, friend.ID, string(models.TicketStatusValid)))
+	order, err := ticketRepo.FindOrderByID(ctx, purchase.OrderID)
+	require.NoError(t, err)
+
+	if refundErr == nil {
+		assert.ErrorIs(t, transferErr, ErrTicketNotTransferable)
+		assert.Equal(t, string(models.OrderStatusRefunded), order.Status)
+		assert.Zero(t, validReceived)
+		return
+	}
+	assert.ErrorIs(t, refundErr, ErrOrderHasTransfers)
+	assert.NoError(t, transferErr)
+	assert.Equal(t, string(models.OrderStatusConfirmed), order.Status)
+	assert.Equal(t, 1, validReceived)
+}
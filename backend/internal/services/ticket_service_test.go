@@ -2,80 +2,439 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/baramulti/ticketing-system/backend/internal/config"
 	"github.com/baramulti/ticketing-system/backend/internal/dto"
 	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/payment"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
 	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-// Summary: Tests ticket purchase with various quantities and scenarios
+// TestTicketService_PurchaseTicket
+// Summary: Tests ticket purchase inside a unit of work
+// Purpose: Verify the event is locked and decremented, the order and one ticket per seat are created, and failures abort the transaction
 func TestTicketService_PurchaseTicket(t *testing.T) {
+	future := time.Now().Add(30 * 24 * time.Hour)
+	limit := 4
+	seatMapID := "seat-map-001"
+	seats := []string{"5b1e1c1a-0000-4000-8000-000000000001", "5b1e1c1a-0000-4000-8000-000000000002"}
+	tier := func(eventID string, salesStart *time.Time, maxPerOrder *int) *models.TicketTier {
+		return &models.TicketTier{ID: "0c9d8e7f-1a2b-4c3d-8e9f-0a1b2c3d4e5f", EventID: eventID, Name: "VIP", Price: 750000,
+			TotalTickets: 50, AvailableTickets: 50, SalesStart: salesStart, MaxPerOrder: maxPerOrder}
+	}
+
 	tests := []struct {
-		name     string
-		userID   string
-		eventID  string
-		quantity int
-		wantErr  bool
+		name        string
+		event       *models.Event
+		findErr     error
+		decrErr     error
+		tiers       []*models.TicketTier // the event's tiers, when no tier is requested
+		tier        *models.TicketTier   // the requested tier
+		tierDecrErr error
+		seats       []string
+		holdErr     error
+		quantity    int
+		expectedErr error
 	}{
 		{
 			name:     "purchase single ticket",
-			userID:   "user-001",
-			eventID:  "event-001",
+			event:    &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100},
 			quantity: 1,
-			wantErr:  false,
 		},
 		{
-			name:     "purchase multiple tickets",
-			userID:   "user-002",
-			eventID:  "event-002",
+			name:     "purchase last tickets",
+			event:    &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 5},
 			quantity: 5,
-			wantErr:  false,
 		},
 		{
-			name:     "purchase max tickets",
-			userID:   "user-003",
-			eventID:  "event-003",
-			quantity: 10,
-			wantErr:  false,
+			name:        "event not found",
+			findErr:     repositories.ErrNotFound,
+			quantity:    1,
+			expectedErr: ErrEventNotFound,
+		},
+		{
+			name:        "not enough tickets left",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 2},
+			quantity:    3,
+			expectedErr: ErrInsufficientTickets,
+		},
+		{
+			name:        "event already happened",
+			event:       &models.Event{ID: "event-001", EventDate: time.Now().Add(-time.Hour), AvailableTickets: 100},
+			quantity:    1,
+			expectedErr: ErrEventEnded,
+		},
+		{
+			name:        "guarded decrement fails",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 10},
+			decrErr:     repositories.ErrInsufficientTickets,
+			quantity:    2,
+			expectedErr: ErrInsufficientTickets,
+		},
+		{
+			name:     "purchase from a tier",
+			event:    &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100},
+			tier:     tier("event-001", nil, &limit),
+			quantity: 2,
+		},
+		{
+			name:        "event sold by tier needs a tier",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100},
+			tiers:       []*models.TicketTier{tier("event-001", nil, nil)},
+			quantity:    1,
+			expectedErr: ErrTierRequired,
+		},
+		{
+			name:        "tier of another event",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100},
+			tier:        tier("event-002", nil, nil),
+			quantity:    1,
+			expectedErr: ErrTierNotFound,
+		},
+		{
+			name:        "tier not on sale yet",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100},
+			tier:        tier("event-001", &future, nil),
+			quantity:    1,
+			expectedErr: ErrTierNotOnSale,
+		},
+		{
+			name:        "above the tier's per-order limit",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100},
+			tier:        tier("event-001", nil, &limit),
+			quantity:    5,
+			expectedErr: ErrTierOrderLimit,
+		},
+		{
+			name:        "tier sold out",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100},
+			tier:        tier("event-001", nil, nil),
+			tierDecrErr: repositories.ErrInsufficientTickets,
+			quantity:    2,
+			expectedErr: ErrInsufficientTickets,
+		},
+		{
+			name:     "reserved seats",
+			event:    &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100, SeatMapID: &seatMapID},
+			seats:    seats,
+			quantity: 2,
+		},
+		{
+			name:        "seated event without seats",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100, SeatMapID: &seatMapID},
+			quantity:    2,
+			expectedErr: ErrSeatsRequired,
+		},
+		{
+			name:        "seats for an event without seating",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100},
+			seats:       seats,
+			quantity:    2,
+			expectedErr: ErrNotSeated,
+		},
+		{
+			name:        "fewer seats than tickets",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100, SeatMapID: &seatMapID},
+			seats:       seats[:1],
+			quantity:    2,
+			expectedErr: ErrSeatCountMismatch,
+		},
+		{
+			name:        "seat already taken",
+			event:       &models.Event{ID: "event-001", EventDate: future, TicketPrice: 250000, AvailableTickets: 100, SeatMapID: &seatMapID},
+			seats:       seats,
+			holdErr:     repositories.ErrConflict,
+			quantity:    2,
+			expectedErr: ErrSeatsUnavailable,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockTicketRepo := mocks.NewTicketRepository(t)
-			mockEventRepo := mocks.NewEventRepository(t)
-			logger := zerolog.Nop()
+			txEvents := mocks.NewEventRepository(t)
+			txTickets := mocks.NewTicketRepository(t)
+			txTiers := mocks.NewTicketTierRepository(t)
+			txSeats := mocks.NewSeatRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: txEvents, Tickets: txTickets, Tiers: txTiers, Seats: txSeats})
+				},
+			).Once()
+
+			txEvents.On("FindByIDForUpdate", mock.Anything, "event-001").Return(tt.event, tt.findErr).Once()
+			badSeats := tt.expectedErr == ErrSeatsRequired || tt.expectedErr == ErrNotSeated || tt.expectedErr == ErrSeatCountMismatch
+			reachesTier := tt.event != nil && tt.event.EventDate.After(time.Now()) && tt.event.AvailableTickets >= tt.quantity && !badSeats
+			var tierID string
+			price := 0.0
+			if tt.event != nil {
+				price = tt.event.TicketPrice
+			}
+			switch {
+			case !reachesTier:
+			case tt.tier == nil:
+				txTiers.On("ListByEventID", mock.Anything, "event-001").Return(tt.tiers, nil).Once()
+			default:
+				tierID, price = tt.tier.ID, tt.tier.Price
+				txTiers.On("FindByID", mock.Anything, tierID).Return(tt.tier, nil).Once()
+				if tt.expectedErr == nil || tt.tierDecrErr != nil {
+					txTiers.On("DecrementAvailableTickets", mock.Anything, tierID, tt.quantity).Return(tt.tierDecrErr).Once()
+				}
+			}
+			if tt.expectedErr == nil || tt.decrErr != nil || tt.holdErr != nil {
+				txEvents.On("DecrementAvailableTickets", mock.Anything, "event-001", tt.quantity).Return(tt.decrErr).Once()
+			}
+			if tt.expectedErr == nil || tt.holdErr != nil {
+				txTickets.On("CreateOrder", mock.Anything, mock.MatchedBy(func(o *models.TicketOrder) bool {
+					return o.UserID == "user-001" && o.Quantity == tt.quantity &&
+						o.Status == string(models.OrderStatusPending) &&
+						o.TotalPrice == price*float64(tt.quantity) &&
+						(tierID == "" && o.TierID == nil || o.TierID != nil && *o.TierID == tierID)
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.TicketOrder).ID = "order-001"
+				}).Return(nil).Once()
+			}
+			if tt.seats != nil && (tt.expectedErr == nil || tt.holdErr != nil) {
+				txSeats.On("HoldSeats", mock.Anything, "event-001", "order-001", tt.seats).Return(tt.holdErr).Once()
+			}
+			if tt.expectedErr == nil {
+				txTickets.On("CreateTickets", mock.Anything, mock.MatchedBy(func(tickets []*models.Ticket) bool {
+					codes := map[string]bool{}
+					for i, ticket := range tickets {
+						if ticket.OrderID != "order-001" || !strings.HasPrefix(ticket.TicketCode, "TKT-") {
+							return false
+						}
+						if tt.seats == nil && ticket.SeatID != nil || tt.seats != nil && (ticket.SeatID == nil || *ticket.SeatID != tt.seats[i]) {
+							return false
+						}
+						codes[ticket.TicketCode] = true
+					}
+					return len(codes) == tt.quantity
+				})).Return(nil).Once()
+			}
 
-			service := NewTicketService(mockTicketRepo, mockEventRepo, logger)
+			ticketRepo := mocks.NewTicketRepository(t)
+			if tt.expectedErr == nil {
+				ticketRepo.On("UpdateOrderPayment", mock.Anything, orderChange("order-001", models.OrderStatusPending, models.OrderStatusPaid), mock.Anything).Return(nil).Once()
+				ticketRepo.On("UpdateOrderPayment", mock.Anything, orderChange("order-001", models.OrderStatusPaid, models.OrderStatusConfirmed), mock.Anything).Return(nil).Once()
+			}
 
-			req := &dto.PurchaseRequest{
-				EventID:  tt.eventID,
+			service := newPurchaseService(t, ticketRepo, uow, payment.NewSimulator(payment.ModeSucceed, 0))
+			resp, err := service.PurchaseTicket(context.Background(), "user-001", &dto.PurchaseRequest{
+				EventID:  "event-001",
+				TierID:   tierID,
+				SeatIDs:  tt.seats,
 				Quantity: tt.quantity,
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+				return
 			}
 
-			resp, err := service.PurchaseTicket(context.Background(), tt.userID, req)
+			assert.NoError(t, err)
+			assert.Equal(t, "order-001", resp.OrderID)
+			assert.Equal(t, string(models.OrderStatusConfirmed), resp.Status)
+			assert.True(t, strings.HasPrefix(resp.TransactionID, "sim_"))
+			assert.Equal(t, price*float64(tt.quantity), resp.TotalPrice)
+			assert.Contains(t, resp.Message, fmt.Sprintf("%d ticket(s)", tt.quantity))
+		})
+	}
+}
+
+// TestTicketService_PurchaseTicket_RollsBack
+// Summary: Tests that a failed ticket insert surfaces the error
+// Purpose: Ensure the unit of work sees the error (and rolls back) instead of a partial purchase being reported
+func TestTicketService_PurchaseTicket_RollsBack(t *testing.T) {
+	txEvents := mocks.NewEventRepository(t)
+	txTickets := mocks.NewTicketRepository(t)
+	txTiers := mocks.NewTicketTierRepository(t)
+	uow := mocks.NewUnitOfWork(t)
+
+	var txErr error
+	uow.On("Do", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+			txErr = fn(repositories.TxRepositories{Events: txEvents, Tickets: txTickets, Tiers: txTiers})
+			return txErr
+		},
+	).Once()
 
-			if tt.wantErr {
-				assert.Error(t, err)
+	event := &models.Event{ID: "event-001", EventDate: time.Now().Add(time.Hour), TicketPrice: 100000, AvailableTickets: 10}
+	txEvents.On("FindByIDForUpdate", mock.Anything, "event-001").Return(event, nil).Once()
+	txTiers.On("ListByEventID", mock.Anything, "event-001").Return([]*models.TicketTier{}, nil).Once()
+	txEvents.On("DecrementAvailableTickets", mock.Anything, "event-001", 2).Return(nil).Once()
+	txTickets.On("CreateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	txTickets.On("CreateTickets", mock.Anything, mock.Anything).Return(repositories.ErrDuplicate).Once()
+
+	service := newPurchaseService(t, mocks.NewTicketRepository(t), uow, payment.NewSimulator(payment.ModeSucceed, 0))
+	resp, err := service.PurchaseTicket(context.Background(), "user-001", &dto.PurchaseRequest{EventID: "event-001", Quantity: 2})
+
+	assert.ErrorIs(t, err, repositories.ErrDuplicate)
+	assert.ErrorIs(t, txErr, repositories.ErrDuplicate)
+	assert.Nil(t, resp)
+}
+
+// TestTicketService_PurchaseTicket_Payment
+// Summary: Tests the purchase against each simulated gateway behaviour
+// Purpose: Verify the order moves pending -> paid -> confirmed on success, declines release the tickets, and timeouts leave the order pending
+func TestTicketService_PurchaseTicket_Payment(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           payment.SimulatorMode
+		delay          time.Duration
+		expectedStatus models.TicketOrderStatus
+		expectedErr    error
+		released       bool
+	}{
+		{name: "gateway approves", mode: payment.ModeSucceed, expectedStatus: models.OrderStatusConfirmed},
+		{name: "gateway answers late but in time", mode: payment.ModeDelay, delay: 10 * time.Millisecond, expectedStatus: models.OrderStatusConfirmed},
+		{name: "gateway declines", mode: payment.ModeDecline, expectedErr: ErrPaymentDeclined, released: true},
+		{name: "gateway times out", mode: payment.ModeTimeout, expectedStatus: models.OrderStatusPending},
+		{name: "gateway answers after timeout", mode: payment.ModeDelay, delay: time.Second, expectedStatus: models.OrderStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txEvents := mocks.NewEventRepository(t)
+			txTickets := mocks.NewTicketRepository(t)
+			txTiers := mocks.NewTicketTierRepository(t)
+			txSeats := mocks.NewSeatRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: txEvents, Tickets: txTickets, Tiers: txTiers, Seats: txSeats})
+				},
+			)
+
+			event := &models.Event{ID: "event-001", EventDate: time.Now().Add(time.Hour), TicketPrice: 100000, AvailableTickets: 10}
+			txEvents.On("FindByIDForUpdate", mock.Anything, "event-001").Return(event, nil).Once()
+			txTiers.On("ListByEventID", mock.Anything, "event-001").Return([]*models.TicketTier{}, nil).Once()
+			txEvents.On("DecrementAvailableTickets", mock.Anything, "event-001", 2).Return(nil).Once()
+			txTickets.On("CreateOrder", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				args.Get(1).(*models.TicketOrder).ID = "order-001"
+			}).Return(nil).Once()
+			txTickets.On("CreateTickets", mock.Anything, mock.Anything).Return(nil).Once()
+
+			ticketRepo := mocks.NewTicketRepository(t)
+			if tt.expectedStatus == models.OrderStatusConfirmed {
+				ticketRepo.On("UpdateOrderPayment", mock.Anything, orderChange("order-001", models.OrderStatusPending, models.OrderStatusPaid), mock.Anything).Return(nil).Once()
+				ticketRepo.On("UpdateOrderPayment", mock.Anything, orderChange("order-001", models.OrderStatusPaid, models.OrderStatusConfirmed), mock.Anything).Return(nil).Once()
+			}
+			if tt.released {
+				txTickets.On("UpdateOrderStatus", mock.Anything, orderChange("order-001", models.OrderStatusPending, models.OrderStatusCancelled)).Return(nil).Once()
+				txTickets.On("UpdateTicketsStatusByOrderID", mock.Anything, "order-001", models.TicketStatusCancelled).Return(2, nil).Once()
+				txSeats.On("ReleaseSeatsByOrder", mock.Anything, "order-001").Return(nil).Once()
+				txEvents.On("IncrementAvailableTickets", mock.Anything, "event-001", 2).Return(nil).Once()
+			}
+
+			service := NewTicketService(ticketRepo, mocks.NewEventRepository(t), uow,
+				payment.NewSimulator(tt.mode, tt.delay), config.PaymentConfig{Timeout: 50 * time.Millisecond}, testCheckoutConfig, zerolog.Nop())
+			resp, err := service.PurchaseTicket(context.Background(), "user-001", &dto.PurchaseRequest{EventID: "event-001", Quantity: 2})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
 				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, "order-001", resp.OrderID)
+			assert.Equal(t, string(tt.expectedStatus), resp.Status)
+			if tt.expectedStatus == models.OrderStatusPending {
+				// Still waiting on the gateway: the buyer is told how long the tickets are held
+				if assert.NotNil(t, resp.HeldUntil) {
+					assert.WithinDuration(t, time.Now().Add(testCheckoutConfig.HoldWindow), *resp.HeldUntil, 5*time.Second)
+				}
+			} else {
+				assert.Nil(t, resp.HeldUntil)
+			}
+		})
+	}
+}
+
+// TestTicketService_ReleaseExpiredHolds
+// Summary: Tests the sweep of pending orders past their hold
+// Purpose: Verify expired orders are cancelled and their tickets returned to the event and tier in one transaction,
+// and errors abort the batch
+func TestTicketService_ReleaseExpiredHolds(t *testing.T) {
+	tierID := "tier-001"
+	expired := []*models.TicketOrder{
+		{ID: "order-001", EventID: "event-001", Quantity: 2, Status: string(models.OrderStatusPending)},
+		{ID: "order-002", EventID: "event-002", Quantity: 1, Status: string(models.OrderStatusPending), TierID: &tierID},
+	}
+
+	tests := []struct {
+		name          string
+		orders        []*models.TicketOrder
+		releaseErr    error
+		expectedCount int
+	}{
+		{name: "nothing expired", orders: []*models.TicketOrder{}},
+		{name: "releases every expired order", orders: expired, expectedCount: 2},
+		{name: "database error", orders: expired[:1], releaseErr: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txEvents := mocks.NewEventRepository(t)
+			txTickets := mocks.NewTicketRepository(t)
+			txTiers := mocks.NewTicketTierRepository(t)
+			txSeats := mocks.NewSeatRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: txEvents, Tickets: txTickets, Tiers: txTiers, Seats: txSeats})
+				},
+			).Once()
+
+			txTickets.On("FindExpiredHoldsForUpdate", mock.Anything, expiredHoldBatch).Return(tt.orders, nil).Once()
+			for _, order := range tt.orders {
+				txTickets.On("UpdateOrderStatus", mock.Anything, orderChange(order.ID, models.OrderStatusPending, models.OrderStatusCancelled)).Return(nil).Once()
+				txTickets.On("UpdateTicketsStatusByOrderID", mock.Anything, order.ID, models.TicketStatusCancelled).Return(order.Quantity, nil).Once()
+				txSeats.On("ReleaseSeatsByOrder", mock.Anything, order.ID).Return(nil).Once()
+				if order.TierID != nil {
+					txTiers.On("IncrementAvailableTickets", mock.Anything, *order.TierID, order.Quantity).Return(nil).Once()
+				}
+				txEvents.On("IncrementAvailableTickets", mock.Anything, order.EventID, order.Quantity).Return(tt.releaseErr).Once()
+			}
 
+			service := newPurchaseService(t, mocks.NewTicketRepository(t), uow, nil)
+			released, err := service.ReleaseExpiredHolds(context.Background())
+
+			if tt.releaseErr != nil {
+				assert.ErrorIs(t, err, tt.releaseErr)
+				assert.Zero(t, released)
+				return
+			}
 			assert.NoError(t, err)
-			assert.NotNil(t, resp)
-			assert.NotEmpty(t, resp.OrderID)
-			assert.NotEmpty(t, resp.TransactionID)
-			assert.Equal(t, "confirmed", resp.Status)
-			assert.Contains(t, resp.Message, fmt.Sprintf("%d ticket(s)", tt.quantity))
+			assert.Equal(t, tt.expectedCount, released)
 		})
 	}
 }
 
+// orderChange matches an order status change by order and statuses
+func orderChange(orderID string, from, to models.TicketOrderStatus) interface{} {
+	return mock.MatchedBy(func(change models.OrderStatusChange) bool {
+		return change.OrderID == orderID && change.From == from && change.To == to && change.Reason != ""
+	})
+}
+
+var testCheckoutConfig = config.CheckoutConfig{HoldWindow: 10 * time.Minute, SweepInterval: time.Minute}
+
+func newPurchaseService(t *testing.T, ticketRepo *mocks.TicketRepository, uow *mocks.UnitOfWork, gateway payment.Gateway) TicketService {
+	t.Helper()
+	return NewTicketService(ticketRepo, mocks.NewEventRepository(t), uow, gateway, config.PaymentConfig{Timeout: time.Second}, testCheckoutConfig, zerolog.Nop())
+}
+
 // TestTicketService_GetUserOrders
 // Purpose: Verify repository integration for listing orders
 func TestTicketService_GetUserOrders(t *testing.T) {
@@ -142,7 +501,7 @@ func TestTicketService_GetUserOrders(t *testing.T) {
 				Return(tt.mockOrders, tt.mockErr).
 				Once()
 
-			service := NewTicketService(mockTicketRepo, mockEventRepo, logger)
+			service := NewTicketService(mockTicketRepo, mockEventRepo, nil, nil, config.PaymentConfig{}, config.CheckoutConfig{}, logger)
 			orders, err := service.GetUserOrders(context.Background(), tt.userID)
 
 			if tt.expectError {
@@ -165,114 +524,208 @@ func TestTicketService_GetUserOrders(t *testing.T) {
 	}
 }
 
-// Purpose: Validate single order retrieval from repository
+// TestTicketService_GetOrderByID
+// Summary: Tests single order retrieval with tickets, history and event
+// Purpose: Verify the buyer and admins see the order with its relationships, and anyone else gets ErrOrderNotFound
 func TestTicketService_GetOrderByID(t *testing.T) {
-	now := time.Now()
+	orderID := "0b7e4c52-6f3e-4d8a-9c1b-2a3d4e5f6a7b"
+	buyer := Actor{UserID: "user-001", Roles: []string{models.RoleUser}}
 
 	tests := []struct {
 		name        string
 		orderID     string
-		mockOrder   *models.TicketOrder
-		mockErr     error
-		expectError bool
+		actor       Actor
+		findErr     error
+		expectedErr error
 	}{
-		{
-			name:    "valid order found",
-			orderID: "order-valid-123",
-			mockOrder: &models.TicketOrder{
-				ID:         "order-valid-123",
-				EventID:    "event-001",
-				UserID:     "user-001",
-				Quantity:   3,
-				TotalPrice: 750000,
-				Status:     "confirmed",
-				CreatedAt:  now,
-				UpdatedAt:  now,
-			},
-			mockErr:     nil,
-			expectError: false,
-		},
-		{
-			name:        "order not found",
-			orderID:     "order-nonexistent",
-			mockOrder:   nil,
-			mockErr:     fmt.Errorf("order not found"),
-			expectError: true,
-		},
-		{
-			name:    "order with pending status",
-			orderID: "order-pending-456",
-			mockOrder: &models.TicketOrder{
-				ID:         "order-pending-456",
-				EventID:    "event-002",
-				UserID:     "user-002",
-				Quantity:   1,
-				TotalPrice: 150000,
-				Status:     "pending",
-				CreatedAt:  now,
-				UpdatedAt:  now,
-			},
-			mockErr:     nil,
-			expectError: false,
-		},
+		{name: "buyer sees own order", orderID: orderID, actor: buyer},
+		{name: "admin sees any order", orderID: orderID, actor: Actor{UserID: "admin-001", Roles: []string{models.RoleAdmin}}},
+		{name: "another user", orderID: orderID, actor: Actor{UserID: "user-002", Roles: []string{models.RoleUser}}, expectedErr: ErrOrderNotFound},
+		{name: "order not found", orderID: orderID, actor: buyer, findErr: repositories.ErrNotFound, expectedErr: ErrOrderNotFound},
+		{name: "malformed id", orderID: "order-123", actor: buyer, expectedErr: ErrOrderNotFound},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockTicketRepo := mocks.NewTicketRepository(t)
 			mockEventRepo := mocks.NewEventRepository(t)
-			logger := zerolog.Nop()
 
-			mockTicketRepo.On("FindOrderByID", mock.Anything, tt.orderID).
-				Return(tt.mockOrder, tt.mockErr).
-				Once()
+			order := &models.TicketOrder{ID: orderID, EventID: "event-001", UserID: "user-001", Quantity: 2, Status: string(models.OrderStatusConfirmed)}
+			if tt.orderID == orderID {
+				if tt.findErr != nil {
+					mockTicketRepo.On("FindOrderByID", mock.Anything, orderID).Return(nil, tt.findErr).Once()
+				} else {
+					mockTicketRepo.On("FindOrderByID", mock.Anything, orderID).Return(order, nil).Once()
+				}
+			}
+			if tt.expectedErr == nil {
+				mockTicketRepo.On("FindTicketsByOrderID", mock.Anything, orderID).Return([]*models.Ticket{
+					{ID: "ticket-001", OrderID: orderID, TicketCode: "TKT-0001"},
+					{ID: "ticket-002", OrderID: orderID, TicketCode: "TKT-0002"},
+				}, nil).Once()
+				mockTicketRepo.On("ListOrderHistory", mock.Anything, orderID).Return([]*models.OrderStatusHistory{
+					{OrderID: orderID, ToStatus: string(models.OrderStatusPending), Reason: "order placed"},
+					{OrderID: orderID, ToStatus: string(models.OrderStatusConfirmed), Reason: "payment captured"},
+				}, nil).Once()
+				mockEventRepo.On("FindByID", mock.Anything, "event-001").Return(&models.Event{ID: "event-001", Title: "Jakarta Tech Conference"}, nil).Once()
+			}
 
-			service := NewTicketService(mockTicketRepo, mockEventRepo, logger)
-			order, err := service.GetOrderByID(context.Background(), tt.orderID)
+			service := NewTicketService(mockTicketRepo, mockEventRepo, nil, nil, config.PaymentConfig{}, config.CheckoutConfig{}, zerolog.Nop())
+			got, err := service.GetOrderByID(context.Background(), tt.actor, tt.orderID)
 
-			if tt.expectError {
-				assert.Error(t, err)
-				assert.Nil(t, order)
-				mockTicketRepo.AssertExpectations(t)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
 				return
 			}
-
 			assert.NoError(t, err)
-			assert.NotNil(t, order)
-			assert.Equal(t, tt.mockOrder.ID, order.ID)
-			assert.Equal(t, tt.mockOrder.UserID, order.UserID)
-			assert.Equal(t, tt.mockOrder.Quantity, order.Quantity)
-			assert.Equal(t, tt.mockOrder.Status, order.Status)
-
-			mockTicketRepo.AssertExpectations(t)
+			assert.Len(t, got.Tickets, 2)
+			assert.Len(t, got.History, 2)
+			if assert.NotNil(t, got.Event) {
+				assert.Equal(t, "Jakarta Tech Conference", got.Event.Title)
+			}
 		})
 	}
 }
 
-// Purpose: Ensure response fields are properly populated
-func TestTicketService_PurchaseTicket_ResponseFormat(t *testing.T) {
-	mockTicketRepo := mocks.NewTicketRepository(t)
-	mockEventRepo := mocks.NewEventRepository(t)
-	logger := zerolog.Nop()
+// TestTicketService_CloseOrder
+// Summary: Tests order cancellation and refunds against the simulated gateway
+// Purpose: Verify each status either reverses the payment and releases the tickets, or is rejected,
+// that only the buyer or an admin can cancel, that transferred tickets block a refund, and that tickets
+// already used at the gate are not put back on sale
+func TestTicketService_CloseOrder(t *testing.T) {
+	orderID := "0b7e4c52-6f3e-4d8a-9c1b-2a3d4e5f6a7b"
+	buyer := Actor{UserID: "user-001", Roles: []string{models.RoleUser}}
+
+	tests := []struct {
+		name        string
+		refund      bool
+		actor       Actor
+		status      models.TicketOrderStatus
+		source      models.OrderSource
+		transferred bool // one of the order's tickets was given away
+		used        bool // one of the order's tickets was already scanned
+		captured    bool // the gateway transaction was captured (false: only authorized)
+		reversed    payment.Status
+		expectedErr error
+	}{
+		{name: "cancel pending order", actor: buyer, status: models.OrderStatusPending},
+		{name: "cancel paid order voids the authorization", actor: buyer, status: models.OrderStatusPaid, reversed: payment.StatusVoided},
+		{name: "cancel confirmed order", actor: buyer, status: models.OrderStatusConfirmed, captured: true, expectedErr: ErrOrderNotCancellable},
+		{name: "cancel another user's order", actor: Actor{UserID: "user-002"}, status: models.OrderStatusPending, expectedErr: ErrOrderNotFound},
+		{name: "admin cancels any order", actor: Actor{UserID: "admin-001", Roles: []string{models.RoleAdmin}}, status: models.OrderStatusPending},
+		{name: "refund confirmed order", refund: true, status: models.OrderStatusConfirmed, captured: true, reversed: payment.StatusRefunded},
+		{name: "refund paid order voids the authorization", refund: true, status: models.OrderStatusPaid, reversed: payment.StatusVoided},
+		{name: "refund pending order", refund: true, status: models.OrderStatusPending, expectedErr: ErrOrderNotRefundable},
+		{name: "refund cancelled order", refund: true, status: models.OrderStatusCancelled, expectedErr: ErrOrderNotRefundable},
+		{name: "refund order with a used ticket restocks the rest", refund: true, status: models.OrderStatusConfirmed, used: true, captured: true, reversed: payment.StatusRefunded},
+		{name: "refund order with a transferred ticket", refund: true, status: models.OrderStatusConfirmed, transferred: true, captured: true, expectedErr: ErrOrderHasTransfers},
+		{name: "refund received ticket", refund: true, status: models.OrderStatusConfirmed, source: models.OrderSourceTransfer, expectedErr: ErrOrderNotRefundable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			gateway := payment.NewSimulator(payment.ModeSucceed, 0)
+
+			order := &models.TicketOrder{ID: orderID, EventID: "event-001", UserID: "user-001", Quantity: 2, TotalPrice: 200000, Status: string(tt.status), Source: string(tt.source)}
+			var txnID string
+			if tt.source != models.OrderSourceTransfer && tt.status != models.OrderStatusPending && tt.status != models.OrderStatusCancelled {
+				txn, err := gateway.Charge(ctx, payment.ChargeRequest{Reference: orderID, Amount: order.TotalPrice, Currency: "IDR"})
+				assert.NoError(t, err)
+				if tt.captured {
+					_, err = gateway.Capture(ctx, txn.ID)
+					assert.NoError(t, err)
+				}
+				txnID = txn.ID
+				order.PaymentID = &txnID
+			}
 
-	service := NewTicketService(mockTicketRepo, mockEventRepo, logger)
+			txEvents := mocks.NewEventRepository(t)
+			txTickets := mocks.NewTicketRepository(t)
+			txSeats := mocks.NewSeatRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			uow.On("Do", mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+					return fn(repositories.TxRepositories{Events: txEvents, Tickets: txTickets, Seats: txSeats})
+				},
+			).Once()
+			txTickets.On("FindOrderByIDForUpdate", mock.Anything, orderID).Return(order, nil).Once()
+
+			target := models.OrderStatusCancelled
+			if tt.refund {
+				target = models.OrderStatusRefunded
+			}
+			if tt.refund && tt.status == models.OrderStatusConfirmed && tt.source == "" {
+				second := models.TicketStatusValid
+				if tt.transferred {
+					second = models.TicketStatusTransferred
+				}
+				if tt.used {
+					second = models.TicketStatusUsed
+				}
+				txTickets.On("FindTicketsByOrderID", mock.Anything, orderID).Return([]*models.Ticket{
+					{ID: "ticket-001", OrderID: orderID, Status: string(models.TicketStatusValid)},
+					{ID: "ticket-002", OrderID: orderID, Status: string(second)},
+				}, nil).Once()
+			}
+			if tt.expectedErr == nil {
+				txTickets.On("UpdateOrderStatus", mock.Anything, orderChange(orderID, tt.status, target)).Return(nil).Once()
+				released := 2
+				if tt.used {
+					released = 1
+				}
+				txTickets.On("UpdateTicketsStatusByOrderID", mock.Anything, orderID, models.TicketStatusCancelled).Return(released, nil).Once()
+				txSeats.On("ReleaseSeatsByOrder", mock.Anything, orderID).Return(nil).Once()
+				txEvents.On("IncrementAvailableTickets", mock.Anything, "event-001", released).Return(nil).Once()
+			}
+
+			service := newPurchaseService(t, mocks.NewTicketRepository(t), uow, gateway)
+			var got *models.TicketOrder
+			var err error
+			if tt.refund {
+				got, err = service.RefundOrder(ctx, Actor{UserID: "admin-001", Roles: []string{models.RoleAdmin}}, orderID, "")
+			} else {
+				got, err = service.CancelOrder(ctx, tt.actor, orderID, "")
+			}
 
-	req := &dto.PurchaseRequest{
-		EventID:  "event-test",
-		Quantity: 2,
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				if tt.expectedErr != ErrOrderNotFound && tt.expectedErr != ErrOrderHasTransfers && tt.source == "" {
+					var transitionErr *models.TransitionError
+					assert.ErrorAs(t, err, &transitionErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, string(target), got.Status)
+			if tt.reversed != "" {
+				txn, err := gateway.Status(ctx, txnID)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.reversed, txn.Status)
+			}
+		})
 	}
 
-	resp, err := service.PurchaseTicket(context.Background(), "user-test", req)
+	t.Run("gateway failure keeps the order", func(t *testing.T) {
+		ctx := context.Background()
+		missing := "sim_missing"
+		order := &models.TicketOrder{ID: orderID, EventID: "event-001", UserID: "user-001", Quantity: 2, Status: string(models.OrderStatusConfirmed), PaymentID: &missing}
 
-	assert.NoError(t, err)
-	assert.NotNil(t, resp)
+		txTickets := mocks.NewTicketRepository(t)
+		uow := mocks.NewUnitOfWork(t)
+		uow.On("Do", mock.Anything, mock.Anything).Return(
+			func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+				return fn(repositories.TxRepositories{Events: mocks.NewEventRepository(t), Tickets: txTickets})
+			},
+		).Once()
+		txTickets.On("FindOrderByIDForUpdate", mock.Anything, orderID).Return(order, nil).Once()
+		txTickets.On("FindTicketsByOrderID", mock.Anything, orderID).Return([]*models.Ticket{}, nil).Once()
 
-	// Check all response fields are populated
-	assert.NotEmpty(t, resp.OrderID, "OrderID should not be empty")
-	assert.NotEmpty(t, resp.TransactionID, "TransactionID should not be empty")
-	assert.NotEmpty(t, resp.Status, "Status should not be empty")
-	assert.NotEmpty(t, resp.Message, "Message should not be empty")
+		service := newPurchaseService(t, mocks.NewTicketRepository(t), uow, payment.NewSimulator(payment.ModeSucceed, 0))
+		_, err := service.RefundOrder(ctx, Actor{UserID: "admin-001"}, orderID, "")
 
-	// Verify transaction ID format
-	assert.Contains(t, resp.TransactionID, "TXN-", "TransactionID should contain TXN- prefix")
-}
\ No newline at end of file
+		assert.ErrorIs(t, err, ErrRefundFailed)
+	})
+}
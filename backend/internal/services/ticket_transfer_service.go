@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// TicketTransferService hands individual tickets over to other users
+type TicketTransferService interface {
+	// TransferTicket marks the ticket transferred and issues a new ticket, with
+	// a new code, in a confirmed order of the recipient's
+	TransferTicket(ctx context.Context, actor Actor, ticketID string, req *dto.TransferTicketRequest) (*dto.TransferTicketResponse, error)
+	// GetTransferChain returns every transfer that led to or from the ticket.
+	// Admins, its holder and anyone in the chain may see it.
+	GetTransferChain(ctx context.Context, actor Actor, ticketID string) (*dto.TransferChainResponse, error)
+}
+
+type ticketTransferService struct {
+	uow        repositories.UnitOfWork
+	ticketRepo repositories.TicketRepository
+	eventRepo  repositories.EventRepository
+	userRepo   repositories.UserRepository
+	cfg        config.TransferConfig
+	log        zerolog.Logger
+}
+
+func NewTicketTransferService(
+	uow repositories.UnitOfWork,
+	ticketRepo repositories.TicketRepository,
+	eventRepo repositories.EventRepository,
+	userRepo repositories.UserRepository,
+	cfg config.TransferConfig,
+	log zerolog.Logger,
+) TicketTransferService {
+	return &ticketTransferService{
+		uow:        uow,
+		ticketRepo: ticketRepo,
+		eventRepo:  eventRepo,
+		userRepo:   userRepo,
+		cfg:        cfg,
+		log:        log,
+	}
+}
+
+func (s *ticketTransferService) TransferTicket(ctx context.Context, actor Actor, ticketID string, req *dto.TransferTicketRequest) (*dto.TransferTicketResponse, error) {
+	ticket, order, err := s.findOwnedTicket(ctx, actor, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status != string(models.TicketStatusValid) || order.Status != string(models.OrderStatusConfirmed) {
+		return nil, ErrTicketNotTransferable
+	}
+
+	event, err := s.eventRepo.FindByID(ctx, order.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.TransfersEnabled {
+		return nil, ErrTransfersDisabled
+	}
+	if !time.Now().Before(event.EventDate.Add(-s.cfg.Cutoff)) {
+		return nil, ErrTransferCutoffPassed
+	}
+
+	recipient, err := s.userRepo.FindByEmail(ctx, normalizeEmail(req.Email))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, err
+	}
+	if !recipient.IsActive {
+		return nil, ErrRecipientNotFound
+	}
+	if recipient.ID == order.UserID {
+		return nil, ErrTransferToSelf
+	}
+
+	transfer := &models.TicketTransfer{
+		EventID:      event.ID,
+		FromTicketID: ticket.ID,
+		FromUserID:   &order.UserID,
+		ToUserID:     &recipient.ID,
+	}
+	err = s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		// The order is locked before the ticket, in the order closing it takes
+		// them, so a refund that has checked for transfers cannot be overtaken
+		lockedOrder, err := tx.Tickets.FindOrderByIDForUpdate(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if lockedOrder.Status != string(models.OrderStatusConfirmed) {
+			return ErrTicketNotTransferable
+		}
+		// The lock keeps a concurrent scan or second transfer from using the ticket
+		locked, err := tx.Tickets.FindTicketByIDForUpdate(ctx, ticket.ID)
+		if err != nil {
+			return err
+		}
+		if locked.Status != string(models.TicketStatusValid) {
+			return ErrTicketNotTransferable
+		}
+		if err := tx.Tickets.UpdateTicketStatus(ctx, ticket.ID, models.TicketStatusValid, models.TicketStatusTransferred); err != nil {
+			if errors.Is(err, repositories.ErrConflict) {
+				return ErrTicketNotTransferable
+			}
+			return err
+		}
+
+		received := &models.TicketOrder{
+			EventID:  event.ID,
+			UserID:   recipient.ID,
+			Quantity: 1,
+			Status:   string(models.OrderStatusConfirmed),
+			Source:   string(models.OrderSourceTransfer),
+			TierID:   order.TierID,
+		}
+		if err := tx.Tickets.CreateOrder(ctx, received); err != nil {
+			return err
+		}
+		// The new ticket keeps the seat, which stays held by the original order
+		issued := &models.Ticket{
+			OrderID:    received.ID,
+			TicketCode: newTicketCode(),
+			Status:     string(models.TicketStatusValid),
+			SeatID:     locked.SeatID,
+		}
+		if err := tx.Tickets.CreateTickets(ctx, []*models.Ticket{issued}); err != nil {
+			return err
+		}
+
+		transfer.ToTicketID = issued.ID
+		return tx.Tickets.CreateTransfer(ctx, transfer)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrTicketNotTransferable) {
+			s.log.Error().Err(err).Str("ticket_id", ticket.ID).Msg("failed to transfer ticket")
+		}
+		return nil, err
+	}
+
+	ticket.Status = string(models.TicketStatusTransferred)
+	transfer.ToEmail = &recipient.Email
+	if sender, err := s.userRepo.FindByID(ctx, order.UserID); err == nil {
+		transfer.FromEmail = &sender.Email
+	}
+
+	s.log.Info().
+		Str("transfer_id", transfer.ID).
+		Str("ticket_id", ticket.ID).
+		Str("to_user_id", recipient.ID).
+		Str("actor_id", actor.UserID).
+		Msg("ticket transferred")
+	return &dto.TransferTicketResponse{Transfer: transfer, Ticket: ticket}, nil
+}
+
+func (s *ticketTransferService) GetTransferChain(ctx context.Context, actor Actor, ticketID string) (*dto.TransferChainResponse, error) {
+	if uuid.Validate(ticketID) != nil {
+		return nil, ErrTicketNotFound
+	}
+	ticket, err := s.ticketRepo.FindTicketByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrTicketNotFound
+		}
+		return nil, err
+	}
+	order, err := s.ticketRepo.FindOrderByID(ctx, ticket.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, err := s.ticketRepo.ListTransferChain(ctx, ticket.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list transfer chain: %w", err)
+	}
+	if authorizeOrderOwner(actor, order) != nil && !inTransferChain(actor.UserID, transfers) {
+		return nil, ErrTicketNotFound
+	}
+	return &dto.TransferChainResponse{TicketID: ticket.ID, Transfers: transfers}, nil
+}
+
+// findOwnedTicket loads a ticket and its order. Tickets of other users'
+// orders are reported as not found, as orders are.
+func (s *ticketTransferService) findOwnedTicket(ctx context.Context, actor Actor, ticketID string) (*models.Ticket, *models.TicketOrder, error) {
+	if uuid.Validate(ticketID) != nil {
+		return nil, nil, ErrTicketNotFound
+	}
+	ticket, err := s.ticketRepo.FindTicketByID(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, nil, ErrTicketNotFound
+		}
+		return nil, nil, err
+	}
+	order, err := s.ticketRepo.FindOrderByID(ctx, ticket.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if authorizeOrderOwner(actor, order) != nil {
+		return nil, nil, ErrTicketNotFound
+	}
+	return ticket, order, nil
+}
+
+func inTransferChain(userID string, transfers []*models.TicketTransfer) bool {
+	for _, transfer := range transfers {
+		if (transfer.FromUserID != nil && *transfer.FromUserID == userID) ||
+			(transfer.ToUserID != nil && *transfer.ToUserID == userID) {
+			return true
+		}
+	}
+	return false
+}
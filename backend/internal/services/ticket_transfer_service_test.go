@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/config"
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestTicketTransferService_TransferTicket
+// Summary: Tests handing a ticket over to another registered user
+// Purpose: Verify the sender's ticket is marked transferred and a new ticket is issued in a confirmed
+// order of the recipient's for the same seat, and that disabled events, the cutoff, unknown or self recipients,
+// tickets that are no longer valid and orders refunded before the transfer got their lock are refused
+func TestTicketTransferService_TransferTicket(t *testing.T) {
+	const ticketID = "6f1c2f1e-8d4b-4a43-9a8e-0c2b8f0a1d01"
+	recipient := &models.User{ID: "user-002", Email: "friend@example.com", IsActive: true}
+	seatID := "seat-001"
+
+	tests := []struct {
+		name         string
+		actor        Actor
+		email        string
+		ticket       models.TicketStatus
+		locked       models.TicketStatus      // status once the row is locked; defaults to ticket
+		lockedOrder  models.TicketOrderStatus // order status once the row is locked; defaults to confirmed
+		startsIn     time.Duration
+		disabled     bool
+		recipient    *models.User
+		recipientErr error
+		expectedErr  error
+	}{
+		{name: "owner transfers", actor: Actor{UserID: "user-001"}, email: " Friend@Example.com "},
+		{name: "admin transfers", actor: Actor{UserID: "admin-001", Roles: []string{models.RoleAdmin}}, email: "friend@example.com"},
+		{name: "stranger", actor: Actor{UserID: "user-003"}, email: "friend@example.com", expectedErr: ErrTicketNotFound},
+		{name: "ticket already used", actor: Actor{UserID: "user-001"}, email: "friend@example.com", ticket: models.TicketStatusUsed, expectedErr: ErrTicketNotTransferable},
+		{name: "transfers disabled", actor: Actor{UserID: "user-001"}, email: "friend@example.com", disabled: true, expectedErr: ErrTransfersDisabled},
+		{name: "cutoff passed", actor: Actor{UserID: "user-001"}, email: "friend@example.com", startsIn: 12 * time.Hour, expectedErr: ErrTransferCutoffPassed},
+		{name: "unknown recipient", actor: Actor{UserID: "user-001"}, email: "nobody@example.com", recipientErr: repositories.ErrNotFound, expectedErr: ErrRecipientNotFound},
+		{
+			name: "disabled recipient", actor: Actor{UserID: "user-001"}, email: "friend@example.com",
+			recipient: &models.User{ID: "user-002", Email: "friend@example.com"}, expectedErr: ErrRecipientNotFound,
+		},
+		{
+			name: "transfer to self", actor: Actor{UserID: "user-001"}, email: "me@example.com",
+			recipient: &models.User{ID: "user-001", Email: "me@example.com", IsActive: true}, expectedErr: ErrTransferToSelf,
+		},
+		{name: "transferred concurrently", actor: Actor{UserID: "user-001"}, email: "friend@example.com", locked: models.TicketStatusTransferred, expectedErr: ErrTicketNotTransferable},
+		{name: "order refunded concurrently", actor: Actor{UserID: "user-001"}, email: "friend@example.com", lockedOrder: models.OrderStatusRefunded, expectedErr: ErrTicketNotTransferable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.ticket == "" {
+				tt.ticket = models.TicketStatusValid
+			}
+			if tt.locked == "" {
+				tt.locked = tt.ticket
+			}
+			if tt.lockedOrder == "" {
+				tt.lockedOrder = models.OrderStatusConfirmed
+			}
+			if tt.startsIn == 0 {
+				tt.startsIn = 7 * 24 * time.Hour
+			}
+			if tt.recipient == nil && tt.recipientErr == nil {
+				tt.recipient = recipient
+			}
+
+			ticketRepo := mocks.NewTicketRepository(t)
+			eventRepo := mocks.NewEventRepository(t)
+			userRepo := mocks.NewUserRepository(t)
+			txTickets := mocks.NewTicketRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+
+			ticketRepo.On("FindTicketByID", mock.Anything, ticketID).
+				Return(&models.Ticket{ID: ticketID, OrderID: "order-001", TicketCode: "TKT-OLD", Status: string(tt.ticket)}, nil).Once()
+			ticketRepo.On("FindOrderByID", mock.Anything, "order-001").
+				Return(&models.TicketOrder{ID: "order-001", EventID: "event-001", UserID: "user-001", Status: string(models.OrderStatusConfirmed)}, nil).Once()
+
+			stranger := tt.expectedErr == ErrTicketNotFound
+			if !stranger && tt.ticket == models.TicketStatusValid {
+				eventRepo.On("FindByID", mock.Anything, "event-001").
+					Return(&models.Event{ID: "event-001", EventDate: time.Now().Add(tt.startsIn), TransfersEnabled: !tt.disabled}, nil).Once()
+			}
+			if !stranger && tt.ticket == models.TicketStatusValid && !tt.disabled && tt.startsIn > 24*time.Hour {
+				userRepo.On("FindByEmail", mock.Anything, strings.ToLower(strings.TrimSpace(tt.email))).
+					Return(tt.recipient, tt.recipientErr).Once()
+			}
+
+			reachesTx := tt.expectedErr == nil || tt.locked != tt.ticket || tt.lockedOrder != models.OrderStatusConfirmed
+			if reachesTx {
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Tickets: txTickets})
+					},
+				).Once()
+				txTickets.On("FindOrderByIDForUpdate", mock.Anything, "order-001").
+					Return(&models.TicketOrder{ID: "order-001", EventID: "event-001", UserID: "user-001", Status: string(tt.lockedOrder)}, nil).Once()
+			}
+			if reachesTx && tt.lockedOrder == models.OrderStatusConfirmed {
+				txTickets.On("FindTicketByIDForUpdate", mock.Anything, ticketID).
+					Return(&models.Ticket{ID: ticketID, OrderID: "order-001", Status: string(tt.locked), SeatID: &seatID}, nil).Once()
+			}
+			if tt.expectedErr == nil {
+				txTickets.On("UpdateTicketStatus", mock.Anything, ticketID, models.TicketStatusValid, models.TicketStatusTransferred).Return(nil).Once()
+				txTickets.On("CreateOrder", mock.Anything, mock.MatchedBy(func(o *models.TicketOrder) bool {
+					return o.UserID == "user-002" && o.EventID == "event-001" && o.Quantity == 1 && o.TotalPrice == 0 &&
+						o.Status == string(models.OrderStatusConfirmed) && o.Source == string(models.OrderSourceTransfer)
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.TicketOrder).ID = "order-002"
+				}).Return(nil).Once()
+				txTickets.On("CreateTickets", mock.Anything, mock.MatchedBy(func(tickets []*models.Ticket) bool {
+					return len(tickets) == 1 && tickets[0].OrderID == "order-002" &&
+						strings.HasPrefix(tickets[0].TicketCode, "TKT-") && tickets[0].TicketCode != "TKT-OLD" &&
+						tickets[0].SeatID != nil && *tickets[0].SeatID == seatID
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).([]*models.Ticket)[0].ID = "ticket-new"
+				}).Return(nil).Once()
+				txTickets.On("CreateTransfer", mock.Anything, mock.MatchedBy(func(tr *models.TicketTransfer) bool {
+					return tr.FromTicketID == ticketID && tr.ToTicketID == "ticket-new" &&
+						*tr.FromUserID == "user-001" && *tr.ToUserID == "user-002"
+				})).Return(nil).Once()
+				userRepo.On("FindByID", mock.Anything, "user-001").
+					Return(&models.User{ID: "user-001", Email: "me@example.com"}, nil).Once()
+			}
+
+			svc := NewTicketTransferService(uow, ticketRepo, eventRepo, userRepo, config.TransferConfig{Cutoff: 24 * time.Hour}, zerolog.Nop())
+			got, err := svc.TransferTicket(context.Background(), tt.actor, ticketID, &dto.TransferTicketRequest{Email: tt.email})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, string(models.TicketStatusTransferred), got.Ticket.Status)
+			assert.Equal(t, "ticket-new", got.Transfer.ToTicketID)
+			assert.Equal(t, "me@example.com", *got.Transfer.FromEmail)
+			assert.Equal(t, "friend@example.com", *got.Transfer.ToEmail)
+		})
+	}
+}
+
+// TestTicketTransferService_GetTransferChain
+// Summary: Tests reading a ticket's ownership chain
+// Purpose: Verify the holder, earlier holders and admins can see the chain and anyone else is told the
+// ticket does not exist
+func TestTicketTransferService_GetTransferChain(t *testing.T) {
+	const ticketID = "6f1c2f1e-8d4b-4a43-9a8e-0c2b8f0a1d01"
+	buyer, friend := "user-001", "user-002"
+	chain := []*models.TicketTransfer{
+		{ID: "transfer-001", FromTicketID: "ticket-001", ToTicketID: ticketID, FromUserID: &buyer, ToUserID: &friend},
+	}
+
+	tests := []struct {
+		name        string
+		actor       Actor
+		expectedErr error
+	}{
+		{name: "holder", actor: Actor{UserID: friend}},
+		{name: "earlier holder", actor: Actor{UserID: buyer}},
+		{name: "admin", actor: Actor{UserID: "admin-001", Roles: []string{models.RoleAdmin}}},
+		{name: "stranger", actor: Actor{UserID: "user-003"}, expectedErr: ErrTicketNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticketRepo := mocks.NewTicketRepository(t)
+			ticketRepo.On("FindTicketByID", mock.Anything, ticketID).
+				Return(&models.Ticket{ID: ticketID, OrderID: "order-002", Status: string(models.TicketStatusValid)}, nil).Once()
+			ticketRepo.On("FindOrderByID", mock.Anything, "order-002").
+				Return(&models.TicketOrder{ID: "order-002", UserID: friend, Source: string(models.OrderSourceTransfer)}, nil).Once()
+			ticketRepo.On("ListTransferChain", mock.Anything, ticketID).Return(chain, nil).Once()
+
+			svc := NewTicketTransferService(mocks.NewUnitOfWork(t), ticketRepo, mocks.NewEventRepository(t), mocks.NewUserRepository(t),
+				config.TransferConfig{}, zerolog.Nop())
+			got, err := svc.GetTransferChain(context.Background(), tt.actor, ticketID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, chain, got.Transfers)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/baramulti/ticketing-system/backend/internal/models"
@@ -17,45 +18,32 @@ type UserService interface {
 	Update(ctx context.Context, id string, email string) error
 	Delete(ctx context.Context, id string) error
 	GetUserRoles(ctx context.Context, userID string) ([]*models.Role, error)
+	AssignRole(ctx context.Context, userID, roleName, assignedBy string) error
+	RevokeRole(ctx context.Context, userID, roleName, revokedBy string) error
 }
 
 type userService struct {
 	userRepo repositories.UserRepository
+	permSvc  PermissionService
 	log      zerolog.Logger
 }
 
-func NewUserService(userRepo repositories.UserRepository, log zerolog.Logger) UserService {
+func NewUserService(userRepo repositories.UserRepository, permSvc PermissionService, log zerolog.Logger) UserService {
 	return &userService{
 		userRepo: userRepo,
+		permSvc:  permSvc,
 		log:      log,
 	}
 }
 
 func (s *userService) GetByID(ctx context.Context, id string) (*models.User, error) {
-	// STUB: return mock user for now
-	// TODO: call s.userRepo.FindByID(ctx, id) when repository is implemented
 	s.log.Debug().Str("user_id", id).Msg("fetching user by id")
-
-	return &models.User{
-		ID:        id,
-		Email:     "stub@example.com",
-		IsActive:  true,
-		CreatedAt: time.Now().Add(-30 * 24 * time.Hour),
-		UpdatedAt: time.Now(),
-	}, nil
+	return s.userRepo.FindByID(ctx, id)
 }
 
 func (s *userService) GetByEmail(ctx context.Context, email string) (*models.User, error) {
-	// STUB: return mock user
 	s.log.Debug().Str("email", email).Msg("fetching user by email")
-
-	return &models.User{
-		ID:        "a1b2c3d4-5678-90ab-cdef-1234567890ab",
-		Email:     email,
-		IsActive:  true,
-		CreatedAt: time.Now().Add(-30 * 24 * time.Hour),
-		UpdatedAt: time.Now(),
-	}, nil
+	return s.userRepo.FindByEmail(ctx, email)
 }
 
 func (s *userService) Create(ctx context.Context, email, password string) (*models.User, error) {
@@ -93,19 +81,79 @@ func (s *userService) Delete(ctx context.Context, id string) error {
 }
 
 func (s *userService) GetUserRoles(ctx context.Context, userID string) ([]*models.Role, error) {
-	// STUB: return mock roles
-	// Production: query from user_roles + roles tables
 	s.log.Debug().Str("user_id", userID).Msg("fetching user roles")
+	return s.userRepo.FindRolesByUserID(ctx, userID)
+}
+
+// AssignRole grants a role to a user on behalf of an admin. The acting admin is
+// recorded in user_roles.assigned_by for auditing.
+func (s *userService) AssignRole(ctx context.Context, userID, roleName, assignedBy string) error {
+	if uuid.Validate(userID) != nil {
+		return ErrUserNotFound
+	}
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if err := s.userRepo.AssignRole(ctx, userID, roleName, &assignedBy); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrRoleNotFound
+		}
+		s.log.Error().Err(err).Str("user_id", userID).Str("role", roleName).Msg("failed to assign role")
+		return err
+	}
+
+	// The grant is already stored; a failed invalidation only delays it until the cache TTL
+	if err := s.permSvc.InvalidateUser(ctx, userID); err != nil {
+		s.log.Error().Err(err).Str("user_id", userID).Msg("failed to invalidate permission cache")
+	}
+
+	s.log.Info().
+		Str("user_id", userID).
+		Str("role", roleName).
+		Str("assigned_by", assignedBy).
+		Msg("role assigned")
 
-	// Mock: return "user" role for regular users
-	return []*models.Role{
-		{
-			ID:          uuid.New().String(),
-			Name:        models.RoleUser,
-			Description: "Regular user",
-			IsActive:    true,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		},
-	}, nil
-}
\ No newline at end of file
+	return nil
+}
+
+// RevokeRole removes a role from a user on behalf of an admin. Admins cannot
+// drop their own admin role, so the last admin cannot lock everyone out by accident.
+func (s *userService) RevokeRole(ctx context.Context, userID, roleName, revokedBy string) error {
+	if userID == revokedBy && roleName == models.RoleAdmin {
+		return ErrCannotRevokeOwnAdmin
+	}
+	if uuid.Validate(userID) != nil {
+		return ErrUserNotFound
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if err := s.userRepo.RevokeRole(ctx, userID, roleName); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrRoleNotAssigned
+		}
+		s.log.Error().Err(err).Str("user_id", userID).Str("role", roleName).Msg("failed to revoke role")
+		return err
+	}
+
+	if err := s.permSvc.InvalidateUser(ctx, userID); err != nil {
+		s.log.Error().Err(err).Str("user_id", userID).Msg("failed to invalidate permission cache")
+	}
+
+	s.log.Info().
+		Str("user_id", userID).
+		Str("role", roleName).
+		Str("revoked_by", revokedBy).
+		Msg("role revoked")
+
+	return nil
+}
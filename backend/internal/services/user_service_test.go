@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/baramulti/ticketing-system/backend/internal/cache"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestUserService_AssignRole
+// Summary: Admin role assignment for existing and missing users/roles
+// Purpose: Verify the acting admin is recorded as assigned_by, lookups and malformed user ids map to
+// domain errors and a successful grant drops the user's cached permissions
+func TestUserService_AssignRole(t *testing.T) {
+	adminID := "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"
+
+	tests := []struct {
+		name        string
+		userID      string
+		role        string
+		findErr     error
+		assignErr   error
+		skipRepo    bool
+		expectedErr error
+	}{
+		{
+			name:   "grant organizer",
+			userID: "c4a1e7d2-5b3f-4e8a-9c6d-2f1b0a9e8d7c",
+			role:   models.RoleOrganizer,
+		},
+		{
+			name:        "unknown user",
+			userID:      "e8d7c6b5-a4f3-4e2d-8c1b-0a9f8e7d6c5b",
+			role:        models.RoleOrganizer,
+			findErr:     repositories.ErrNotFound,
+			expectedErr: ErrUserNotFound,
+		},
+		{
+			name:        "unknown role",
+			userID:      "c4a1e7d2-5b3f-4e8a-9c6d-2f1b0a9e8d7c",
+			role:        "superuser",
+			assignErr:   repositories.ErrNotFound,
+			expectedErr: ErrRoleNotFound,
+		},
+		{
+			name:        "malformed user id",
+			userID:      "not-a-uuid",
+			role:        models.RoleOrganizer,
+			skipRepo:    true,
+			expectedErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewUserRepository(t)
+
+			switch {
+			case tt.skipRepo:
+				// Malformed ids are answered without a query
+			case tt.findErr != nil:
+				mockUserRepo.On("FindByID", mock.Anything, tt.userID).Return(nil, tt.findErr).Once()
+			default:
+				mockUserRepo.On("FindByID", mock.Anything, tt.userID).Return(&models.User{ID: tt.userID}, nil).Once()
+				mockUserRepo.On("AssignRole", mock.Anything, tt.userID, tt.role, mock.MatchedBy(func(by *string) bool {
+					return by != nil && *by == adminID
+				})).Return(tt.assignErr).Once()
+			}
+
+			ctx := context.Background()
+			permCache := cache.NewMemoryPermissionCache()
+			_ = permCache.Set(ctx, tt.userID, []string{models.PermEventRead}, time.Minute)
+			permSvc := NewPermissionService(mockUserRepo, permCache, zerolog.Nop())
+
+			service := NewUserService(mockUserRepo, permSvc, zerolog.Nop())
+			err := service.AssignRole(ctx, tt.userID, tt.role, adminID)
+
+			_, cached, _ := permCache.Get(ctx, tt.userID)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.True(t, cached, "cache should be untouched when nothing changed")
+				return
+			}
+			assert.NoError(t, err)
+			assert.False(t, cached, "cached permissions should be invalidated")
+		})
+	}
+}
+
+// TestUserService_RevokeRole
+// Summary: Admin role revocation
+// Purpose: Verify revocation maps missing or malformed users and missing assignments to domain errors, refuses an admin
+// dropping their own admin role, and drops the user's cached permissions
+func TestUserService_RevokeRole(t *testing.T) {
+	adminID := "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"
+
+	tests := []struct {
+		name        string
+		userID      string
+		role        string
+		findErr     error
+		revokeErr   error
+		skipRepo    bool
+		expectedErr error
+	}{
+		{
+			name:   "revoke organizer",
+			userID: "c4a1e7d2-5b3f-4e8a-9c6d-2f1b0a9e8d7c",
+			role:   models.RoleOrganizer,
+		},
+		{
+			name:        "unknown user",
+			userID:      "e8d7c6b5-a4f3-4e2d-8c1b-0a9f8e7d6c5b",
+			role:        models.RoleOrganizer,
+			findErr:     repositories.ErrNotFound,
+			expectedErr: ErrUserNotFound,
+		},
+		{
+			name:        "role not held",
+			userID:      "c4a1e7d2-5b3f-4e8a-9c6d-2f1b0a9e8d7c",
+			role:        models.RoleValidator,
+			revokeErr:   repositories.ErrNotFound,
+			expectedErr: ErrRoleNotAssigned,
+		},
+		{
+			name:        "admin revoking own admin role",
+			userID:      adminID,
+			role:        models.RoleAdmin,
+			skipRepo:    true,
+			expectedErr: ErrCannotRevokeOwnAdmin,
+		},
+		{
+			name:        "malformed user id",
+			userID:      "not-a-uuid",
+			role:        models.RoleOrganizer,
+			skipRepo:    true,
+			expectedErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewUserRepository(t)
+
+			if !tt.skipRepo {
+				if tt.findErr != nil {
+					mockUserRepo.On("FindByID", mock.Anything, tt.userID).Return(nil, tt.findErr).Once()
+				} else {
+					mockUserRepo.On("FindByID", mock.Anything, tt.userID).Return(&models.User{ID: tt.userID}, nil).Once()
+					mockUserRepo.On("RevokeRole", mock.Anything, tt.userID, tt.role).Return(tt.revokeErr).Once()
+				}
+			}
+
+			ctx := context.Background()
+			permCache := cache.NewMemoryPermissionCache()
+			_ = permCache.Set(ctx, tt.userID, []string{models.PermEventCreate}, time.Minute)
+			permSvc := NewPermissionService(mockUserRepo, permCache, zerolog.Nop())
+
+			service := NewUserService(mockUserRepo, permSvc, zerolog.Nop())
+			err := service.RevokeRole(ctx, tt.userID, tt.role, adminID)
+
+			_, cached, _ := permCache.Get(ctx, tt.userID)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.True(t, cached)
+				return
+			}
+			assert.NoError(t, err)
+			assert.False(t, cached, "cached permissions should be invalidated")
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	// Venue time zones are checked against the embedded IANA database, so
+	// validation does not depend on the host having tzdata installed
+	_ "time/tzdata"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// VenueService manages the venues events take place at
+type VenueService interface {
+	GetByID(ctx context.Context, id string) (*models.Venue, error)
+	List(ctx context.Context, page, pageSize int) (*dto.VenueListResponse, error)
+	Create(ctx context.Context, actor Actor, req *dto.CreateVenueRequest) (*models.Venue, error)
+	Update(ctx context.Context, actor Actor, id string, req *dto.UpdateVenueRequest) (*models.Venue, error)
+	Delete(ctx context.Context, actor Actor, id string) error
+}
+
+type venueService struct {
+	repo repositories.VenueRepository
+	uow  repositories.UnitOfWork
+	log  zerolog.Logger
+}
+
+func NewVenueService(repo repositories.VenueRepository, uow repositories.UnitOfWork, log zerolog.Logger) VenueService {
+	return &venueService{
+		repo: repo,
+		uow:  uow,
+		log:  log,
+	}
+}
+
+func (s *venueService) GetByID(ctx context.Context, id string) (*models.Venue, error) {
+	if uuid.Validate(id) != nil {
+		return nil, ErrVenueNotFound
+	}
+	venue, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrVenueNotFound
+		}
+		return nil, err
+	}
+	return venue, nil
+}
+
+func (s *venueService) List(ctx context.Context, page, pageSize int) (*dto.VenueListResponse, error) {
+	page, pageSize = pageBounds(page, pageSize)
+	total, err := s.repo.Count(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to count venues")
+		return nil, err
+	}
+	venues, err := s.repo.List(ctx, pageSize, (page-1)*pageSize)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to list venues")
+		return nil, err
+	}
+	return &dto.VenueListResponse{Venues: venues, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+// Create stores a new venue owned by the acting organizer (or admin)
+func (s *venueService) Create(ctx context.Context, actor Actor, req *dto.CreateVenueRequest) (*models.Venue, error) {
+	if !validTimeZone(req.TimeZone) {
+		return nil, ErrInvalidTimeZone
+	}
+
+	createdBy := actor.UserID
+	venue := &models.Venue{
+		Name:      strings.TrimSpace(req.Name),
+		Address:   strings.TrimSpace(req.Address),
+		TimeZone:  req.TimeZone,
+		Capacity:  req.Capacity,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		CreatedBy: &createdBy,
+	}
+	if err := s.repo.Create(ctx, venue); err != nil {
+		s.log.Error().Err(err).Str("actor_id", actor.UserID).Msg("failed to create venue")
+		return nil, err
+	}
+
+	s.log.Info().Str("venue_id", venue.ID).Str("actor_id", actor.UserID).Msg("venue created")
+	return venue, nil
+}
+
+// Update applies the fields present in req to a venue the actor manages.
+// The venue row stays locked meanwhile, so no event can grow past a
+// lowered capacity.
+func (s *venueService) Update(ctx context.Context, actor Actor, id string, req *dto.UpdateVenueRequest) (*models.Venue, error) {
+	if uuid.Validate(id) != nil {
+		return nil, ErrVenueNotFound
+	}
+	if req.TimeZone != nil && !validTimeZone(*req.TimeZone) {
+		return nil, ErrInvalidTimeZone
+	}
+
+	var venue *models.Venue
+	err := s.uow.Do(ctx, func(tx repositories.TxRepositories) error {
+		var err error
+		venue, err = tx.Venues.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.authorizeOwner(actor, venue); err != nil {
+			return err
+		}
+
+		applyVenueUpdate(venue, req)
+		if req.Capacity != nil {
+			largest, err := tx.Events.MaxTotalTicketsByVenue(ctx, id)
+			if err != nil {
+				return err
+			}
+			if largest > venue.Capacity {
+				return ErrCapacityBelowEvents
+			}
+		}
+		return tx.Venues.Update(ctx, venue)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotFound):
+			return nil, ErrVenueNotFound
+		case !isVenueClientError(err):
+			s.log.Error().Err(err).Str("venue_id", id).Msg("failed to update venue")
+		}
+		return nil, err
+	}
+
+	s.log.Info().Str("venue_id", id).Str("actor_id", actor.UserID).Msg("venue updated")
+	return venue, nil
+}
+
+// Delete removes a venue the actor manages. Venues with events are kept.
+func (s *venueService) Delete(ctx context.Context, actor Actor, id string) error {
+	venue, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeOwner(actor, venue); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotFound):
+			return ErrVenueNotFound
+		case errors.Is(err, repositories.ErrForeignKeyViolation):
+			return ErrVenueHasEvents
+		}
+		s.log.Error().Err(err).Str("venue_id", id).Msg("failed to delete venue")
+		return err
+	}
+
+	s.log.Info().Str("venue_id", id).Str("actor_id", actor.UserID).Msg("venue deleted")
+	return nil
+}
+
+// authorizeOwner checks the actor may manage the venue: admins manage every
+// venue, organizers only the ones they created. Venues without a creator
+// are admin-managed.
+func (s *venueService) authorizeOwner(actor Actor, venue *models.Venue) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	if venue.CreatedBy == nil || *venue.CreatedBy != actor.UserID {
+		s.log.Warn().Str("venue_id", venue.ID).Str("actor_id", actor.UserID).Msg("venue access denied: not the owner")
+		return ErrNotVenueOwner
+	}
+	return nil
+}
+
+// applyVenueUpdate copies the fields present in req onto venue
+func applyVenueUpdate(venue *models.Venue, req *dto.UpdateVenueRequest) {
+	if req.Name != nil {
+		venue.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Address != nil {
+		venue.Address = strings.TrimSpace(*req.Address)
+	}
+	if req.TimeZone != nil {
+		venue.TimeZone = *req.TimeZone
+	}
+	if req.Capacity != nil {
+		venue.Capacity = *req.Capacity
+	}
+	if req.Latitude != nil {
+		venue.Latitude, venue.Longitude = req.Latitude, req.Longitude
+	}
+}
+
+// validTimeZone reports whether name is an IANA time zone. "Local" names
+// the server's zone, not a place, and is refused.
+func validTimeZone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// isVenueClientError reports whether err is the caller's mistake rather than a failure
+func isVenueClientError(err error) bool {
+	for _, target := range []error{
+		ErrVenueNotFound, ErrNotVenueOwner, ErrInvalidTimeZone, ErrCapacityBelowEvents,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baramulti/ticketing-system/backend/internal/dto"
+	"github.com/baramulti/ticketing-system/backend/internal/models"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories"
+	"github.com/baramulti/ticketing-system/backend/internal/repositories/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testVenueID = "3f8e2a1b-7c6d-4e5f-9a0b-1c2d3e4f5a6b"
+
+// TestVenueService_Create
+// Summary: Tests creating a venue
+// Purpose: Verify the creator owns the venue and only IANA time zone names are accepted
+func TestVenueService_Create(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeZone    string
+		expectedErr error
+	}{
+		{name: "IANA time zone", timeZone: "Asia/Jakarta"},
+		{name: "UTC", timeZone: "UTC"},
+		{name: "unknown time zone", timeZone: "Asia/Atlantis", expectedErr: ErrInvalidTimeZone},
+		{name: "offset instead of a zone", timeZone: "+07:00", expectedErr: ErrInvalidTimeZone},
+		{name: "server's local zone", timeZone: "Local", expectedErr: ErrInvalidTimeZone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venueRepo := mocks.NewVenueRepository(t)
+			if tt.expectedErr == nil {
+				venueRepo.On("Create", mock.Anything, mock.MatchedBy(func(v *models.Venue) bool {
+					return v.Name == "Jakarta Convention Center" && *v.CreatedBy == "organizer-001"
+				})).Return(nil).Once()
+			}
+
+			svc := NewVenueService(venueRepo, mocks.NewUnitOfWork(t), zerolog.Nop())
+			lat, lng := -6.2146, 106.8019
+			got, err := svc.Create(context.Background(), Actor{UserID: "organizer-001"}, &dto.CreateVenueRequest{
+				Name:      " Jakarta Convention Center ",
+				Address:   "Jl. Gatot Subroto, Senayan",
+				TimeZone:  tt.timeZone,
+				Capacity:  5000,
+				Latitude:  &lat,
+				Longitude: &lng,
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.timeZone, got.TimeZone)
+			assert.Equal(t, 5000, got.Capacity)
+		})
+	}
+}
+
+// TestVenueService_Update
+// Summary: Tests partial venue updates by owners, other organizers and admins
+// Purpose: Verify organizers only change their own venues, admins change any, and capacity cannot
+// drop below the largest event held at the venue
+func TestVenueService_Update(t *testing.T) {
+	ownerID := "organizer-001"
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+
+	tests := []struct {
+		name         string
+		actor        Actor
+		req          dto.UpdateVenueRequest
+		largestEvent int
+		expectedErr  error
+	}{
+		{name: "owner renames", actor: Actor{UserID: ownerID}, req: dto.UpdateVenueRequest{Name: strPtr("JCC Plenary")}},
+		{name: "owner grows capacity", actor: Actor{UserID: ownerID}, req: dto.UpdateVenueRequest{Capacity: intPtr(6000)}, largestEvent: 5000},
+		{name: "capacity still fits the events", actor: Actor{UserID: ownerID}, req: dto.UpdateVenueRequest{Capacity: intPtr(3000)}, largestEvent: 3000},
+		{name: "capacity below an event", actor: Actor{UserID: ownerID}, req: dto.UpdateVenueRequest{Capacity: intPtr(2999)}, largestEvent: 3000, expectedErr: ErrCapacityBelowEvents},
+		{name: "other organizer", actor: Actor{UserID: "organizer-002"}, req: dto.UpdateVenueRequest{Name: strPtr("Hijacked")}, expectedErr: ErrNotVenueOwner},
+		{name: "admin overrides ownership", actor: Actor{UserID: "admin-001", Roles: []string{models.RoleAdmin}}, req: dto.UpdateVenueRequest{TimeZone: strPtr("Asia/Makassar")}},
+		{name: "invalid time zone", actor: Actor{UserID: ownerID}, req: dto.UpdateVenueRequest{TimeZone: strPtr("WIB")}, expectedErr: ErrInvalidTimeZone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			venue := &models.Venue{ID: testVenueID, Name: "Jakarta Convention Center", TimeZone: "Asia/Jakarta", Capacity: 5000, CreatedBy: &ownerID}
+			venueRepo := mocks.NewVenueRepository(t)
+			eventRepo := mocks.NewEventRepository(t)
+			uow := mocks.NewUnitOfWork(t)
+			if tt.expectedErr != ErrInvalidTimeZone {
+				uow.On("Do", mock.Anything, mock.Anything).Return(
+					func(ctx context.Context, fn func(repositories.TxRepositories) error) error {
+						return fn(repositories.TxRepositories{Events: eventRepo, Venues: venueRepo})
+					},
+				).Once()
+				venueRepo.On("FindByIDForUpdate", mock.Anything, testVenueID).Return(venue, nil).Once()
+			}
+			if tt.req.Capacity != nil {
+				eventRepo.On("MaxTotalTicketsByVenue", mock.Anything, testVenueID).Return(tt.largestEvent, nil).Once()
+			}
+			if tt.expectedErr == nil {
+				venueRepo.On("Update", mock.Anything, venue).Return(nil).Once()
+			}
+
+			svc := NewVenueService(mocks.NewVenueRepository(t), uow, zerolog.Nop())
+			got, err := svc.Update(context.Background(), tt.actor, testVenueID, &tt.req)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.req.Capacity != nil {
+				assert.Equal(t, *tt.req.Capacity, got.Capacity)
+			}
+		})
+	}
+}
+
+// TestVenueService_Delete
+// Summary: Tests deleting venues
+// Purpose: Verify admin-managed venues stay off-limits to organizers, venues with events are kept
+// and malformed ids are not found
+func TestVenueService_Delete(t *testing.T) {
+	ownerID := "organizer-001"
+
+	tests := []struct {
+		name        string
+		venueID     string // defaults to testVenueID
+		createdBy   *string
+		deleteErr   error
+		expectedErr error
+	}{
+		{name: "owner deletes", createdBy: &ownerID},
+		{name: "malformed venue id", venueID: "not-a-uuid", expectedErr: ErrVenueNotFound},
+		{name: "admin-managed venue", expectedErr: ErrNotVenueOwner},
+		{name: "venue with events", createdBy: &ownerID, deleteErr: repositories.ErrForeignKeyViolation, expectedErr: ErrVenueHasEvents},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.venueID == "" {
+				tt.venueID = testVenueID
+			}
+			venueRepo := mocks.NewVenueRepository(t)
+			if tt.expectedErr != ErrVenueNotFound {
+				venueRepo.On("FindByID", mock.Anything, testVenueID).Return(&models.Venue{ID: testVenueID, CreatedBy: tt.createdBy}, nil).Once()
+			}
+			if tt.expectedErr == nil || tt.expectedErr == ErrVenueHasEvents {
+				venueRepo.On("Delete", mock.Anything, testVenueID).Return(tt.deleteErr).Once()
+			}
+
+			svc := NewVenueService(venueRepo, mocks.NewUnitOfWork(t), zerolog.Nop())
+			err := svc.Delete(context.Background(), Actor{UserID: ownerID}, tt.venueID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
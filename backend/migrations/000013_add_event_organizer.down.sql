@@ -0,0 +1,6 @@
+DELETE FROM role_permissions
+WHERE role_id = (SELECT id FROM roles WHERE name = 'organizer')
+AND permission_id = (SELECT id FROM permissions WHERE name = 'events.delete');
+
+DROP INDEX IF EXISTS idx_events_organizer_id;
+ALTER TABLE events DROP COLUMN IF EXISTS organizer_id;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Event ownership
+-- Organizers may only manage events they created. Existing events have no
+-- organizer and stay admin-managed.
+ALTER TABLE events ADD COLUMN organizer_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_events_organizer_id ON events(organizer_id);
+
+-- Organizers can delete events; ownership is enforced by the event service
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'organizer'
+AND p.name = 'events.delete'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
@@ -0,0 +1 @@
+DELETE FROM permissions WHERE name = 'roles.manage';
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Permission for the role/permission admin API
+INSERT INTO permissions (name, resource, action, description) VALUES
+    ('roles.manage', 'roles', 'manage', 'Manage roles, permissions and role assignments');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin'
+AND p.name = 'roles.manage';
@@ -0,0 +1,14 @@
+-- Payment webhook events
+-- Every accepted webhook is stored under the gateway's event ID, so a
+-- redelivered event is recognised and ignored.
+CREATE TABLE payment_webhook_events (
+    id VARCHAR(255) PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    transaction_id VARCHAR(255),
+    reference VARCHAR(255),
+    payload JSONB NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_payment_webhook_events_reference ON payment_webhook_events(reference);
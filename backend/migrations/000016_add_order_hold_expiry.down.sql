@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_ticket_orders_pending_hold;
+ALTER TABLE ticket_orders DROP COLUMN IF EXISTS hold_expires_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- Pending orders hold their tickets until hold_expires_at; the API's hold
+-- sweeper cancels them and returns the tickets to the event after that.
+ALTER TABLE ticket_orders ADD COLUMN hold_expires_at TIMESTAMP;
+
+-- Indexes
+CREATE INDEX idx_ticket_orders_pending_hold ON ticket_orders(hold_expires_at) WHERE status = 'pending';
@@ -0,0 +1,17 @@
+-- Order status history
+-- One row per order status change, written in the same statement as the
+-- change itself. from_status is NULL for the row recording the order's
+-- creation; changed_by is NULL for changes made by the system (hold sweeper,
+-- payment webhooks).
+CREATE TABLE order_status_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES ticket_orders(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_order_status_history_order_id ON order_status_history(order_id, created_at);
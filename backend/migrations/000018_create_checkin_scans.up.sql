@@ -0,0 +1,23 @@
+-- Check-in scans
+-- One row per gate scan, made online or uploaded later by a scanner that was
+-- offline. Offline scans keep the ID the device gave them, so a re-uploaded
+-- batch is recognised. A ticket has at most one admitted scan, the earliest;
+-- later scans of it are recorded as duplicates.
+CREATE TABLE checkin_scans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    ticket_id UUID REFERENCES tickets(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    validator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    device_id VARCHAR(100),
+    gate VARCHAR(100),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('online', 'offline')),
+    result VARCHAR(20) NOT NULL CHECK (result IN ('admitted', 'duplicate', 'rejected')),
+    reason VARCHAR(50),
+    scanned_at TIMESTAMP NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_checkin_scans_ticket_id ON checkin_scans(ticket_id, scanned_at);
+CREATE INDEX idx_checkin_scans_event_id ON checkin_scans(event_id, scanned_at);
+CREATE UNIQUE INDEX idx_checkin_scans_admission ON checkin_scans(ticket_id) WHERE result = 'admitted';
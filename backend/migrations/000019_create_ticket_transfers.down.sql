@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS ticket_transfers;
+ALTER TABLE ticket_orders DROP COLUMN IF EXISTS source;
+ALTER TABLE events DROP COLUMN IF EXISTS transfers_enabled;
@@ -0,0 +1,20 @@
+-- Ticket transfers
+-- Organizers can turn transfers off per event.
+ALTER TABLE events ADD COLUMN transfers_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- A transferred ticket is replaced by a new ticket on a zero-price order for
+-- the recipient; source tells those orders apart from purchases.
+ALTER TABLE ticket_orders ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'purchase'
+    CHECK (source IN ('purchase', 'transfer'));
+
+-- One row per hand-over. A ticket is handed over at most once and received
+-- at most once, so following the rows gives its ownership chain.
+CREATE TABLE ticket_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    from_ticket_id UUID NOT NULL UNIQUE REFERENCES tickets(id) ON DELETE CASCADE,
+    to_ticket_id UUID NOT NULL UNIQUE REFERENCES tickets(id) ON DELETE CASCADE,
+    from_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    to_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1 @@
+ALTER TABLE events DROP CONSTRAINT IF EXISTS available_not_above_total_check;
\ No newline at end of file
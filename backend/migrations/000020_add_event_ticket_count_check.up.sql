@@ -0,0 +1,3 @@
+-- An event never has more tickets for sale than it has in total
+ALTER TABLE events ADD CONSTRAINT available_not_above_total_check
+    CHECK (available_tickets <= total_tickets);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_ticket_orders_tier_id;
+ALTER TABLE ticket_orders DROP COLUMN IF EXISTS tier_id;
+DROP TABLE IF EXISTS ticket_tiers;
@@ -0,0 +1,30 @@
+-- Ticket tiers (Early Bird, Regular, VIP, ...)
+-- Each tier has its own price and inventory. The event's own counts stay the
+-- venue capacity, so tier capacities add up to at most total_tickets and a
+-- tier purchase takes from both.
+CREATE TABLE ticket_tiers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    price DECIMAL(10,2) NOT NULL,
+    total_tickets INTEGER NOT NULL,
+    available_tickets INTEGER NOT NULL,
+    sales_start TIMESTAMP,
+    sales_end TIMESTAMP,
+    max_per_order INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT ticket_tiers_event_name_key UNIQUE (event_id, name),
+    CONSTRAINT tier_price_check CHECK (price >= 0),
+    CONSTRAINT tier_total_tickets_check CHECK (total_tickets > 0),
+    CONSTRAINT tier_available_tickets_check CHECK (available_tickets >= 0 AND available_tickets <= total_tickets),
+    CONSTRAINT tier_sales_window_check CHECK (sales_end IS NULL OR sales_start IS NULL OR sales_end > sales_start),
+    CONSTRAINT tier_max_per_order_check CHECK (max_per_order IS NULL OR max_per_order > 0)
+);
+
+-- Orders bought from a tier
+ALTER TABLE ticket_orders ADD COLUMN tier_id UUID REFERENCES ticket_tiers(id) ON DELETE RESTRICT;
+
+-- Indexes
+CREATE INDEX idx_ticket_tiers_event_id ON ticket_tiers(event_id);
+CREATE INDEX idx_ticket_orders_tier_id ON ticket_orders(tier_id);
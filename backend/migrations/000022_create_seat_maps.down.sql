@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_events_seat_map_id;
+DROP INDEX IF EXISTS idx_event_seats_order_id;
+DROP INDEX IF EXISTS idx_seats_seat_map_id;
+ALTER TABLE tickets DROP COLUMN IF EXISTS seat_id;
+DROP TABLE IF EXISTS event_seats;
+ALTER TABLE events DROP COLUMN IF EXISTS seat_map_id;
+DROP TABLE IF EXISTS seats;
+DROP TABLE IF EXISTS seat_maps;
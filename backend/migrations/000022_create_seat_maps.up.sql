@@ -0,0 +1,43 @@
+-- Reserved seating
+-- A seat map is a venue's layout: sections, rows and numbered seats. Events
+-- that use one get a copy of its seats in event_seats, and their ticket
+-- counts follow the number of seats.
+CREATE TABLE seat_maps (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    venue VARCHAR(255) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE seats (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seat_map_id UUID NOT NULL REFERENCES seat_maps(id) ON DELETE CASCADE,
+    section VARCHAR(50) NOT NULL,
+    row_label VARCHAR(10) NOT NULL,
+    number INTEGER NOT NULL,
+    CONSTRAINT seats_position_key UNIQUE (seat_map_id, section, row_label, number),
+    CONSTRAINT seat_number_check CHECK (number > 0)
+);
+
+ALTER TABLE events ADD COLUMN seat_map_id UUID REFERENCES seat_maps(id) ON DELETE RESTRICT;
+
+-- Per-event seat inventory. A seat is free while order_id is NULL; holding
+-- it is a single conditional UPDATE, so two orders can never take the same
+-- seat. Deleted orders free their seats.
+CREATE TABLE event_seats (
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    seat_id UUID NOT NULL REFERENCES seats(id) ON DELETE RESTRICT,
+    order_id UUID REFERENCES ticket_orders(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, seat_id)
+);
+
+-- The seat each ticket is for
+ALTER TABLE tickets ADD COLUMN seat_id UUID REFERENCES seats(id) ON DELETE RESTRICT;
+
+-- Indexes
+CREATE INDEX idx_seats_seat_map_id ON seats(seat_map_id);
+CREATE INDEX idx_event_seats_order_id ON event_seats(order_id);
+CREATE INDEX idx_events_seat_map_id ON events(seat_map_id);
@@ -0,0 +1,9 @@
+DELETE FROM permissions WHERE name IN ('venues.create', 'venues.update', 'venues.delete');
+
+ALTER TABLE events ADD COLUMN venue VARCHAR(255);
+UPDATE events e SET venue = v.name FROM venues v WHERE v.id = e.venue_id;
+ALTER TABLE events ALTER COLUMN venue SET NOT NULL;
+DROP INDEX IF EXISTS idx_events_venue_id;
+ALTER TABLE events DROP COLUMN venue_id;
+
+DROP TABLE IF EXISTS venues;
@@ -0,0 +1,47 @@
+-- Venues
+-- Events take place at a venue instead of naming one in free text. The
+-- venue's capacity bounds the event's total tickets.
+CREATE TABLE venues (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    capacity INTEGER NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT venue_capacity_check CHECK (capacity > 0),
+    CONSTRAINT venue_coordinates_check CHECK (
+        (latitude IS NULL) = (longitude IS NULL)
+        AND (latitude IS NULL OR latitude BETWEEN -90 AND 90)
+        AND (longitude IS NULL OR longitude BETWEEN -180 AND 180)
+    )
+);
+
+-- One venue per distinct free-text venue, big enough for its largest event.
+-- Their address, time zone and coordinates have to be filled in by hand.
+INSERT INTO venues (name, capacity)
+SELECT venue, MAX(total_tickets) FROM events GROUP BY venue;
+
+ALTER TABLE events ADD COLUMN venue_id UUID REFERENCES venues(id) ON DELETE RESTRICT;
+UPDATE events e SET venue_id = v.id FROM venues v WHERE v.name = e.venue;
+ALTER TABLE events ALTER COLUMN venue_id SET NOT NULL;
+ALTER TABLE events DROP COLUMN venue;
+
+-- Indexes
+CREATE INDEX idx_events_venue_id ON events(venue_id);
+
+-- Admins and organizers manage venues; organizers only the ones they created,
+-- which the venue service enforces
+INSERT INTO permissions (name, resource, action, description) VALUES
+    ('venues.create', 'venues', 'create', 'Create venues'),
+    ('venues.update', 'venues', 'update', 'Update venues'),
+    ('venues.delete', 'venues', 'delete', 'Delete venues');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('admin', 'organizer')
+AND p.name IN ('venues.create', 'venues.update', 'venues.delete');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_events_ticket_price_id;
+DROP INDEX IF EXISTS idx_events_event_date_id;
+CREATE INDEX idx_events_event_date ON events(event_date);
@@ -0,0 +1,7 @@
+-- Event listing
+-- Listings sort by date or price with id as the tie-breaker, and cursor
+-- pages continue after a (value, id) pair, so both sorts get a composite
+-- index. The date one replaces the single-column index.
+DROP INDEX IF EXISTS idx_events_event_date;
+CREATE INDEX idx_events_event_date_id ON events(event_date, id);
+CREATE INDEX idx_events_ticket_price_id ON events(ticket_price, id);
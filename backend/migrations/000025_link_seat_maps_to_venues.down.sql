@@ -0,0 +1,5 @@
+ALTER TABLE seat_maps ADD COLUMN venue VARCHAR(255);
+UPDATE seat_maps sm SET venue = v.name FROM venues v WHERE v.id = sm.venue_id;
+ALTER TABLE seat_maps ALTER COLUMN venue SET NOT NULL;
+DROP INDEX IF EXISTS idx_seat_maps_venue_id;
+ALTER TABLE seat_maps DROP COLUMN venue_id;
@@ -0,0 +1,47 @@
+-- Seat maps belong to a venue
+-- A seat map named its venue in free text, so a map of one venue could be
+-- assigned to an event at another. It now references the venue instead.
+ALTER TABLE seat_maps ADD COLUMN venue_id UUID REFERENCES venues(id) ON DELETE RESTRICT;
+
+-- A map already in use belongs to the venue of its earliest event
+UPDATE seat_maps sm SET venue_id = used.venue_id
+FROM (
+    SELECT DISTINCT ON (seat_map_id) seat_map_id, venue_id
+    FROM events
+    WHERE seat_map_id IS NOT NULL
+    ORDER BY seat_map_id, event_date, id
+) used
+WHERE used.seat_map_id = sm.id;
+
+-- Otherwise it belongs to the venue of the same name
+UPDATE seat_maps sm SET venue_id = (
+    SELECT v.id FROM venues v WHERE v.name = sm.venue ORDER BY v.created_at, v.id LIMIT 1
+)
+WHERE sm.venue_id IS NULL;
+
+-- Unknown venues are created like migration 000023 did, sized for the map;
+-- their address, time zone and coordinates have to be filled in by hand
+INSERT INTO venues (name, capacity)
+SELECT sm.venue, GREATEST(MAX(seat_counts.seats), 1)
+FROM seat_maps sm
+JOIN (SELECT seat_map_id, COUNT(*) AS seats FROM seats GROUP BY seat_map_id) seat_counts
+    ON seat_counts.seat_map_id = sm.id
+WHERE sm.venue_id IS NULL
+GROUP BY sm.venue;
+
+INSERT INTO venues (name, capacity)
+SELECT DISTINCT sm.venue, 1
+FROM seat_maps sm
+WHERE sm.venue_id IS NULL
+AND NOT EXISTS (SELECT 1 FROM venues v WHERE v.name = sm.venue);
+
+UPDATE seat_maps sm SET venue_id = (
+    SELECT v.id FROM venues v WHERE v.name = sm.venue ORDER BY v.created_at, v.id LIMIT 1
+)
+WHERE sm.venue_id IS NULL;
+
+ALTER TABLE seat_maps ALTER COLUMN venue_id SET NOT NULL;
+ALTER TABLE seat_maps DROP COLUMN venue;
+
+-- Indexes
+CREATE INDEX idx_seat_maps_venue_id ON seat_maps(venue_id);
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type Claims struct {
@@ -14,29 +15,34 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// GenerateToken signs an HS256 token with a shared secret.
+// Prefer KeySet.GenerateToken; this is kept for local development and tests.
 func GenerateToken(userID, email string, roles []string, secret string, expiry time.Duration) (string, error) {
-	claims := Claims{
+	return NewHMACKeySet(secret).GenerateToken(userID, email, roles, expiry)
+}
+
+// ValidateToken verifies an HS256 token signed with a shared secret.
+// Prefer KeySet.ValidateToken; this is kept for local development and tests.
+func ValidateToken(tokenString, secret string) (*Claims, error) {
+	return NewHMACKeySet(secret).ValidateToken(tokenString)
+}
+
+func newClaims(userID, email string, roles []string, expiry time.Duration) Claims {
+	now := time.Now()
+	return Claims{
 		UserID: userID,
 		Email:  email,
 		Roles:  roles,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        uuid.New().String(), // jti, used for revocation
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
 }
 
-func ValidateToken(tokenString, secret string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(secret), nil
-	})
-
+func parseClaims(tokenString string, keyFunc jwt.Keyfunc) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keyFunc)
 	if err != nil {
 		return nil, err
 	}
@@ -46,4 +52,4 @@ func ValidateToken(tokenString, secret string) (*Claims, error) {
 	}
 
 	return nil, errors.New("invalid token")
-}
\ No newline at end of file
+}
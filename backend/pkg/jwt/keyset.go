@@ -0,0 +1,231 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported asymmetric algorithms
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+const minRSAKeyBits = 2048
+
+// Key is a signing or verification key identified by kid.
+// Verification-only keys (previous signing keys kept during rotation) have no private part.
+type Key struct {
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+}
+
+// KeySet signs tokens with one active key and verifies tokens signed by any key
+// in the set, selected by the "kid" header. Without asymmetric keys it falls
+// back to HS256 with a shared secret.
+type KeySet struct {
+	active     *Key
+	keys       map[string]*Key
+	ordered    []*Key // active key first, for a stable JWKS
+	hmacSecret []byte
+}
+
+// NewHMACKeySet creates a key set that signs and verifies HS256 tokens with a shared secret
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{hmacSecret: []byte(secret)}
+}
+
+// NewKeySet creates a key set from an active signing key and older keys that are
+// still accepted for verification. Key IDs must be unique.
+func NewKeySet(active *Key, verifyOnly ...*Key) (*KeySet, error) {
+	if active == nil || active.Private == nil {
+		return nil, errors.New("active key must have a private key")
+	}
+
+	ks := &KeySet{
+		active:  active,
+		keys:    map[string]*Key{active.ID: active},
+		ordered: []*Key{active},
+	}
+	for _, key := range verifyOnly {
+		if _, exists := ks.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		ks.keys[key.ID] = key
+		ks.ordered = append(ks.ordered, key)
+	}
+	return ks, nil
+}
+
+// LoadKeySet reads the active private key and any previous keys from PEM files.
+// Previous key files may hold either a public or a private key.
+func LoadKeySet(privateKeyFile string, previousKeyFiles []string) (*KeySet, error) {
+	active, err := LoadKeyFile(privateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make([]*Key, 0, len(previousKeyFiles))
+	for _, file := range previousKeyFiles {
+		key, err := LoadKeyFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key.Private = nil // only ever used for verification
+		previous = append(previous, key)
+	}
+
+	return NewKeySet(active, previous...)
+}
+
+// LoadKeyFile reads an RSA or Ed25519 key from a PEM file. The key ID is the
+// RFC 7638 JWK thumbprint of the public key, so it is stable across restarts
+// and hosts without any naming convention.
+func LoadKeyFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	key, err := parsePEMBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// GenerateEd25519Key creates an in-memory Ed25519 signing key, for
+// development setups without a key file. Tokens signed with it stop
+// verifying once the process exits.
+func GenerateEd25519Key() (*Key, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newKey(priv)
+}
+
+func parsePEMBlock(block *pem.Block) (*Key, error) {
+	var parsed interface{}
+	var err error
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newKey(parsed)
+}
+
+func newKey(parsed interface{}) (*Key, error) {
+	key := &Key{}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Algorithm, key.Private, key.Public = AlgRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Algorithm, key.Public = AlgRS256, k
+	case ed25519.PrivateKey:
+		key.Algorithm, key.Private, key.Public = AlgEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Algorithm, key.Public = AlgEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T (want RSA or Ed25519)", parsed)
+	}
+
+	if pub, ok := key.Public.(*rsa.PublicKey); ok && pub.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("RSA key must be at least %d bits", minRSAKeyBits)
+	}
+
+	kid, err := thumbprint(key.Public)
+	if err != nil {
+		return nil, err
+	}
+	key.ID = kid
+
+	return key, nil
+}
+
+// GenerateToken signs a token with the active key and sets its kid header
+func (ks *KeySet) GenerateToken(userID, email string, roles []string, expiry time.Duration) (string, error) {
+	claims := newClaims(userID, email, roles, expiry)
+
+	if ks.active == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(ks.hmacSecret)
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(ks.active.Algorithm), claims)
+	token.Header["kid"] = ks.active.ID
+	return token.SignedString(ks.active.Private)
+}
+
+// ValidateToken verifies a token against the key named by its kid header.
+// The algorithm must match the key's own algorithm, so a token cannot pick a
+// weaker method (e.g. HS256 keyed with a public key).
+func (ks *KeySet) ValidateToken(tokenString string) (*Claims, error) {
+	return parseClaims(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if ks.active == nil {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, errors.New("unexpected signing method")
+			}
+			return ks.hmacSecret, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		key, ok := ks.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		if token.Method.Alg() != key.Algorithm {
+			return nil, errors.New("unexpected signing method")
+		}
+		return key.Public, nil
+	})
+}
+
+// ActiveKeyID returns the kid of the signing key, or "" in HS256 mode
+func (ks *KeySet) ActiveKeyID() string {
+	if ks.active == nil {
+		return ""
+	}
+	return ks.active.ID
+}
+
+// JWKS returns the public half of every key in the set. It is empty in HS256
+// mode since a shared secret must never be published.
+func (ks *KeySet) JWKS() *JWKS {
+	set := &JWKS{Keys: []JWK{}}
+	for _, key := range ks.ordered {
+		set.Keys = append(set.Keys, toJWK(key))
+	}
+	return set
+}
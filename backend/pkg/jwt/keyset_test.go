@@ -0,0 +1,274 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEd25519Key(t *testing.T) *Key {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := newKey(priv)
+	require.NoError(t, err)
+	return key
+}
+
+func newRSAKey(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	return priv
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+// TestKeySet_SignAndVerify
+// Summary: Signs and verifies tokens with RS256 and EdDSA keys.
+// Purpose: Ensures both asymmetric algorithms round-trip claims and set the kid header.
+func TestKeySet_SignAndVerify(t *testing.T) {
+	rsaKey, err := newKey(newRSAKey(t, 2048))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		key  *Key
+		alg  string
+	}{
+		{name: "RS256", key: rsaKey, alg: AlgRS256},
+		{name: "EdDSA", key: newEd25519Key(t), alg: AlgEdDSA},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := NewKeySet(tt.key)
+			require.NoError(t, err)
+
+			token, err := ks.GenerateToken("user-1", "user@example.com", []string{"user"}, time.Minute)
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.alg, parsed.Method.Alg())
+			assert.Equal(t, ks.ActiveKeyID(), parsed.Header["kid"])
+
+			claims, err := ks.ValidateToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", claims.UserID)
+			assert.Equal(t, []string{"user"}, claims.Roles)
+			assert.NotEmpty(t, claims.ID)
+		})
+	}
+}
+
+// TestKeySet_Rotation
+// Summary: Verifies tokens across a signing key rotation.
+// Purpose: Ensures tokens from the previous key stay valid while unknown keys are rejected.
+func TestKeySet_Rotation(t *testing.T) {
+	oldKey := newEd25519Key(t)
+	currentKey := newEd25519Key(t)
+	strangerKey := newEd25519Key(t)
+
+	before, err := NewKeySet(oldKey)
+	require.NoError(t, err)
+	stranger, err := NewKeySet(strangerKey)
+	require.NoError(t, err)
+
+	oldPublic := *oldKey
+	oldPublic.Private = nil
+	after, err := NewKeySet(currentKey, &oldPublic)
+	require.NoError(t, err)
+
+	oldToken, err := before.GenerateToken("user-1", "user@example.com", nil, time.Minute)
+	require.NoError(t, err)
+	newToken, err := after.GenerateToken("user-1", "user@example.com", nil, time.Minute)
+	require.NoError(t, err)
+	strangerToken, err := stranger.GenerateToken("user-1", "user@example.com", nil, time.Minute)
+	require.NoError(t, err)
+
+	_, err = after.ValidateToken(oldToken)
+	assert.NoError(t, err, "token signed before rotation should still verify")
+
+	_, err = after.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	_, err = before.ValidateToken(newToken)
+	assert.Error(t, err, "old set does not know the new key")
+
+	_, err = after.ValidateToken(strangerToken)
+	assert.Error(t, err)
+
+	_, err = NewKeySet(currentKey, currentKey)
+	assert.Error(t, err, "duplicate kid must be rejected")
+
+	_, err = NewKeySet(&oldPublic)
+	assert.Error(t, err, "active key needs a private part")
+}
+
+// TestKeySet_RejectsAlgorithmConfusion
+// Summary: Rejects tokens whose algorithm differs from the key's algorithm.
+// Purpose: Ensures an HS256 token keyed with the published public key, or an unsigned token, cannot pass.
+func TestKeySet_RejectsAlgorithmConfusion(t *testing.T) {
+	priv := newRSAKey(t, 2048)
+	key, err := newKey(priv)
+	require.NoError(t, err)
+	ks, err := NewKeySet(key)
+	require.NoError(t, err)
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	claims := newClaims("user-1", "user@example.com", []string{"admin"}, time.Minute)
+
+	hsToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hsToken.Header["kid"] = key.ID
+	forged, err := hsToken.SignedString(pubPEM)
+	require.NoError(t, err)
+
+	noneToken := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	noneToken.Header["kid"] = key.ID
+	unsigned, err := noneToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	_, err = ks.ValidateToken(forged)
+	assert.Error(t, err)
+
+	_, err = ks.ValidateToken(unsigned)
+	assert.Error(t, err)
+
+	// An HS256 key set must not accept asymmetric tokens either
+	rsToken, err := ks.GenerateToken("user-1", "user@example.com", nil, time.Minute)
+	require.NoError(t, err)
+	_, err = NewHMACKeySet("secret").ValidateToken(rsToken)
+	assert.Error(t, err)
+}
+
+// TestLoadKeySet
+// Summary: Loads signing and previous keys from PEM files.
+// Purpose: Ensures supported PEM encodings load and weak or unsupported keys are refused.
+func TestLoadKeySet(t *testing.T) {
+	rsaPriv := newRSAKey(t, 2048)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(rsaPriv)
+	require.NoError(t, err)
+
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPKCS8, err := x509.MarshalPKCS8PrivateKey(edPriv)
+	require.NoError(t, err)
+	edPub, err := x509.MarshalPKIXPublicKey(edPriv.Public())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		privateFile string
+		previous    []string
+		expectAlg   string
+		expectKeys  int
+		expectErr   bool
+	}{
+		{
+			name:        "ed25519 pkcs8",
+			privateFile: writePEM(t, "PRIVATE KEY", edPKCS8),
+			expectAlg:   AlgEdDSA,
+			expectKeys:  1,
+		},
+		{
+			name:        "rsa pkcs1 with previous public key",
+			privateFile: writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPriv)),
+			previous:    []string{writePEM(t, "PUBLIC KEY", edPub)},
+			expectAlg:   AlgRS256,
+			expectKeys:  2,
+		},
+		{
+			name:        "rsa pkcs8",
+			privateFile: writePEM(t, "PRIVATE KEY", pkcs8),
+			expectAlg:   AlgRS256,
+			expectKeys:  1,
+		},
+		{
+			name:        "rsa key too small",
+			privateFile: writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(newRSAKey(t, 1024))),
+			expectErr:   true,
+		},
+		{
+			name:        "public key as active key",
+			privateFile: writePEM(t, "PUBLIC KEY", edPub),
+			expectErr:   true,
+		},
+		{
+			name:        "unsupported block type",
+			privateFile: writePEM(t, "CERTIFICATE", []byte("junk")),
+			expectErr:   true,
+		},
+		{
+			name:        "missing file",
+			privateFile: filepath.Join(t.TempDir(), "missing.pem"),
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := LoadKeySet(tt.privateFile, tt.previous)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			jwks := ks.JWKS()
+			assert.Len(t, jwks.Keys, tt.expectKeys)
+			assert.Equal(t, tt.expectAlg, jwks.Keys[0].Alg)
+			assert.Equal(t, ks.ActiveKeyID(), jwks.Keys[0].Kid)
+		})
+	}
+}
+
+// TestKeySet_JWKS
+// Summary: Publishes public keys in JWK format.
+// Purpose: Ensures the kid is the RFC 7638 thumbprint and no secret material is published.
+func TestKeySet_JWKS(t *testing.T) {
+	// Example key from RFC 7638 section 3.1
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	require.NoError(t, err)
+	kid, err := thumbprint(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537})
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", kid)
+
+	active := newEd25519Key(t)
+	ks, err := NewKeySet(active)
+	require.NoError(t, err)
+
+	jwks := ks.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, JWK{
+		Kty: "OKP",
+		Kid: active.ID,
+		Use: "sig",
+		Alg: AlgEdDSA,
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(active.Public.(ed25519.PublicKey)),
+	}, jwks.Keys[0])
+
+	assert.Empty(t, NewHMACKeySet("secret").JWKS().Keys)
+}
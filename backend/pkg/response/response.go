@@ -22,4 +22,14 @@ func Error(c *gin.Context, statusCode int, message string) {
 		Success: false,
 		Error:   message,
 	})
-}
\ No newline at end of file
+}
+
+// ErrorWithData reports a failure that still carries a payload the client
+// needs, such as the details behind a refusal
+func ErrorWithData(c *gin.Context, statusCode int, message string, data interface{}) {
+	c.JSON(statusCode, APIResponse{
+		Success: false,
+		Data:    data,
+		Error:   message,
+	})
+}
@@ -0,0 +1,241 @@
+// Package ticketpass issues and verifies the signed payloads encoded in
+// ticket QR codes. Passes are Ed25519-signed, so a gate scanner holding only
+// the public keys can check them without reaching the API. The same keys sign
+// the ticket snapshots scanners download for working offline.
+//
+// A pass is "TP1.<key hint>.<claims>.<signature>": the hint is the first
+// characters of the signing key's kid, the claims are base64url JSON and the
+// signature covers everything before it. Ed25519 keeps the signature at 64
+// bytes, so the QR code stays small enough to scan from a phone screen.
+package ticketpass
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
+)
+
+const (
+	version         = "TP1"
+	documentVersion = "TD1" // signed documents; keeps them from passing as passes
+	keyHintLength   = 8
+)
+
+var (
+	// ErrMalformed is returned for a string that is not a ticket pass
+	ErrMalformed = errors.New("malformed ticket pass")
+	// ErrUnknownKey is returned when no known key matches the pass's key hint
+	ErrUnknownKey = errors.New("ticket pass signed with an unknown key")
+	// ErrBadSignature is returned when the signature does not match the claims
+	ErrBadSignature = errors.New("invalid ticket pass signature")
+	// ErrExpired is returned for a pass past its expiry
+	ErrExpired = errors.New("ticket pass has expired")
+)
+
+// Claims are what a pass vouches for
+type Claims struct {
+	TicketID  string `json:"tid"`
+	EventID   string `json:"eid"`
+	ExpiresAt int64  `json:"exp"` // unix seconds
+}
+
+// Signer issues passes with one active Ed25519 key and verifies passes signed
+// by it or by a previous key kept for rotation
+type Signer struct {
+	active *jwtutil.Key
+	keys   *jwtutil.KeySet
+	verify *Verifier
+}
+
+// NewSigner creates a signer from an active Ed25519 key and older keys whose
+// passes are still accepted
+func NewSigner(active *jwtutil.Key, previous ...*jwtutil.Key) (*Signer, error) {
+	all := append([]*jwtutil.Key{active}, previous...)
+	for _, key := range all {
+		if key.Algorithm != jwtutil.AlgEdDSA {
+			return nil, fmt.Errorf("ticket pass keys must be Ed25519, got %s", key.Algorithm)
+		}
+	}
+
+	keys, err := jwtutil.NewKeySet(active, previous...)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := NewVerifier(all...)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{active: active, keys: keys, verify: verifier}, nil
+}
+
+// LoadSigner reads the active private key and any previous keys from PEM files
+func LoadSigner(privateKeyFile string, previousKeyFiles []string) (*Signer, error) {
+	active, err := jwtutil.LoadKeyFile(privateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make([]*jwtutil.Key, 0, len(previousKeyFiles))
+	for _, file := range previousKeyFiles {
+		key, err := jwtutil.LoadKeyFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key.Private = nil
+		previous = append(previous, key)
+	}
+	return NewSigner(active, previous...)
+}
+
+// Sign encodes and signs claims
+func (s *Signer) Sign(claims Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := version + "." + keyHint(s.active.ID) + "." + b64(payload)
+	signature := ed25519.Sign(s.active.Private.(ed25519.PrivateKey), []byte(signed))
+	return signed + "." + b64(signature), nil
+}
+
+// SignedDocument is a signed JSON payload, such as a scanner's ticket
+// snapshot. The signature covers "TD1.<key>.<payload>".
+type SignedDocument struct {
+	KeyHint   string `json:"key"`
+	Payload   string `json:"payload"`   // base64url JSON
+	Signature string `json:"signature"` // base64url Ed25519
+}
+
+// SignDocument encodes and signs v
+func (s *Signer) SignDocument(v any) (*SignedDocument, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &SignedDocument{KeyHint: keyHint(s.active.ID), Payload: b64(payload)}
+	signature := ed25519.Sign(s.active.Private.(ed25519.PrivateKey), []byte(doc.signedPart()))
+	doc.Signature = b64(signature)
+	return doc, nil
+}
+
+func (d *SignedDocument) signedPart() string {
+	return documentVersion + "." + d.KeyHint + "." + d.Payload
+}
+
+// Verifier returns a verifier for every key of the signer
+func (s *Signer) Verifier() *Verifier {
+	return s.verify
+}
+
+// JWKS returns the public keys scanners need to verify passes offline
+func (s *Signer) JWKS() *jwtutil.JWKS {
+	return s.keys.JWKS()
+}
+
+// Verifier checks passes against a set of public keys
+type Verifier struct {
+	keys map[string]ed25519.PublicKey // by key hint
+}
+
+// NewVerifier creates a verifier for the given Ed25519 keys
+func NewVerifier(keys ...*jwtutil.Key) (*Verifier, error) {
+	v := &Verifier{keys: make(map[string]ed25519.PublicKey, len(keys))}
+	for _, key := range keys {
+		pub, ok := key.Public.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("ticket pass keys must be Ed25519, got %T", key.Public)
+		}
+		hint := keyHint(key.ID)
+		if _, exists := v.keys[hint]; exists {
+			return nil, fmt.Errorf("duplicate ticket pass key hint %q", hint)
+		}
+		v.keys[hint] = pub
+	}
+	return v, nil
+}
+
+// Verify checks the pass's signature and expiry and returns its claims
+func (v *Verifier) Verify(pass string, now time.Time) (*Claims, error) {
+	parts := strings.Split(pass, ".")
+	if len(parts) != 4 || parts[0] != version {
+		return nil, ErrMalformed
+	}
+
+	pub, ok := v.keys[parts[1]]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	signed := pass[:len(pass)-len(parts[3])-1]
+	if !ed25519.Verify(pub, []byte(signed), signature) {
+		return nil, ErrBadSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrMalformed
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpired
+	}
+	return &claims, nil
+}
+
+// VerifyDocument checks the document's signature and decodes its payload into v
+func (v *Verifier) VerifyDocument(doc *SignedDocument, dst any) error {
+	pub, ok := v.keys[doc.KeyHint]
+	if !ok {
+		return ErrUnknownKey
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(doc.Signature)
+	if err != nil {
+		return ErrMalformed
+	}
+	if !ed25519.Verify(pub, []byte(doc.signedPart()), signature) {
+		return ErrBadSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(doc.Payload)
+	if err != nil {
+		return ErrMalformed
+	}
+	if err := json.Unmarshal(payload, dst); err != nil {
+		return ErrMalformed
+	}
+	return nil
+}
+
+// HashCode returns the hex SHA-256 of a ticket code. Snapshots carry hashes,
+// so a lost scanner does not leak codes that can be typed in at a gate.
+func HashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func keyHint(kid string) string {
+	if len(kid) <= keyHintLength {
+		return kid
+	}
+	return kid[:keyHintLength]
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
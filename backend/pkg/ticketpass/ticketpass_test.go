@@ -0,0 +1,124 @@
+package ticketpass
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	jwtutil "github.com/baramulti/ticketing-system/backend/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T, previous ...*jwtutil.Key) *Signer {
+	t.Helper()
+	key, err := jwtutil.GenerateEd25519Key()
+	require.NoError(t, err)
+	signer, err := NewSigner(key, previous...)
+	require.NoError(t, err)
+	return signer
+}
+
+// TestSigner_RoundTrip
+// Summary: Signing and verifying ticket passes
+// Purpose: Verify a pass round-trips its claims, and tampering, expiry and foreign keys are rejected
+func TestSigner_RoundTrip(t *testing.T) {
+	now := time.Now()
+	signer := newTestSigner(t)
+	claims := Claims{TicketID: "ticket-001", EventID: "event-001", ExpiresAt: now.Add(time.Hour).Unix()}
+
+	pass, err := signer.Sign(claims)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pass, "TP1."))
+
+	got, err := signer.Verifier().Verify(pass, now)
+	require.NoError(t, err)
+	assert.Equal(t, claims, *got)
+
+	tests := []struct {
+		name string
+		pass string
+		now  time.Time
+		want error
+	}{
+		{name: "expired", pass: pass, now: now.Add(2 * time.Hour), want: ErrExpired},
+		{name: "not a pass", pass: "TKT-0001", now: now, want: ErrMalformed},
+		{name: "wrong version", pass: "TP0" + pass[3:], now: now, want: ErrMalformed},
+		{name: "claims swapped", pass: swapClaims(t, signer, pass, Claims{TicketID: "ticket-002", EventID: "event-001", ExpiresAt: claims.ExpiresAt}), now: now, want: ErrBadSignature},
+		{name: "signed by another key", pass: signWith(t, newTestSigner(t), claims), now: now, want: ErrUnknownKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Verifier().Verify(tt.pass, tt.now)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+// TestSigner_Rotation
+// Summary: Key rotation for ticket passes
+// Purpose: Verify passes from a previous key still verify and both public keys are published
+func TestSigner_Rotation(t *testing.T) {
+	old := newTestSigner(t)
+	claims := Claims{TicketID: "ticket-001", EventID: "event-001", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	oldPass := signWith(t, old, claims)
+
+	oldKey := *old.active
+	oldKey.Private = nil
+	current := newTestSigner(t, &oldKey)
+
+	_, err := current.Verifier().Verify(oldPass, time.Now())
+	assert.NoError(t, err)
+	assert.Len(t, current.JWKS().Keys, 2)
+
+	rsaOnly := &jwtutil.Key{ID: "rsa", Algorithm: jwtutil.AlgRS256}
+	_, err = NewSigner(current.active, rsaOnly)
+	assert.Error(t, err, "only Ed25519 keys can sign passes")
+}
+
+// TestSigner_Document
+// Summary: Signing and verifying snapshot documents
+// Purpose: Verify a document round-trips, edits to its payload are caught, and a pass signature cannot be reused for a document
+func TestSigner_Document(t *testing.T) {
+	type snapshot struct {
+		EventID string   `json:"event_id"`
+		Codes   []string `json:"codes"`
+	}
+	signer := newTestSigner(t)
+	want := snapshot{EventID: "event-001", Codes: []string{HashCode("TKT-0001")}}
+
+	doc, err := signer.SignDocument(want)
+	require.NoError(t, err)
+
+	var got snapshot
+	require.NoError(t, signer.Verifier().VerifyDocument(doc, &got))
+	assert.Equal(t, want, got)
+
+	tampered := *doc
+	tampered.Payload = b64([]byte(`{"event_id":"event-001","codes":[]}`))
+	assert.ErrorIs(t, signer.Verifier().VerifyDocument(&tampered, &got), ErrBadSignature)
+
+	pass := strings.Split(signWith(t, signer, Claims{TicketID: "ticket-001", ExpiresAt: time.Now().Add(time.Hour).Unix()}), ".")
+	asDoc := &SignedDocument{KeyHint: pass[1], Payload: pass[2], Signature: pass[3]}
+	assert.ErrorIs(t, signer.Verifier().VerifyDocument(asDoc, &got), ErrBadSignature)
+
+	other, err := newTestSigner(t).SignDocument(want)
+	require.NoError(t, err)
+	assert.ErrorIs(t, signer.Verifier().VerifyDocument(other, &got), ErrUnknownKey)
+}
+
+func signWith(t *testing.T, signer *Signer, claims Claims) string {
+	t.Helper()
+	pass, err := signer.Sign(claims)
+	require.NoError(t, err)
+	return pass
+}
+
+// swapClaims keeps the pass's signature but replaces its claims
+func swapClaims(t *testing.T, signer *Signer, pass string, claims Claims) string {
+	t.Helper()
+	forged := strings.Split(signWith(t, signer, claims), ".")
+	parts := strings.Split(pass, ".")
+	parts[2] = forged[2]
+	return strings.Join(parts, ".")
+}
@@ -1,15 +1,44 @@
 package validator
 
 import (
+	"regexp"
+
 	"github.com/go-playground/validator/v10"
 )
 
 var validate *validator.Validate
 
+var (
+	// permissionNamePattern is the resource.action format, e.g. events.create
+	permissionNamePattern = regexp.MustCompile(`^[a-z][a-z_]*\.[a-z][a-z_]*$`)
+
+	// roleNamePattern keeps role names usable in URLs and logs, e.g. gate_staff
+	roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+)
+
 func init() {
 	validate = validator.New()
-	// TODO: register custom validators here
-	// validate.RegisterValidation("custom_tag", customValidatorFunc)
+	if err := RegisterCustom(validate); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterCustom adds the project's custom tags to a validator instance.
+// The router calls it for gin's binding validator so `binding` tags can use them.
+func RegisterCustom(v *validator.Validate) error {
+	if err := v.RegisterValidation("permission_name", func(fl validator.FieldLevel) bool {
+		return IsPermissionName(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+	return v.RegisterValidation("role_name", func(fl validator.FieldLevel) bool {
+		return roleNamePattern.MatchString(fl.Field().String())
+	})
+}
+
+// IsPermissionName reports whether name follows the resource.action format
+func IsPermissionName(name string) bool {
+	return permissionNamePattern.MatchString(name)
 }
 
 func Validate(data interface{}) error {
@@ -18,4 +47,4 @@ func Validate(data interface{}) error {
 
 func ValidateVar(field interface{}, tag string) error {
 	return validate.Var(field, tag)
-}
\ No newline at end of file
+}
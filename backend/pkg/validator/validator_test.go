@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPermissionName
+// Summary: Validates permission names against the resource.action format
+// Purpose: Ensures only lowercase resource.action names are accepted by the permission_name tag
+func TestPermissionName(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{name: "seeded permission", value: "events.create", valid: true},
+		{name: "underscores", value: "ticket_tiers.read_sales", valid: true},
+		{name: "missing action", value: "events", valid: false},
+		{name: "empty action", value: "events.", valid: false},
+		{name: "three segments", value: "events.sales.read", valid: false},
+		{name: "uppercase", value: "Events.Create", valid: false},
+		{name: "wildcard", value: "events.*", valid: false},
+		{name: "whitespace", value: "events. create", valid: false},
+		{name: "empty", value: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.valid, IsPermissionName(tt.value))
+
+			err := ValidateVar(tt.value, "permission_name")
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -64,7 +64,7 @@ export const EventCard: React.FC<EventCardProps> = ({ event, onSelectEvent }) =>
         <div>
           <span style={{ color: '#666', fontSize: '13px' }}>📍 Location:</span>
           <p style={{ margin: '5px 0 0 0', fontWeight: '500' }}>
-            {event.venue}
+            {event.venue?.name}
           </p>
         </div>
 
@@ -1,9 +1,20 @@
+export interface Venue {
+  id: string;
+  name: string;
+  address: string;
+  time_zone: string;
+  capacity: number;
+  latitude?: number;
+  longitude?: number;
+}
+
 export interface Event {
   id: string;
   title: string;
   description: string;
   event_date: string;
-  venue: string;
+  venue_id: string;
+  venue?: Venue;
   ticket_price: number;
   total_tickets: number;
   available_tickets: number;
@@ -14,5 +25,7 @@ export interface Event {
 export interface EventListResponse {
   events: Event[];
   total: number;
-  page: number;
+  page?: number;
+  page_size: number;
+  next_cursor?: string;
 }
\ No newline at end of file